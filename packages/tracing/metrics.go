@@ -0,0 +1,75 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/metric"
+	metricSdk "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
+)
+
+type NewMeterProviderArgs struct {
+	CollectorURL     string
+	ServiceName      string
+	ServiceNamespace string
+	ServiceVersion   string
+	// How often the metrics are exported. Defaults to a minute
+	Interval time.Duration
+}
+
+// InitMetrics exports the metrics to the collector and registers the
+// provider globally, so GetMeter returns meters bound to it.
+func InitMetrics(ctx context.Context, args NewMeterProviderArgs) (*metricSdk.MeterProvider, error) {
+	if args.CollectorURL == "" {
+		return nil, fmt.Errorf("OTEL collector URL is required")
+	}
+
+	options := []otlpmetricgrpc.Option{
+		otlpmetricgrpc.WithEndpoint(args.CollectorURL),
+	}
+
+	if !strings.Contains(args.CollectorURL, "grpcs://") {
+		options = append(options, otlpmetricgrpc.WithInsecure())
+	}
+
+	exporter, err := otlpmetricgrpc.New(ctx, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	interval := args.Interval
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	resource := resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(args.ServiceName),
+		semconv.ServiceVersion(args.ServiceVersion),
+		semconv.ServiceNamespace(args.ServiceNamespace),
+		semconv.TelemetrySDKLanguageGo,
+		semconv.TelemetrySDKNameKey.String("opentelemetry"),
+	)
+
+	meterProvider := metricSdk.NewMeterProvider(
+		metricSdk.WithReader(metricSdk.NewPeriodicReader(exporter, metricSdk.WithInterval(interval))),
+		metricSdk.WithResource(resource),
+	)
+
+	otel.SetMeterProvider(meterProvider)
+	return meterProvider, nil
+}
+
+func GetMeter(name string) metric.Meter {
+	if name == "" {
+		return otel.Meter("github.com/lopesgabriel/tellawl")
+	}
+
+	return otel.Meter(name)
+}
@@ -9,10 +9,24 @@ import (
 type MembersRepository interface {
 	FindByID(ctx context.Context, id string) (*models.Member, error)
 	FindByEmail(ctx context.Context, email string) (*models.Member, error)
+	// List returns up to filter.Limit members matching the filter ordered by
+	// id, and how many members match it across all pages
+	List(ctx context.Context, filter MembersFilter) ([]models.Member, int, error)
 	Upsert(ctx context.Context, member *models.Member) error
 	Close() error
 }
 
+// MembersFilter selects the members with any of the ids or emails, every
+// member when both are empty.
+type MembersFilter struct {
+	Ids    []string
+	Emails []string
+	// Only members with a greater id are returned, empty starts from the
+	// first one
+	After string
+	Limit int
+}
+
 type Repositories struct {
 	Members MembersRepository
 }
@@ -5,9 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/gorilla/mux"
-	"github.com/lopesgabriel/tellawl/services/member-service/internal/domain/models"
+	"github.com/lopesgabriel/tellawl/services/member-service/internal/domain/repository"
 	"github.com/lopesgabriel/tellawl/services/member-service/internal/infra/database"
 	"go.opentelemetry.io/otel/codes"
 )
@@ -41,31 +43,64 @@ func (h *apiHandler) HandleGetMemberByID(w http.ResponseWriter, r *http.Request)
 	return nil
 }
 
+const (
+	defaultMembersPageSize = 100
+	maxMembersPageSize     = 500
+	// How many ids and emails, together, a single request may look up
+	maxMembersFilterValues = 500
+)
+
+// HandleListMembers pages through the members with any of the id and email
+// query parameters, every member when none is given. Both parameters may be
+// repeated or hold comma separated values. Members are ordered by id and the
+// next page starts after the id returned in meta.next, which is null on the
+// last page. meta.total counts the matching members across all pages.
 func (h *apiHandler) HandleListMembers(w http.ResponseWriter, r *http.Request) error {
 	ctx, span := h.tracer.Start(r.Context(), "HandleListMembers")
 	defer span.End()
 
-	members := []models.Member{}
+	query := r.URL.Query()
+	filter := repository.MembersFilter{
+		Ids:    splitQueryValues(query["id"]),
+		Emails: splitQueryValues(query["email"]),
+		After:  query.Get("after"),
+		Limit:  defaultMembersPageSize,
+	}
 
-	if email := r.URL.Query().Get("email"); email != "" {
-		member, err := h.repositories.Members.FindByEmail(ctx, email)
-		if err != nil {
-			if errors.Is(err, database.ErrNotFound) {
-				span.SetStatus(codes.Error, "Member not found")
-				return NewNotFoundError(ctx, fmt.Sprintf("Member '%s' not found", email))
-			}
+	if len(filter.Ids)+len(filter.Emails) > maxMembersFilterValues {
+		span.SetStatus(codes.Error, "Too many filter values")
+		return NewBadRequestError(ctx, fmt.Sprintf("at most %d ids and emails can be looked up at once", maxMembersFilterValues), nil)
+	}
 
-			span.SetStatus(codes.Error, err.Error())
-			return err
+	if rawLimit := query.Get("limit"); rawLimit != "" {
+		parsed, err := strconv.Atoi(rawLimit)
+		if err != nil || parsed <= 0 || parsed > maxMembersPageSize {
+			span.SetStatus(codes.Error, "Invalid limit")
+			return NewBadRequestError(ctx, fmt.Sprintf("limit must be between 1 and %d", maxMembersPageSize), err)
 		}
+		filter.Limit = parsed
+	}
 
-		members = append(members, *member)
+	// One extra member tells whether there is a next page
+	limit := filter.Limit
+	filter.Limit++
+	members, total, err := h.repositories.Members.List(ctx, filter)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	var next *string
+	if len(members) > limit {
+		members = members[:limit]
+		next = &members[limit-1].Id
 	}
 
 	payload := map[string]interface{}{
 		"data": toMembersAPIResponse(members),
 		"meta": map[string]interface{}{
-			"total": len(members),
+			"total": total,
+			"next":  next,
 		},
 	}
 
@@ -80,3 +115,18 @@ func (h *apiHandler) HandleListMembers(w http.ResponseWriter, r *http.Request) e
 	span.SetStatus(codes.Ok, "OK")
 	return nil
 }
+
+// splitQueryValues flattens repeated and comma separated query values,
+// skipping empty ones.
+func splitQueryValues(values []string) []string {
+	result := []string{}
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				result = append(result, part)
+			}
+		}
+	}
+
+	return result
+}
@@ -3,11 +3,14 @@ package database
 import (
 	"context"
 	"log/slog"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/lopesgabriel/tellawl/services/member-service/internal/domain/events"
 	"github.com/lopesgabriel/tellawl/services/member-service/internal/domain/models"
+	"github.com/lopesgabriel/tellawl/services/member-service/internal/domain/repository"
 )
 
 type inMemoryMemberRepository struct {
@@ -50,6 +53,29 @@ func (r inMemoryMemberRepository) FindByEmail(ctx context.Context, email string)
 	return &member, nil
 }
 
+func (r inMemoryMemberRepository) List(ctx context.Context, filter repository.MembersFilter) ([]models.Member, int, error) {
+	matching := []models.Member{}
+	for _, m := range r.items {
+		all := len(filter.Ids) == 0 && len(filter.Emails) == 0
+		if all || slices.Contains(filter.Ids, m.Id) || slices.Contains(filter.Emails, m.Email) {
+			matching = append(matching, m)
+		}
+	}
+
+	slices.SortFunc(matching, func(a, b models.Member) int {
+		return strings.Compare(a.Id, b.Id)
+	})
+
+	members := []models.Member{}
+	for _, m := range matching {
+		if m.Id > filter.After && len(members) < filter.Limit {
+			members = append(members, m)
+		}
+	}
+
+	return members, len(matching), nil
+}
+
 func (r *inMemoryMemberRepository) Upsert(ctx context.Context, user *models.Member) error {
 	ctx, cancel := context.WithTimeout(ctx, time.Second*5)
 	defer cancel()
@@ -10,6 +10,7 @@ import (
 	"github.com/lopesgabriel/tellawl/packages/tracing"
 	"github.com/lopesgabriel/tellawl/services/member-service/internal/domain/events"
 	"github.com/lopesgabriel/tellawl/services/member-service/internal/domain/models"
+	"github.com/lopesgabriel/tellawl/services/member-service/internal/domain/repository"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
@@ -81,6 +82,71 @@ func (r *postgreSQLMembersRepository) FindByEmail(ctx context.Context, email str
 	return &member, nil
 }
 
+func (r *postgreSQLMembersRepository) List(ctx context.Context, filter repository.MembersFilter) ([]models.Member, int, error) {
+	ctx, span := r.tracer.Start(ctx, "List", trace.WithAttributes(
+		attribute.Int("members.ids", len(filter.Ids)),
+		attribute.Int("members.emails", len(filter.Emails)),
+		attribute.String("members.after", filter.After),
+		attribute.Int("members.limit", filter.Limit),
+	))
+	defer span.End()
+
+	ids := filter.Ids
+	if ids == nil {
+		ids = []string{}
+	}
+	emails := filter.Emails
+	if emails == nil {
+		emails = []string{}
+	}
+
+	where := `WHERE ((cardinality($1::text[]) = 0 AND cardinality($2::text[]) = 0)
+		OR id = ANY($1) OR email = ANY($2))`
+
+	var total int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM members `+where, ids, emails).Scan(&total)
+	if err != nil {
+		span.SetStatus(codes.Error, "failed to count members")
+		span.RecordError(err)
+		return nil, 0, err
+	}
+
+	query := `SELECT
+		id, first_name, last_name, email, hashed_password, created_at, updated_at
+	FROM members ` + where + ` AND id > $3
+	ORDER BY id
+	LIMIT $4
+	`
+	rows, err := r.db.QueryContext(ctx, query, ids, emails, filter.After, filter.Limit)
+	if err != nil {
+		span.SetStatus(codes.Error, "failed to list members")
+		span.RecordError(err)
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	members := []models.Member{}
+	for rows.Next() {
+		var member models.Member
+		err := rows.Scan(&member.Id, &member.FirstName, &member.LastName, &member.Email, &member.HashedPassword, &member.CreatedAt, &member.UpdatedAt)
+		if err != nil {
+			span.SetStatus(codes.Error, "failed to scan member")
+			span.RecordError(err)
+			return nil, 0, err
+		}
+
+		members = append(members, member)
+	}
+
+	if err := rows.Err(); err != nil {
+		span.SetStatus(codes.Error, "failed to list members")
+		span.RecordError(err)
+		return nil, 0, err
+	}
+
+	return members, total, nil
+}
+
 func (r *postgreSQLMembersRepository) Upsert(ctx context.Context, member *models.Member) error {
 	ctx, span := r.tracer.Start(ctx, "Upsert", trace.WithAttributes(
 		attribute.String("member.id", member.Id),
@@ -95,6 +95,14 @@ func (l *kafkaListener) handleKafkaMessage(message *broker.KafkaMessage) error {
 			l.logger.Error(ctx, "Failed to handle NewDonationCommittedEvent", slog.Any("error", err))
 		}
 		return err
+	case WalletInvitationCreatedEventType:
+		err := l.handleWalletInvitationCreated(ctx, message)
+		if err != nil {
+			span.SetStatus(codes.Error, "failed to handle wallet invitation created")
+			span.RecordError(err)
+			l.logger.Error(ctx, "Failed to handle WalletInvitationCreatedEvent", slog.Any("error", err))
+		}
+		return err
 	default:
 		l.logger.Warn(ctx, "Received unsupported event type", slog.String("ce-type", ceType))
 		span.SetStatus(codes.Ok, "nothing to do")
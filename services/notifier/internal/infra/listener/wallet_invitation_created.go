@@ -0,0 +1,74 @@
+package listener
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/lopesgabriel/tellawl/packages/broker"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+const WalletInvitationCreatedEventType = "com.tellawl.wallet.invitation.created"
+
+// WalletInvitationCreatedEvent is emitted by the wallet service when someone
+// is invited to a wallet, the invitee may not have signed up yet.
+type WalletInvitationCreatedEvent struct {
+	InvitationId string    `json:"invitation_id"`
+	WalletId     string    `json:"wallet_id"`
+	WalletName   string    `json:"wallet_name"`
+	Email        string    `json:"email"`
+	Role         string    `json:"role"`
+	InviterName  string    `json:"inviter_name"`
+	Token        string    `json:"token"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+func (e WalletInvitationCreatedEvent) AggregateID() string {
+	return e.WalletId
+}
+
+func (e WalletInvitationCreatedEvent) OccurredAt() time.Time {
+	return e.Timestamp
+}
+
+// handleWalletInvitationCreated emails the invitee only, unlike the other
+// notifications which are broadcast to the configured targets.
+func (l *kafkaListener) handleWalletInvitationCreated(ctx context.Context, message *broker.KafkaMessage) error {
+	ctx, span := l.tracer.Start(ctx, "handleWalletInvitationCreated")
+	defer span.End()
+
+	var event WalletInvitationCreatedEvent
+	err := json.Unmarshal(message.Value, &event)
+	if err != nil {
+		l.logger.Error(ctx, "Failed to unmarshal WalletInvitationCreatedEvent", slog.Any("error", err))
+		span.SetStatus(codes.Error, "Failed to unmarshal event")
+		span.RecordError(err)
+		return err
+	}
+
+	span.SetAttributes(attribute.String("invitation_id", event.InvitationId))
+
+	subject := fmt.Sprintf("%s convidou você para a carteira '%s'", event.InviterName, event.WalletName)
+	body := fmt.Sprintf(
+		"%s convidou você para participar da carteira '%s' como %s.\n\nUse o código abaixo para aceitar ou recusar o convite até %s:\n\n%s",
+		event.InviterName,
+		event.WalletName,
+		event.Role,
+		event.ExpiresAt.Format("02/01/2006 15:04 MST"),
+		event.Token,
+	)
+	err = l.emailClient.SendEmail(ctx, []string{event.Email}, subject, body)
+	if err != nil {
+		span.SetStatus(codes.Error, "Failed to send email notification")
+		span.RecordError(err)
+		return err
+	}
+
+	span.SetStatus(codes.Ok, "success")
+	return nil
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/lopesgabriel/tellawl/packages/logger"
+	"github.com/lopesgabriel/tellawl/packages/tracing"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/repository"
+	usecases "github.com/lopesgabriel/tellawl/services/wallet/internal/use-cases"
+)
+
+// runMaintenanceCommand runs a one-off command instead of the API:
+//
+//	wallet rebuild-snapshots [wallet_id...]
+//	    recomputes the daily balance snapshots of the given wallets, or of
+//	    every wallet, from their transactions
+//
+// A failing command returns an error, so the process exits with a non-zero
+// status even when only part of the work failed.
+func runMaintenanceCommand(ctx context.Context, repos *repository.Repositories, appLogger *logger.AppLogger, args []string) error {
+	useCases := usecases.NewUseCases(usecases.NewUseCasesArgs{
+		Repos:  repos,
+		Logger: appLogger,
+		Tracer: tracing.GetTracer("github.com/lopesgabriel/tellawl/service/wallet/internal/use-cases"),
+	})
+
+	switch args[0] {
+	case "rebuild-snapshots":
+		rebuilt, err := useCases.RebuildBalanceSnapshots(ctx, usecases.RebuildBalanceSnapshotsUseCaseInput{
+			WalletIds: args[1:],
+		})
+		appLogger.Info(ctx, "balance snapshots rebuilt", slog.Int("rebuilt", rebuilt))
+		return err
+	default:
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+}
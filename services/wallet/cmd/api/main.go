@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"os"
 
 	"github.com/lopesgabriel/tellawl/packages/broker"
 	"github.com/lopesgabriel/tellawl/packages/logger"
@@ -11,6 +12,7 @@ import (
 	"github.com/lopesgabriel/tellawl/services/wallet/internal/config"
 	"github.com/lopesgabriel/tellawl/services/wallet/internal/infra/controllers"
 	"github.com/lopesgabriel/tellawl/services/wallet/internal/infra/database"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/infra/listener"
 	"github.com/lopesgabriel/tellawl/services/wallet/internal/infra/publisher"
 	usecases "github.com/lopesgabriel/tellawl/services/wallet/internal/use-cases"
 	"go.opentelemetry.io/otel"
@@ -48,21 +50,65 @@ func main() {
 	}
 
 	// Publisher initialization
-	publisher := publisher.InitEventPublisher(ctx, appConfig, appLogger, kafkaBroker)
+	eventPublisher := publisher.InitEventPublisher(ctx, appConfig, appLogger, kafkaBroker)
 
 	// Database initialization
-	repos, err := database.InitDatabase(ctx, appConfig, publisher)
+	repos, err := database.InitDatabase(ctx, appConfig, eventPublisher)
 	if err != nil {
 		appLogger.Fatal(ctx, "failed to initialize database", slog.String("error", err.Error()))
 	}
 
+	// Maintenance commands run once instead of the API, see runMaintenanceCommand
+	if len(os.Args) > 1 {
+		if err := runMaintenanceCommand(ctx, repos, appLogger, os.Args[1:]); err != nil {
+			appLogger.Fatal(ctx, "maintenance command failed", slog.String("command", os.Args[1]), slog.String("error", err.Error()))
+		}
+		return
+	}
+
+	// Events saved to the outbox are only published while a broker is configured
+	if repos.Outbox != nil && kafkaBroker != nil {
+		relay, err := publisher.NewOutboxRelay(publisher.NewOutboxRelayArgs{
+			Outbox:      repos.Outbox,
+			Broker:      kafkaBroker,
+			Logger:      appLogger,
+			BatchSize:   appConfig.OutboxBatchSize,
+			Interval:    appConfig.OutboxRelayInterval,
+			MaxAttempts: appConfig.OutboxMaxAttempts,
+		})
+		if err != nil {
+			appLogger.Fatal(ctx, "failed to initialize the outbox relay", slog.String("error", err.Error()))
+		}
+
+		go relay.Run(ctx)
+	} else if repos.Outbox != nil {
+		appLogger.Warn(ctx, "No message broker configured, wallet events will wait in the outbox until one is")
+	}
+
 	useCases := usecases.NewUseCases(usecases.NewUseCasesArgs{
 		Repos:  repos,
 		Logger: appLogger,
 		Tracer: tracing.GetTracer("github.com/lopesgabriel/tellawl/service/wallet/internal/use-cases"),
 	})
+
+	// Without a broker members are only fetched from member-service
+	if kafkaBroker != nil && appConfig.MemberEventsTopic != "" {
+		memberListener := listener.NewMemberEventsListener(listener.NewMemberEventsListenerArgs{
+			Broker:   kafkaBroker,
+			Topic:    appConfig.MemberEventsTopic,
+			UseCases: useCases,
+			Logger:   appLogger,
+		})
+		if err := memberListener.Start(ctx); err != nil {
+			appLogger.Fatal(ctx, "failed to start the member events listener", slog.String("error", err.Error()))
+		}
+	}
+
 	apiHandler := controllers.NewAPIHandler(useCases, appConfig.Version)
 
+	go runRecurringTransactionsScheduler(ctx, useCases, appLogger, appConfig.RecurringTransactionsInterval)
+	go runIdempotencyKeysPurge(ctx, useCases, appLogger, appConfig.IdempotencyKeysPurgeInterval)
+
 	appLogger.Info(ctx, "Starting the API Server", slog.Int("port", appConfig.Port))
 	apiHandler.Listen(appConfig.Port)
 }
@@ -92,6 +138,16 @@ func initTelemetry(ctx context.Context, appConfig *config.AppConfiguration) (fun
 
 	otel.SetTracerProvider(tracerProvider)
 
+	meterProvider, err := tracing.InitMetrics(ctx, tracing.NewMeterProviderArgs{
+		CollectorURL:     appConfig.OTELCollectorUrl,
+		ServiceName:      appConfig.ServiceName,
+		ServiceNamespace: appConfig.ServiceNamespace,
+		ServiceVersion:   appConfig.Version,
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	return func() error {
 		if err := appLogger.Shutdown(ctx); err != nil {
 			return err
@@ -99,6 +155,9 @@ func initTelemetry(ctx context.Context, appConfig *config.AppConfiguration) (fun
 		if err := tracerProvider.Shutdown(ctx); err != nil {
 			return err
 		}
+		if err := meterProvider.Shutdown(ctx); err != nil {
+			return err
+		}
 		return nil
 	}, nil
 }
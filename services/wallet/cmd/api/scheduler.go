@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/lopesgabriel/tellawl/packages/logger"
+	usecases "github.com/lopesgabriel/tellawl/services/wallet/internal/use-cases"
+)
+
+// runRecurringTransactionsScheduler materialises the due recurring
+// transactions right away and then on every interval, until ctx is done.
+// Occurrences missed while the service was down are caught up on start.
+func runRecurringTransactionsScheduler(ctx context.Context, useCases *usecases.UseCase, appLogger *logger.AppLogger, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	appLogger.Info(ctx, "Starting the recurring transactions scheduler", slog.String("interval", interval.String()))
+
+	for {
+		registered, err := useCases.MaterializeRecurringTransactions(ctx, usecases.MaterializeRecurringTransactionsUseCaseInput{
+			Now: time.Now(),
+		})
+		if err != nil {
+			appLogger.Error(ctx, "failed to materialize recurring transactions", slog.String("error", err.Error()))
+		} else if registered > 0 {
+			appLogger.Info(ctx, "recurring transactions materialized", slog.Int("registered", registered))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runIdempotencyKeysPurge deletes the expired idempotency keys right away and
+// then on every interval, until ctx is done. Replicas purging at the same
+// time delete each key once.
+func runIdempotencyKeysPurge(ctx context.Context, useCases *usecases.UseCase, appLogger *logger.AppLogger, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	appLogger.Info(ctx, "Starting the idempotency keys purge", slog.String("interval", interval.String()))
+
+	for {
+		deleted, err := useCases.PurgeIdempotencyKeys(ctx, usecases.PurgeIdempotencyKeysUseCaseInput{
+			Now: time.Now(),
+		})
+		if err != nil {
+			appLogger.Error(ctx, "failed to purge idempotency keys", slog.String("error", err.Error()))
+		} else if deleted > 0 {
+			appLogger.Info(ctx, "expired idempotency keys purged", slog.Int("deleted", deleted))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_transactions_category_id;
+
+ALTER TABLE transactions DROP COLUMN IF EXISTS category_id;
+
+DROP INDEX IF EXISTS idx_categories_wallet_id_name;
+
+DROP TABLE IF EXISTS categories;
@@ -0,0 +1,30 @@
+CREATE TABLE categories (
+    id VARCHAR(36) PRIMARY KEY,
+    wallet_id VARCHAR(36) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP,
+    FOREIGN KEY (wallet_id) REFERENCES wallets(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX idx_categories_wallet_id_name ON categories(wallet_id, LOWER(name));
+
+ALTER TABLE transactions
+    ADD COLUMN category_id VARCHAR(36) REFERENCES categories(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_transactions_category_id ON transactions(category_id);
+
+-- Seed the default catalog for wallets created before categories existed
+INSERT INTO categories (id, wallet_id, name)
+SELECT gen_random_uuid()::text, w.id, c.name
+FROM wallets w
+CROSS JOIN (VALUES
+    ('Groceries'),
+    ('Housing'),
+    ('Transportation'),
+    ('Health'),
+    ('Education'),
+    ('Leisure'),
+    ('Salary'),
+    ('Other')
+) AS c(name);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_transactions_status;
+
+ALTER TABLE transactions
+    DROP COLUMN IF EXISTS updated_at,
+    DROP COLUMN IF EXISTS replaced_by_id,
+    DROP COLUMN IF EXISTS replaces_id,
+    DROP COLUMN IF EXISTS status;
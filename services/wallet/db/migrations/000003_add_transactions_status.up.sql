@@ -0,0 +1,7 @@
+ALTER TABLE transactions
+    ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'active',
+    ADD COLUMN replaces_id VARCHAR(36) REFERENCES transactions(id),
+    ADD COLUMN replaced_by_id VARCHAR(36) REFERENCES transactions(id) DEFERRABLE INITIALLY DEFERRED,
+    ADD COLUMN updated_at TIMESTAMP;
+
+CREATE INDEX idx_transactions_status ON transactions(wallet_id, status);
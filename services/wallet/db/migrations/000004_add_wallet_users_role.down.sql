@@ -0,0 +1,2 @@
+ALTER TABLE wallet_users
+    DROP COLUMN IF EXISTS role;
@@ -0,0 +1,8 @@
+ALTER TABLE wallet_users
+    ADD COLUMN role VARCHAR(20) NOT NULL DEFAULT 'editor';
+
+UPDATE wallet_users wu
+SET role = 'owner'
+FROM wallets w
+WHERE w.id = wu.wallet_id
+  AND w.creator_id = wu.member_id;
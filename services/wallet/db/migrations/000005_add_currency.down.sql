@@ -0,0 +1,5 @@
+ALTER TABLE transactions
+    DROP COLUMN IF EXISTS amount_currency;
+
+ALTER TABLE wallets
+    DROP COLUMN IF EXISTS currency;
@@ -0,0 +1,10 @@
+ALTER TABLE wallets
+    ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'BRL';
+
+ALTER TABLE transactions
+    ADD COLUMN amount_currency CHAR(3) NOT NULL DEFAULT 'BRL';
+
+UPDATE transactions t
+SET amount_currency = w.currency
+FROM wallets w
+WHERE w.id = t.wallet_id;
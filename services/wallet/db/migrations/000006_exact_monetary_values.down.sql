@@ -0,0 +1,5 @@
+ALTER TABLE transactions
+    ALTER COLUMN amount_value TYPE INTEGER;
+
+ALTER TABLE wallets
+    ALTER COLUMN balance_value TYPE INTEGER;
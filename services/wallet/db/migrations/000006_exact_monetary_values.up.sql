@@ -0,0 +1,32 @@
+ALTER TABLE wallets
+    ALTER COLUMN balance_value TYPE BIGINT;
+
+ALTER TABLE transactions
+    ALTER COLUMN amount_value TYPE BIGINT;
+
+-- Balances used to be computed truncating every amount to whole units,
+-- recompute them from the active transactions using the finest offset
+-- among the wallet and its transactions, so no cents are dropped.
+WITH offsets AS (
+    SELECT w.id AS wallet_id,
+           GREATEST(w.balance_offset, COALESCE(MAX(t.amount_offset), 1)) AS balance_offset
+    FROM wallets w
+    LEFT JOIN transactions t ON t.wallet_id = w.id AND t.status = 'active'
+    GROUP BY w.id, w.balance_offset
+),
+balances AS (
+    SELECT o.wallet_id,
+           o.balance_offset,
+           COALESCE(SUM(
+               CASE t.type WHEN 'withdraw' THEN -1 ELSE 1 END
+               * t.amount_value::NUMERIC * o.balance_offset / t.amount_offset
+           ), 0) AS balance_value
+    FROM offsets o
+    LEFT JOIN transactions t ON t.wallet_id = o.wallet_id AND t.status = 'active'
+    GROUP BY o.wallet_id, o.balance_offset
+)
+UPDATE wallets w
+SET balance_value = ROUND(b.balance_value)::BIGINT,
+    balance_offset = b.balance_offset
+FROM balances b
+WHERE b.wallet_id = w.id;
@@ -0,0 +1,33 @@
+CREATE TABLE recurring_transactions (
+    id VARCHAR(36) PRIMARY KEY,
+    wallet_id VARCHAR(36) NOT NULL REFERENCES wallets(id) ON DELETE CASCADE,
+    amount_value BIGINT NOT NULL,
+    amount_offset INTEGER NOT NULL,
+    amount_currency CHAR(3) NOT NULL,
+    created_by VARCHAR(36) NOT NULL,
+    type VARCHAR(50) NOT NULL,
+    description TEXT,
+    category_id VARCHAR(36) REFERENCES categories(id) ON DELETE SET NULL,
+    frequency VARCHAR(20) NOT NULL,
+    day_of_month INTEGER NOT NULL DEFAULT 0,
+    start_date DATE NOT NULL,
+    end_date DATE,
+    status VARCHAR(20) NOT NULL DEFAULT 'active',
+    next_occurrence DATE NOT NULL,
+    last_occurrence DATE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP
+);
+
+CREATE INDEX idx_recurring_transactions_wallet_id ON recurring_transactions(wallet_id);
+CREATE INDEX idx_recurring_transactions_due ON recurring_transactions(next_occurrence) WHERE status = 'active';
+
+ALTER TABLE transactions
+    ADD COLUMN recurring_transaction_id VARCHAR(36) REFERENCES recurring_transactions(id) ON DELETE SET NULL,
+    ADD COLUMN occurrence_date DATE;
+
+-- Guarantees an occurrence is materialised only once, even when several
+-- schedulers race for it
+CREATE UNIQUE INDEX idx_transactions_recurring_occurrence
+    ON transactions(recurring_transaction_id, occurrence_date)
+    WHERE recurring_transaction_id IS NOT NULL;
@@ -0,0 +1,24 @@
+CREATE TABLE budgets (
+    id VARCHAR(36) PRIMARY KEY,
+    wallet_id VARCHAR(36) NOT NULL REFERENCES wallets(id) ON DELETE CASCADE,
+    category_id VARCHAR(36) REFERENCES categories(id) ON DELETE CASCADE,
+    limit_value BIGINT NOT NULL,
+    limit_offset INTEGER NOT NULL,
+    limit_currency CHAR(3) NOT NULL,
+    thresholds JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP
+);
+
+-- A single budget for the whole wallet and per category
+CREATE UNIQUE INDEX idx_budgets_wallet_category ON budgets(wallet_id, COALESCE(category_id, ''));
+
+CREATE TABLE budget_periods (
+    budget_id VARCHAR(36) NOT NULL REFERENCES budgets(id) ON DELETE CASCADE,
+    period_start DATE NOT NULL,
+    spent_value BIGINT NOT NULL DEFAULT 0,
+    spent_offset INTEGER NOT NULL,
+    spent_currency CHAR(3) NOT NULL,
+    notified_thresholds JSONB NOT NULL DEFAULT '[]',
+    PRIMARY KEY (budget_id, period_start)
+);
@@ -0,0 +1,4 @@
+ALTER TABLE transactions
+    ADD COLUMN transfer_id VARCHAR(36);
+
+CREATE INDEX idx_transactions_transfer_id ON transactions(transfer_id) WHERE transfer_id IS NOT NULL;
@@ -0,0 +1,2 @@
+-- Serves the keyset pagination of the transactions listing
+CREATE INDEX idx_transactions_wallet_listing ON transactions(wallet_id, created_at DESC, id DESC);
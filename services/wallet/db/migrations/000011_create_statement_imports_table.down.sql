@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS statement_import_rows;
+DROP TABLE IF EXISTS statement_imports;
@@ -0,0 +1,29 @@
+CREATE TABLE statement_imports (
+    id VARCHAR(36) PRIMARY KEY,
+    wallet_id VARCHAR(36) NOT NULL REFERENCES wallets(id) ON DELETE CASCADE,
+    format VARCHAR(10) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'preview',
+    created_by VARCHAR(36) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    committed_at TIMESTAMP
+);
+
+CREATE INDEX idx_statement_imports_wallet_id ON statement_imports(wallet_id);
+
+-- Rows that could not be parsed only have the line and the error
+CREATE TABLE statement_import_rows (
+    import_id VARCHAR(36) NOT NULL REFERENCES statement_imports(id) ON DELETE CASCADE,
+    line INTEGER NOT NULL,
+    entry_date DATE,
+    description TEXT NOT NULL DEFAULT '',
+    amount_value BIGINT,
+    amount_offset INTEGER,
+    amount_currency CHAR(3),
+    type VARCHAR(50),
+    error TEXT,
+    duplicate_of VARCHAR(36),
+    transaction_id VARCHAR(36) REFERENCES transactions(id) ON DELETE SET NULL,
+    PRIMARY KEY (import_id, line)
+);
+
+CREATE INDEX idx_statement_import_rows_committed ON statement_import_rows(entry_date) WHERE transaction_id IS NOT NULL;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS statement_external_ids;
+ALTER TABLE statement_import_rows DROP COLUMN IF EXISTS already_imported;
+ALTER TABLE statement_import_rows DROP COLUMN IF EXISTS external_id;
@@ -0,0 +1,13 @@
+ALTER TABLE statement_import_rows ADD COLUMN external_id VARCHAR(255);
+ALTER TABLE statement_import_rows ADD COLUMN already_imported BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Bank ids (e.g. OFX FITIDs) imported to each wallet, an id is only ever
+-- registered once per wallet
+CREATE TABLE statement_external_ids (
+    wallet_id VARCHAR(36) NOT NULL REFERENCES wallets(id) ON DELETE CASCADE,
+    external_id VARCHAR(255) NOT NULL,
+    transaction_id VARCHAR(36) NOT NULL,
+    import_id VARCHAR(36) NOT NULL REFERENCES statement_imports(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (wallet_id, external_id)
+);
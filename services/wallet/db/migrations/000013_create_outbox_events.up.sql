@@ -0,0 +1,16 @@
+-- Domain events written in the same transaction as the aggregate and relayed
+-- to the broker in id order. Sent rows are kept for auditing
+CREATE TABLE outbox_events (
+    id BIGSERIAL PRIMARY KEY,
+    event_id VARCHAR(36) NOT NULL UNIQUE,
+    event_type VARCHAR(255) NOT NULL,
+    aggregate_id VARCHAR(36) NOT NULL,
+    payload JSONB NOT NULL,
+    occurred_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    sent_at TIMESTAMP
+);
+
+CREATE INDEX idx_outbox_events_pending ON outbox_events(id) WHERE sent_at IS NULL;
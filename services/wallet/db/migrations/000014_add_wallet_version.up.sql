@@ -0,0 +1,2 @@
+-- Incremented on every save, a save based on an older version is rejected
+ALTER TABLE wallets ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
@@ -0,0 +1,17 @@
+-- Requests sent with an Idempotency-Key header and, once they complete, the
+-- response replayed to retries. Rows older than a day can be removed
+CREATE TABLE idempotency_keys (
+    member_id VARCHAR(36) NOT NULL,
+    key VARCHAR(255) NOT NULL,
+    method VARCHAR(10) NOT NULL,
+    path TEXT NOT NULL,
+    fingerprint CHAR(64) NOT NULL,
+    status_code INTEGER,
+    content_type VARCHAR(255),
+    body BYTEA,
+    created_at TIMESTAMP NOT NULL,
+    completed_at TIMESTAMP,
+    PRIMARY KEY (member_id, key)
+);
+
+CREATE INDEX idx_idempotency_keys_created_at ON idempotency_keys(created_at);
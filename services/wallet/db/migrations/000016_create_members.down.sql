@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS member_backfills;
+DROP TABLE IF EXISTS members;
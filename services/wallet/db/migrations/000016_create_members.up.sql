@@ -0,0 +1,20 @@
+-- Local copy of the members of member-service, kept up to date by its events
+-- so wallets and transactions do not look their authors up over HTTP
+CREATE TABLE members (
+    id VARCHAR(36) PRIMARY KEY,
+    first_name VARCHAR(255) NOT NULL,
+    last_name VARCHAR(255) NOT NULL,
+    email VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP,
+    synced_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_members_email ON members(email);
+
+-- Set once every member was copied from member-service, so the backfill only
+-- runs on the first start
+CREATE TABLE member_backfills (
+    id BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+    completed_at TIMESTAMP NOT NULL
+);
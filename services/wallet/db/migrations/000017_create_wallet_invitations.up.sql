@@ -0,0 +1,20 @@
+-- Invitations to join a wallet, the invitee is only added once it accepts.
+-- member_id stays empty until someone signs up with the email
+CREATE TABLE wallet_invitations (
+    id VARCHAR(36) PRIMARY KEY,
+    wallet_id VARCHAR(36) NOT NULL REFERENCES wallets(id) ON DELETE CASCADE,
+    wallet_name VARCHAR(255) NOT NULL,
+    email VARCHAR(255) NOT NULL,
+    member_id VARCHAR(36),
+    role VARCHAR(20) NOT NULL,
+    token VARCHAR(64) NOT NULL UNIQUE,
+    status VARCHAR(20) NOT NULL,
+    invited_by VARCHAR(36) NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    responded_at TIMESTAMP
+);
+
+CREATE INDEX idx_wallet_invitations_pending_email ON wallet_invitations(email) WHERE status = 'pending';
+CREATE INDEX idx_wallet_invitations_pending_member ON wallet_invitations(member_id) WHERE status = 'pending';
+CREATE INDEX idx_wallet_invitations_wallet ON wallet_invitations(wallet_id);
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS transaction_splits;
+
+ALTER TABLE transactions
+    DROP COLUMN IF EXISTS settled_with_id;
+ALTER TABLE transactions
+    DROP COLUMN IF EXISTS split_method;
@@ -0,0 +1,17 @@
+-- Expenses shared among members of the wallet, each share is owed to the
+-- member who registered the transaction. Settlements pay a member back
+ALTER TABLE transactions
+    ADD COLUMN split_method VARCHAR(20);
+ALTER TABLE transactions
+    ADD COLUMN settled_with_id VARCHAR(36);
+
+CREATE TABLE transaction_splits (
+    transaction_id VARCHAR(36) NOT NULL REFERENCES transactions(id) ON DELETE CASCADE,
+    member_id VARCHAR(36) NOT NULL,
+    position INTEGER NOT NULL,
+    percentage INTEGER NOT NULL DEFAULT 0,
+    amount_value BIGINT NOT NULL,
+    amount_offset INTEGER NOT NULL,
+    amount_currency CHAR(3) NOT NULL,
+    PRIMARY KEY (transaction_id, member_id)
+);
@@ -0,0 +1,57 @@
+-- How the balance of each wallet moved every day, in UTC, so historical
+-- balances and reports do not replay every transaction. Kept up to date on
+-- each save of the wallet, the maintenance command rebuilds them
+CREATE TABLE wallet_balance_snapshots (
+    wallet_id VARCHAR(36) NOT NULL REFERENCES wallets(id) ON DELETE CASCADE,
+    day DATE NOT NULL,
+    amount_offset INTEGER NOT NULL,
+    amount_currency CHAR(3) NOT NULL,
+    opening_value BIGINT NOT NULL,
+    closing_value BIGINT NOT NULL,
+    income_value BIGINT NOT NULL,
+    outcome_value BIGINT NOT NULL,
+    transfers_value BIGINT NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (wallet_id, day)
+);
+
+-- Snapshots of the existing transactions, in the finest offset among the
+-- wallet and its transactions like the balance
+WITH offsets AS (
+    SELECT w.id AS wallet_id,
+           w.currency,
+           GREATEST(w.balance_offset, COALESCE(MAX(t.amount_offset), 1)) AS amount_offset
+    FROM wallets w
+    LEFT JOIN transactions t ON t.wallet_id = w.id AND t.status = 'active'
+    GROUP BY w.id, w.currency, w.balance_offset
+),
+days AS (
+    SELECT o.wallet_id,
+           o.currency,
+           o.amount_offset,
+           t.created_at::DATE AS day,
+           SUM(CASE t.type WHEN 'deposit' THEN 1 WHEN 'withdraw' THEN -1 ELSE 0 END
+               * t.amount_value::NUMERIC * o.amount_offset / t.amount_offset) AS net_value,
+           COALESCE(SUM(t.amount_value::NUMERIC * o.amount_offset / t.amount_offset)
+               FILTER (WHERE t.transfer_id IS NULL AND t.type = 'deposit'), 0) AS income_value,
+           COALESCE(SUM(t.amount_value::NUMERIC * o.amount_offset / t.amount_offset)
+               FILTER (WHERE t.transfer_id IS NULL AND t.type = 'withdraw'), 0) AS outcome_value,
+           COALESCE(SUM(CASE t.type WHEN 'deposit' THEN 1 ELSE -1 END
+               * t.amount_value::NUMERIC * o.amount_offset / t.amount_offset)
+               FILTER (WHERE t.transfer_id IS NOT NULL), 0) AS transfers_value
+    FROM offsets o
+    JOIN transactions t ON t.wallet_id = o.wallet_id AND t.status = 'active'
+    GROUP BY o.wallet_id, o.currency, o.amount_offset, t.created_at::DATE
+)
+INSERT INTO wallet_balance_snapshots (wallet_id, day, amount_offset, amount_currency, opening_value, closing_value, income_value, outcome_value, transfers_value)
+SELECT wallet_id,
+       day,
+       amount_offset,
+       currency,
+       ROUND(SUM(net_value) OVER running - net_value)::BIGINT,
+       ROUND(SUM(net_value) OVER running)::BIGINT,
+       ROUND(income_value)::BIGINT,
+       ROUND(outcome_value)::BIGINT,
+       ROUND(transfers_value)::BIGINT
+FROM days
+WINDOW running AS (PARTITION BY wallet_id ORDER BY day);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_outbox_events_pending;
+ALTER TABLE outbox_events DROP COLUMN IF EXISTS failed_at;
+CREATE INDEX idx_outbox_events_pending ON outbox_events(id) WHERE sent_at IS NULL;
@@ -0,0 +1,6 @@
+-- Messages given up on after too many failed attempts, they are kept for
+-- inspection but no longer relayed
+ALTER TABLE outbox_events ADD COLUMN failed_at TIMESTAMP;
+
+DROP INDEX IF EXISTS idx_outbox_events_pending;
+CREATE INDEX idx_outbox_events_pending ON outbox_events(id) WHERE sent_at IS NULL AND failed_at IS NULL;
@@ -0,0 +1,3 @@
+-- Cancelled definitions cannot be told apart from the ones cancelled by a
+-- member, nothing to revert
+SELECT 1;
@@ -0,0 +1,14 @@
+-- Occurrences are registered on behalf of the creator of the definition,
+-- definitions of members removed from the wallet or left without write
+-- permission can never be materialised
+UPDATE recurring_transactions rt
+SET status = 'cancelled',
+    updated_at = CURRENT_TIMESTAMP
+WHERE rt.status = 'active'
+  AND NOT EXISTS (
+      SELECT 1
+      FROM wallet_users wu
+      WHERE wu.wallet_id = rt.wallet_id
+        AND wu.member_id = rt.created_by
+        AND wu.role IN ('owner', 'editor')
+  );
@@ -6,6 +6,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -22,6 +23,23 @@ type AppConfiguration struct {
 	KafkaTopic       string
 	KafkaBrokers     []string
 	LogLevel         slog.Level
+
+	// Topic member-service publishes its events to, the wallet topic by default
+	MemberEventsTopic string
+
+	// Rates used to convert between currencies, e.g. "USD/BRL=5.10,EUR/BRL=5.90"
+	ExchangeRates string
+
+	RecurringTransactionsInterval time.Duration
+
+	// How often expired idempotency keys and their responses are deleted
+	IdempotencyKeysPurgeInterval time.Duration
+
+	// How often the outbox is polled and how many events are published at a time
+	OutboxRelayInterval time.Duration
+	OutboxBatchSize     int
+	// Failed attempts after which an event is given up on
+	OutboxMaxAttempts int
 }
 
 func InitAppConfigurations() *AppConfiguration {
@@ -41,6 +59,33 @@ func InitAppConfigurations() *AppConfiguration {
 		brokers = strings.Split(rawBrokers, ",")
 	}
 
+	recurringTransactionsInterval, err := time.ParseDuration(getEnv("RECURRING_TRANSACTIONS_INTERVAL", "5m"))
+	if err != nil || recurringTransactionsInterval <= 0 {
+		recurringTransactionsInterval = 5 * time.Minute
+	}
+
+	idempotencyKeysPurgeInterval, err := time.ParseDuration(getEnv("IDEMPOTENCY_KEYS_PURGE_INTERVAL", "1h"))
+	if err != nil || idempotencyKeysPurgeInterval <= 0 {
+		idempotencyKeysPurgeInterval = time.Hour
+	}
+
+	outboxRelayInterval, err := time.ParseDuration(getEnv("OUTBOX_RELAY_INTERVAL", "1s"))
+	if err != nil || outboxRelayInterval <= 0 {
+		outboxRelayInterval = time.Second
+	}
+
+	outboxBatchSize, err := strconv.Atoi(getEnv("OUTBOX_BATCH_SIZE", "100"))
+	if err != nil || outboxBatchSize <= 0 {
+		outboxBatchSize = 100
+	}
+
+	outboxMaxAttempts, err := strconv.Atoi(getEnv("OUTBOX_MAX_ATTEMPTS", "10"))
+	if err != nil || outboxMaxAttempts <= 0 {
+		outboxMaxAttempts = 10
+	}
+
+	kafkaTopic := getEnv("KAFKA_TOPIC", "")
+
 	return &AppConfiguration{
 		Version:          getEnv("VERSION", "1.0.0"),
 		Port:             port,
@@ -50,9 +95,21 @@ func InitAppConfigurations() *AppConfiguration {
 		OTELCollectorUrl: getEnv("OTEL_COLLECTOR_URL", "localhost:4317"),
 		ServiceName:      getEnv("SERVICE_NAME", "wallet"),
 		ServiceNamespace: getEnv("SERVICE_NAMESPACE", "tellawl"),
-		KafkaTopic:       getEnv("KAFKA_TOPIC", ""),
+		KafkaTopic:       kafkaTopic,
 		KafkaBrokers:     brokers,
 		LogLevel:         parseLogLevel(getEnv("LOG_LEVEL", "INFO")),
+
+		MemberEventsTopic: getEnv("MEMBER_EVENTS_TOPIC", kafkaTopic),
+
+		ExchangeRates: getEnv("EXCHANGE_RATES", ""),
+
+		RecurringTransactionsInterval: recurringTransactionsInterval,
+
+		IdempotencyKeysPurgeInterval: idempotencyKeysPurgeInterval,
+
+		OutboxRelayInterval: outboxRelayInterval,
+		OutboxBatchSize:     outboxBatchSize,
+		OutboxMaxAttempts:   outboxMaxAttempts,
 	}
 }
 
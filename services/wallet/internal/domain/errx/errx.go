@@ -12,6 +12,64 @@ var (
 	ErrInvalidCredentials      = errors.New("invalid credentials")
 	ErrInvalidTransactionType  = errors.New("invalid transaction type")
 	ErrNotFound                = errors.New("entry not found")
+	ErrCategoryNotFound        = errors.New("category not found")
+	ErrCategoryAlreadyExists   = errors.New("category already exists")
+	ErrTransactionNotFound     = errors.New("transaction not found")
+	ErrTransactionNotActive    = errors.New("transaction was already voided or replaced")
+	ErrMemberAlreadyInWallet   = errors.New("member already has access to the wallet")
+	ErrMemberNotInWallet       = errors.New("member does not have access to the wallet")
+	ErrCannotRemoveCreator     = errors.New("the wallet creator cannot be removed from the wallet")
+	ErrCannotChangeCreatorRole = errors.New("the wallet creator role cannot be changed")
+	ErrInvalidWalletRole       = errors.New("invalid wallet role")
+	ErrInvalidCurrency         = errors.New("invalid or unsupported currency")
+	ErrCurrencyMismatch        = errors.New("the amount currency does not match the wallet currency")
+	ErrInvalidAmount           = errors.New("invalid amount")
+	ErrInvalidCursor           = errors.New("invalid pagination cursor")
+
+	ErrRecurringTransactionNotFound = errors.New("recurring transaction not found")
+	ErrInvalidRecurrence            = errors.New("invalid recurrence schedule")
+	ErrOccurrenceNotDue             = errors.New("the occurrence is not the next one due for the recurring transaction")
+
+	ErrBudgetNotFound         = errors.New("budget not found")
+	ErrBudgetAlreadyExists    = errors.New("a budget already exists for the wallet or category")
+	ErrInvalidBudgetThreshold = errors.New("budget thresholds must be positive percentages")
+
+	ErrInvalidTransfer       = errors.New("a transfer must move money between two different wallets")
+	ErrTransactionIsTransfer = errors.New("the transaction is a leg of a transfer and cannot be changed on its own")
+
+	ErrInvalidSplit            = errors.New("invalid split, expenses must be shared by wallet members with shares adding up to the amount or to 100%")
+	ErrInvalidSettlement       = errors.New("a settlement must pay back another member of the wallet")
+	ErrNothingToSettle         = errors.New("there is nothing to settle between the members")
+	ErrTransactionIsSettlement = errors.New("settlements cannot be edited, void and register them again")
+	ErrMemberHasOpenBalance    = errors.New("the member must settle up the shared expenses before leaving the wallet")
+
+	ErrInvalidReportGranularity = errors.New("invalid report granularity, must be week, month or year")
+	ErrInvalidReportRange       = errors.New("invalid report range")
+	ErrInvalidTimezone          = errors.New("invalid timezone")
+
+	ErrWalletConflict = errors.New("the wallet was changed by another request, try again")
+
+	ErrInvalidIdempotencyKey    = errors.New("idempotency keys must have between 1 and 255 characters")
+	ErrIdempotencyKeyReused     = errors.New("the idempotency key was already used for a different request")
+	ErrIdempotencyKeyInProgress = errors.New("a request with the same idempotency key is still being processed")
+	ErrIdempotencyKeyTakenOver  = errors.New("the idempotency key was taken over by a retry after the request took too long")
+
+	ErrInvalidExportFormat = errors.New("invalid export format, must be csv, json or ofx")
+
+	ErrInvalidExchangeRate  = errors.New("exchange rates must be positive decimals")
+	ErrExchangeRateNotFound = errors.New("no exchange rate available between the currencies")
+
+	ErrInvalidStatement         = errors.New("the statement could not be read")
+	ErrInvalidStatementMapping  = errors.New("invalid statement column mapping")
+	ErrInvalidStatementFormat   = errors.New("invalid statement format, must be csv or ofx")
+	ErrInvalidStatementLine     = errors.New("the accepted lines must be valid rows of the statement not imported before")
+	ErrStatementImportNotFound  = errors.New("statement import not found")
+	ErrStatementImportCommitted = errors.New("the statement import was already committed")
+
+	ErrInvitationNotFound       = errors.New("invitation not found")
+	ErrInvitationNotPending     = errors.New("the invitation was already accepted, declined or revoked")
+	ErrInvitationExpired        = errors.New("the invitation has expired")
+	ErrInvitationAlreadyPending = errors.New("a pending invitation already exists for the email")
 )
 
 func MissingRequiredFieldsError(fields ...string) error {
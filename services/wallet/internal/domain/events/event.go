@@ -16,10 +16,18 @@ type EventPublisher interface {
 	Close() error
 }
 
+// Amount is the representation of a monetary value in the events payload.
+type Amount struct {
+	Value    int    `json:"value"`
+	Offset   int    `json:"offset"`
+	Currency string `json:"currency"`
+}
+
 type WalletCreatedEvent struct {
 	WalletId  string    `json:"wallet_id"`
 	CreatorId string    `json:"creator_id"`
 	Name      string    `json:"name"`
+	Currency  string    `json:"currency"`
 	Timestamp time.Time `json:"timestamp"`
 }
 
@@ -30,6 +38,7 @@ func (e WalletCreatedEvent) OccurredAt() time.Time { return e.Timestamp }
 type WalletSharedEvent struct {
 	WalletId  string    `json:"wallet_id"`
 	MemberId  string    `json:"member_id"`
+	Role      string    `json:"role"`
 	Timestamp time.Time `json:"timestamp"`
 }
 
@@ -37,14 +46,59 @@ func (e WalletSharedEvent) EventType() string     { return "com.tellawl.wallet.s
 func (e WalletSharedEvent) AggregateID() string   { return e.WalletId }
 func (e WalletSharedEvent) OccurredAt() time.Time { return e.Timestamp }
 
+type WalletUnsharedEvent struct {
+	WalletId  string    `json:"wallet_id"`
+	MemberId  string    `json:"member_id"`
+	RemovedBy string    `json:"removed_by"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+func (e WalletUnsharedEvent) EventType() string     { return "com.tellawl.wallet.unshared" }
+func (e WalletUnsharedEvent) AggregateID() string   { return e.WalletId }
+func (e WalletUnsharedEvent) OccurredAt() time.Time { return e.Timestamp }
+
+type WalletMemberRoleChangedEvent struct {
+	WalletId     string    `json:"wallet_id"`
+	MemberId     string    `json:"member_id"`
+	PreviousRole string    `json:"previous_role"`
+	Role         string    `json:"role"`
+	ChangedBy    string    `json:"changed_by"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+func (e WalletMemberRoleChangedEvent) EventType() string {
+	return "com.tellawl.wallet.member.role_changed"
+}
+func (e WalletMemberRoleChangedEvent) AggregateID() string   { return e.WalletId }
+func (e WalletMemberRoleChangedEvent) OccurredAt() time.Time { return e.Timestamp }
+
+type WalletRenamedEvent struct {
+	WalletId  string    `json:"wallet_id"`
+	Name      string    `json:"name"`
+	RenamedBy string    `json:"renamed_by"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+func (e WalletRenamedEvent) EventType() string     { return "com.tellawl.wallet.renamed" }
+func (e WalletRenamedEvent) AggregateID() string   { return e.WalletId }
+func (e WalletRenamedEvent) OccurredAt() time.Time { return e.Timestamp }
+
 type TransactionRegisteredEvent struct {
-	TransactionId string         `json:"transaction_id"`
-	WalletId      string         `json:"wallet_id"`
-	MemberId      string         `json:"member_id"`
-	Description   string         `json:"description"`
-	Amount        map[string]int `json:"amount"`
-	Type          string         `json:"type"`
-	Timestamp     time.Time      `json:"timestamp"`
+	TransactionId string    `json:"transaction_id"`
+	WalletId      string    `json:"wallet_id"`
+	MemberId      string    `json:"member_id"`
+	Description   string    `json:"description"`
+	Amount        Amount    `json:"amount"`
+	Type          string    `json:"type"`
+	CategoryId    *string   `json:"category_id"`
+	CategoryName  string    `json:"category_name,omitempty"`
+	Timestamp     time.Time `json:"timestamp"`
+
+	RecurringTransactionId *string  `json:"recurring_transaction_id,omitempty"`
+	TransferId             *string  `json:"transfer_id,omitempty"`
+	SplitMethod            string   `json:"split_method,omitempty"`
+	SplitMemberIds         []string `json:"split_member_ids,omitempty"`
+	SettledWithId          *string  `json:"settled_with_id,omitempty"`
 }
 
 func (e TransactionRegisteredEvent) EventType() string {
@@ -52,3 +106,179 @@ func (e TransactionRegisteredEvent) EventType() string {
 }
 func (e TransactionRegisteredEvent) AggregateID() string   { return e.WalletId }
 func (e TransactionRegisteredEvent) OccurredAt() time.Time { return e.Timestamp }
+
+type RecurringTransactionCreatedEvent struct {
+	RecurringTransactionId string     `json:"recurring_transaction_id"`
+	WalletId               string     `json:"wallet_id"`
+	MemberId               string     `json:"member_id"`
+	Description            string     `json:"description"`
+	Amount                 Amount     `json:"amount"`
+	Type                   string     `json:"type"`
+	Frequency              string     `json:"frequency"`
+	NextOccurrence         time.Time  `json:"next_occurrence"`
+	EndDate                *time.Time `json:"end_date"`
+	Timestamp              time.Time  `json:"timestamp"`
+}
+
+func (e RecurringTransactionCreatedEvent) EventType() string {
+	return "com.tellawl.wallet.recurring_transaction.created"
+}
+func (e RecurringTransactionCreatedEvent) AggregateID() string   { return e.WalletId }
+func (e RecurringTransactionCreatedEvent) OccurredAt() time.Time { return e.Timestamp }
+
+type RecurringTransactionCancelledEvent struct {
+	RecurringTransactionId string    `json:"recurring_transaction_id"`
+	WalletId               string    `json:"wallet_id"`
+	MemberId               string    `json:"member_id"`
+	Timestamp              time.Time `json:"timestamp"`
+}
+
+func (e RecurringTransactionCancelledEvent) EventType() string {
+	return "com.tellawl.wallet.recurring_transaction.cancelled"
+}
+func (e RecurringTransactionCancelledEvent) AggregateID() string   { return e.WalletId }
+func (e RecurringTransactionCancelledEvent) OccurredAt() time.Time { return e.Timestamp }
+
+type BudgetThresholdReachedEvent struct {
+	BudgetId      string    `json:"budget_id"`
+	WalletId      string    `json:"wallet_id"`
+	CategoryId    *string   `json:"category_id"`
+	TransactionId string    `json:"transaction_id"`
+	Period        string    `json:"period"`
+	Threshold     int       `json:"threshold"`
+	Percentage    int       `json:"percentage"`
+	Limit         Amount    `json:"limit"`
+	Spent         Amount    `json:"spent"`
+	Timestamp     time.Time `json:"timestamp"`
+}
+
+func (e BudgetThresholdReachedEvent) EventType() string {
+	return "com.tellawl.wallet.budget.threshold_reached"
+}
+func (e BudgetThresholdReachedEvent) AggregateID() string   { return e.WalletId }
+func (e BudgetThresholdReachedEvent) OccurredAt() time.Time { return e.Timestamp }
+
+type TransferRegisteredEvent struct {
+	TransferId            string    `json:"transfer_id"`
+	FromWalletId          string    `json:"from_wallet_id"`
+	ToWalletId            string    `json:"to_wallet_id"`
+	WithdrawTransactionId string    `json:"withdraw_transaction_id"`
+	DepositTransactionId  string    `json:"deposit_transaction_id"`
+	MemberId              string    `json:"member_id"`
+	Description           string    `json:"description"`
+	Amount                Amount    `json:"amount"`
+	Timestamp             time.Time `json:"timestamp"`
+}
+
+func (e TransferRegisteredEvent) EventType() string {
+	return "com.tellawl.wallet.transfer.registered"
+}
+func (e TransferRegisteredEvent) AggregateID() string   { return e.FromWalletId }
+func (e TransferRegisteredEvent) OccurredAt() time.Time { return e.Timestamp }
+
+type TransactionUpdatedEvent struct {
+	TransactionId         string    `json:"transaction_id"`
+	PreviousTransactionId string    `json:"previous_transaction_id"`
+	WalletId              string    `json:"wallet_id"`
+	MemberId              string    `json:"member_id"`
+	Description           string    `json:"description"`
+	Amount                Amount    `json:"amount"`
+	Type                  string    `json:"type"`
+	CategoryId            *string   `json:"category_id"`
+	Timestamp             time.Time `json:"timestamp"`
+}
+
+func (e TransactionUpdatedEvent) EventType() string {
+	return "com.tellawl.wallet.transaction.updated"
+}
+func (e TransactionUpdatedEvent) AggregateID() string   { return e.WalletId }
+func (e TransactionUpdatedEvent) OccurredAt() time.Time { return e.Timestamp }
+
+type TransactionVoidedEvent struct {
+	TransactionId string    `json:"transaction_id"`
+	WalletId      string    `json:"wallet_id"`
+	MemberId      string    `json:"member_id"`
+	Amount        Amount    `json:"amount"`
+	Type          string    `json:"type"`
+	Timestamp     time.Time `json:"timestamp"`
+}
+
+func (e TransactionVoidedEvent) EventType() string {
+	return "com.tellawl.wallet.transaction.voided"
+}
+func (e TransactionVoidedEvent) AggregateID() string   { return e.WalletId }
+func (e TransactionVoidedEvent) OccurredAt() time.Time { return e.Timestamp }
+
+type StatementImportedEvent struct {
+	ImportId         string    `json:"import_id"`
+	WalletId         string    `json:"wallet_id"`
+	MemberId         string    `json:"member_id"`
+	Format           string    `json:"format"`
+	TransactionCount int       `json:"transaction_count"`
+	Timestamp        time.Time `json:"timestamp"`
+}
+
+func (e StatementImportedEvent) EventType() string {
+	return "com.tellawl.wallet.statement.imported"
+}
+func (e StatementImportedEvent) AggregateID() string   { return e.WalletId }
+func (e StatementImportedEvent) OccurredAt() time.Time { return e.Timestamp }
+
+type InvitationCreatedEvent struct {
+	InvitationId string    `json:"invitation_id"`
+	WalletId     string    `json:"wallet_id"`
+	WalletName   string    `json:"wallet_name"`
+	Email        string    `json:"email"`
+	MemberId     *string   `json:"member_id"`
+	Role         string    `json:"role"`
+	InvitedBy    string    `json:"invited_by"`
+	InviterName  string    `json:"inviter_name"`
+	Token        string    `json:"token"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+func (e InvitationCreatedEvent) EventType() string {
+	return "com.tellawl.wallet.invitation.created"
+}
+func (e InvitationCreatedEvent) AggregateID() string   { return e.WalletId }
+func (e InvitationCreatedEvent) OccurredAt() time.Time { return e.Timestamp }
+
+type InvitationAcceptedEvent struct {
+	InvitationId string    `json:"invitation_id"`
+	WalletId     string    `json:"wallet_id"`
+	MemberId     string    `json:"member_id"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+func (e InvitationAcceptedEvent) EventType() string {
+	return "com.tellawl.wallet.invitation.accepted"
+}
+func (e InvitationAcceptedEvent) AggregateID() string   { return e.WalletId }
+func (e InvitationAcceptedEvent) OccurredAt() time.Time { return e.Timestamp }
+
+type InvitationDeclinedEvent struct {
+	InvitationId string    `json:"invitation_id"`
+	WalletId     string    `json:"wallet_id"`
+	MemberId     string    `json:"member_id"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+func (e InvitationDeclinedEvent) EventType() string {
+	return "com.tellawl.wallet.invitation.declined"
+}
+func (e InvitationDeclinedEvent) AggregateID() string   { return e.WalletId }
+func (e InvitationDeclinedEvent) OccurredAt() time.Time { return e.Timestamp }
+
+type InvitationRevokedEvent struct {
+	InvitationId string    `json:"invitation_id"`
+	WalletId     string    `json:"wallet_id"`
+	RevokedBy    string    `json:"revoked_by"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+func (e InvitationRevokedEvent) EventType() string {
+	return "com.tellawl.wallet.invitation.revoked"
+}
+func (e InvitationRevokedEvent) AggregateID() string   { return e.WalletId }
+func (e InvitationRevokedEvent) OccurredAt() time.Time { return e.Timestamp }
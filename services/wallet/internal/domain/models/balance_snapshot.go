@@ -0,0 +1,171 @@
+package models
+
+import (
+	"slices"
+	"time"
+)
+
+// BalanceSnapshot is how the balance of a wallet moved during a day, in UTC.
+// Days without active transactions have no snapshot, their balance is the
+// closing balance of the last snapshot before them.
+type BalanceSnapshot struct {
+	WalletId string
+	// Midnight of the day, in UTC
+	Day     time.Time
+	Opening Monetary
+	Closing Monetary
+	// Income and outcome leave transfers out, they are summed apart as a
+	// signed amount like in EvolutionTotals
+	Income    Monetary
+	Outcome   Monetary
+	Transfers Monetary
+}
+
+// SnapshotDay returns the day of the snapshot covering the moment.
+func SnapshotDay(t time.Time) time.Time {
+	year, month, day := t.UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// BalanceMovement is a transaction moving the balance of its wallet, or the
+// effect of a transaction being reverted when it is voided or replaced.
+type BalanceMovement struct {
+	TransactionId string
+	Reverted      bool
+}
+
+// BalanceHistory holds snapshots of a wallet, sorted by day. When it only
+// holds the recent ones, it must include the last snapshot before the days
+// of the transactions applied to it.
+type BalanceHistory struct {
+	WalletId string
+	// Opening balance of a wallet without snapshots
+	Zero      Monetary
+	Snapshots []BalanceSnapshot
+}
+
+// NewBalanceHistory computes the snapshots of a wallet from scratch out of
+// its transactions.
+func NewBalanceHistory(walletId string, zero Monetary, transactions []Transaction) *BalanceHistory {
+	history := &BalanceHistory{WalletId: walletId, Zero: zero, Snapshots: []BalanceSnapshot{}}
+	for _, transaction := range transactions {
+		if transaction.IsActive() {
+			history.Apply(transaction, false)
+		}
+	}
+
+	return history
+}
+
+// Apply records the transaction in the snapshot of the day it was created,
+// reverting it instead when asked to, and carries its effect over to the
+// balances of the following days.
+func (h *BalanceHistory) Apply(transaction Transaction, reverted bool) {
+	day := SnapshotDay(transaction.CreatedAt)
+	effect, amount := transaction.BalanceEffect(), transaction.Amount
+	if reverted {
+		effect, amount = effect.Neg(), amount.Neg()
+	}
+
+	index, found := slices.BinarySearchFunc(h.Snapshots, day, func(snapshot BalanceSnapshot, day time.Time) int {
+		return snapshot.Day.Compare(day)
+	})
+	if !found {
+		opening := h.Zero
+		if index > 0 {
+			opening = h.Snapshots[index-1].Closing
+		}
+		h.Snapshots = slices.Insert(h.Snapshots, index, BalanceSnapshot{
+			WalletId:  h.WalletId,
+			Day:       day,
+			Opening:   opening,
+			Closing:   opening,
+			Income:    h.Zero,
+			Outcome:   h.Zero,
+			Transfers: h.Zero,
+		})
+	}
+
+	snapshot := &h.Snapshots[index]
+	switch {
+	case transaction.IsTransfer():
+		snapshot.Transfers = snapshot.Transfers.Sum(effect)
+	case transaction.Type == TransactionTypeDeposit:
+		snapshot.Income = snapshot.Income.Sum(amount)
+	case transaction.Type == TransactionTypeWithdraw:
+		snapshot.Outcome = snapshot.Outcome.Sum(amount)
+	}
+	snapshot.Closing = snapshot.Closing.Sum(effect)
+
+	for i := index + 1; i < len(h.Snapshots); i++ {
+		h.Snapshots[i].Opening = h.Snapshots[i].Opening.Sum(effect)
+		h.Snapshots[i].Closing = h.Snapshots[i].Closing.Sum(effect)
+	}
+}
+
+// BalanceAt returns the balance at the start of the day, the closing
+// balance of the last snapshot before it.
+func (h *BalanceHistory) BalanceAt(day time.Time) Monetary {
+	index, _ := slices.BinarySearchFunc(h.Snapshots, day, func(snapshot BalanceSnapshot, day time.Time) int {
+		return snapshot.Day.Compare(day)
+	})
+	if index == 0 {
+		return h.Zero
+	}
+
+	return h.Snapshots[index-1].Closing
+}
+
+// SnapshotCoverage is the part of a report range read from the daily
+// snapshots: the whole days from First to End (exclusive), except the
+// Straddling ones where a bucket of the report starts at a time other than
+// midnight UTC. Transactions outside of it are summed one by one.
+type SnapshotCoverage struct {
+	First      time.Time
+	End        time.Time
+	Straddling []time.Time
+}
+
+// SnapshotCoverage finds the days of the range whose snapshots each fall
+// entirely into a single bucket of the report.
+func (g ReportGranularity) SnapshotCoverage(location *time.Location, from, to time.Time) SnapshotCoverage {
+	first := SnapshotDay(from)
+	if first.Before(from) {
+		first = first.AddDate(0, 0, 1)
+	}
+	end := SnapshotDay(to)
+	if end.Before(first) {
+		end = first
+	}
+
+	coverage := SnapshotCoverage{First: first, End: end, Straddling: []time.Time{}}
+	for start := g.NextBucket(g.BucketStart(from, location)); start.Before(end); start = g.NextBucket(start) {
+		day := SnapshotDay(start)
+		if !day.Equal(start) && !day.Before(first) {
+			coverage.Straddling = append(coverage.Straddling, day)
+		}
+	}
+
+	return coverage
+}
+
+// Covers reports whether the transactions of the moment are summed by the
+// snapshots.
+func (c SnapshotCoverage) Covers(t time.Time) bool {
+	if t.Before(c.First) || !t.Before(c.End) {
+		return false
+	}
+
+	return !slices.ContainsFunc(c.Straddling, SnapshotDay(t).Equal)
+}
+
+// Offset is the least common offset of the amounts of the snapshot, all of
+// them can be expressed exactly in it.
+func (s BalanceSnapshot) Offset() int {
+	offset := 1
+	for _, amount := range []Monetary{s.Opening, s.Closing, s.Income, s.Outcome, s.Transfers} {
+		offset = lcm(offset, validOffset(amount.Offset))
+	}
+
+	return offset
+}
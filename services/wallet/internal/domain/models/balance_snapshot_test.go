@@ -0,0 +1,102 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+)
+
+func TestBalanceHistory(t *testing.T) {
+	zero := models.Monetary{Offset: 100, Currency: "BRL"}
+	transaction := func(id string, value int, transactionType models.TransactionType, createdAt time.Time) models.Transaction {
+		return models.Transaction{
+			Id:        id,
+			Amount:    models.Monetary{Value: value, Offset: 100, Currency: "BRL"},
+			Type:      transactionType,
+			Status:    models.TransactionStatusActive,
+			CreatedAt: createdAt,
+		}
+	}
+
+	march := func(day, hour int) time.Time {
+		return time.Date(2026, time.March, day, hour, 0, 0, 0, time.UTC)
+	}
+
+	t.Run("should chain the days from the opening balance", func(t *testing.T) {
+		history := models.NewBalanceHistory("wallet", zero, []models.Transaction{
+			transaction("1", 10000, models.TransactionTypeDeposit, march(1, 10)),
+			transaction("2", 2500, models.TransactionTypeWithdraw, march(1, 18)),
+			transaction("3", 1000, models.TransactionTypeWithdraw, march(3, 9)),
+		})
+
+		if len(history.Snapshots) != 2 {
+			t.Fatalf("Expected a snapshot per day with transactions, got %v", len(history.Snapshots))
+		}
+
+		first, second := history.Snapshots[0], history.Snapshots[1]
+		if first.Income.Value != 10000 || first.Outcome.Value != 2500 || first.Closing.Value != 7500 {
+			t.Errorf("Expected 100.00 in, 25.00 out and 75.00 closing, got %v, %v and %v", first.Income, first.Outcome, first.Closing)
+		}
+		if second.Opening.Value != 7500 || second.Closing.Value != 6500 {
+			t.Errorf("Expected the second day to go from 75.00 to 65.00, got %v and %v", second.Opening, second.Closing)
+		}
+
+		if balance := history.BalanceAt(march(2, 0)); balance.Value != 7500 {
+			t.Errorf("Expected 75.00 on a day without transactions, got %v", balance)
+		}
+	})
+
+	t.Run("should carry a reverted transaction over to the following days", func(t *testing.T) {
+		deposit := transaction("1", 10000, models.TransactionTypeDeposit, march(1, 10))
+		history := models.NewBalanceHistory("wallet", zero, []models.Transaction{
+			deposit,
+			transaction("2", 1000, models.TransactionTypeWithdraw, march(3, 9)),
+		})
+
+		history.Apply(deposit, true)
+
+		if history.Snapshots[0].Income.Value != 0 || history.Snapshots[0].Closing.Value != 0 {
+			t.Errorf("Expected the first day to be emptied, got %+v", history.Snapshots[0])
+		}
+		if history.Snapshots[1].Opening.Value != 0 || history.Snapshots[1].Closing.Value != -1000 {
+			t.Errorf("Expected the second day to go from 0 to -10.00, got %v and %v", history.Snapshots[1].Opening, history.Snapshots[1].Closing)
+		}
+	})
+}
+
+func TestSnapshotCoverage(t *testing.T) {
+	saoPaulo, err := time.LoadLocation("America/Sao_Paulo")
+	if err != nil {
+		t.Skipf("timezone database not available: %v", err)
+	}
+
+	from := time.Date(2026, time.January, 1, 0, 0, 0, 0, saoPaulo)
+	to := time.Date(2026, time.March, 1, 0, 0, 0, 0, saoPaulo)
+	coverage := models.ReportMonthly.SnapshotCoverage(saoPaulo, from, to)
+
+	// Midnight in Sao Paulo is 03:00 UTC, the days buckets start in are
+	// only partly covered
+	cases := []struct {
+		moment  time.Time
+		covered bool
+	}{
+		{time.Date(2026, time.January, 1, 2, 0, 0, 0, time.UTC), false},
+		{time.Date(2026, time.January, 1, 12, 0, 0, 0, time.UTC), false},
+		{time.Date(2026, time.January, 2, 12, 0, 0, 0, time.UTC), true},
+		{time.Date(2026, time.February, 1, 1, 0, 0, 0, time.UTC), false},
+		{time.Date(2026, time.February, 28, 12, 0, 0, 0, time.UTC), true},
+		{time.Date(2026, time.March, 1, 1, 0, 0, 0, time.UTC), false},
+	}
+
+	for _, c := range cases {
+		if coverage.Covers(c.moment) != c.covered {
+			t.Errorf("Expected %v to be covered: %v", c.moment, c.covered)
+		}
+	}
+
+	utc := models.ReportMonthly.SnapshotCoverage(time.UTC, time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC))
+	if len(utc.Straddling) != 0 || !utc.Covers(time.Date(2026, time.February, 1, 1, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected buckets starting at midnight UTC to be covered by whole days, got %+v", utc)
+	}
+}
@@ -0,0 +1,110 @@
+package models
+
+import (
+	"slices"
+	"time"
+)
+
+// DefaultBudgetThresholds are the consumption percentages notified when a
+// budget is created without explicit thresholds.
+var DefaultBudgetThresholds = []int{80, 100}
+
+// Budget is a monthly spending limit for the whole wallet or, when
+// CategoryId is set, for a single category. Consumption is tracked per
+// calendar month in UTC.
+type Budget struct {
+	Id         string
+	WalletId   string
+	CategoryId *string
+	Limit      Monetary
+	Thresholds []int
+	Periods    []BudgetPeriod
+
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+}
+
+// BudgetPeriod is the consumption of a budget in a month.
+type BudgetPeriod struct {
+	Start              time.Time
+	Spent              Monetary
+	NotifiedThresholds []int
+}
+
+// BudgetPeriodSummary is the state of a budget in a month.
+type BudgetPeriodSummary struct {
+	Start     time.Time
+	Limit     Monetary
+	Spent     Monetary
+	Remaining Monetary
+	// Percentage of the limit already spent, truncated
+	Percentage int
+}
+
+// BudgetPeriodStart returns the first day of the month of t in UTC.
+func BudgetPeriodStart(t time.Time) time.Time {
+	year, month, _ := t.UTC().Date()
+	return time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// Applies reports whether a transaction in the category counts towards the budget.
+func (b Budget) Applies(categoryId *string) bool {
+	if b.CategoryId == nil {
+		return true
+	}
+
+	return categoryId != nil && *categoryId == *b.CategoryId
+}
+
+func (b Budget) FindPeriod(start time.Time) *BudgetPeriod {
+	for i := range b.Periods {
+		if b.Periods[i].Start.Equal(start) {
+			return &b.Periods[i]
+		}
+	}
+
+	return nil
+}
+
+// Summaries returns the current period followed by the past periods with
+// any consumption, the most recent first.
+func (b Budget) Summaries(now time.Time) []BudgetPeriodSummary {
+	current := BudgetPeriodStart(now)
+	periods := []BudgetPeriod{{Start: current, Spent: Monetary{Offset: b.Limit.Offset, Currency: b.Limit.Currency}}}
+	if period := b.FindPeriod(current); period != nil {
+		periods[0] = *period
+	}
+
+	for _, period := range b.Periods {
+		if period.Start.Before(current) {
+			periods = append(periods, period)
+		}
+	}
+
+	slices.SortStableFunc(periods[1:], func(a, b BudgetPeriod) int {
+		return b.Start.Compare(a.Start)
+	})
+
+	summaries := make([]BudgetPeriodSummary, len(periods))
+	for i, period := range periods {
+		summaries[i] = BudgetPeriodSummary{
+			Start:      period.Start,
+			Limit:      b.Limit,
+			Spent:      period.Spent,
+			Remaining:  b.Limit.Sub(period.Spent),
+			Percentage: b.consumedPercentage(period.Spent),
+		}
+	}
+
+	return summaries
+}
+
+// consumedPercentage returns how much of the limit was spent, truncated.
+func (b Budget) consumedPercentage(spent Monetary) int {
+	spentValue, limitValue, _ := normalize(spent, b.Limit)
+	if limitValue <= 0 {
+		return 0
+	}
+
+	return spentValue * 100 / limitValue
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+)
+
+// DefaultCategoryNames are seeded into every new wallet so members can start
+// classifying transactions right away.
+var DefaultCategoryNames = []string{
+	"Groceries",
+	"Housing",
+	"Transportation",
+	"Health",
+	"Education",
+	"Leisure",
+	"Salary",
+	"Other",
+}
+
+type Category struct {
+	Id       string
+	WalletId string
+	Name     string
+
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+}
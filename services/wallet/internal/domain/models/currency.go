@@ -0,0 +1,75 @@
+package models
+
+import (
+	"strings"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
+)
+
+// DefaultCurrency is used for wallets created without an explicit currency.
+const DefaultCurrency = "BRL"
+
+// currencyMinorUnits maps the supported ISO 4217 codes to the number of
+// decimal digits of their minor unit.
+var currencyMinorUnits = map[string]int{
+	"ARS": 2,
+	"AUD": 2,
+	"BRL": 2,
+	"CAD": 2,
+	"CHF": 2,
+	"CLP": 0,
+	"CNY": 2,
+	"COP": 2,
+	"CZK": 2,
+	"DKK": 2,
+	"EUR": 2,
+	"GBP": 2,
+	"HKD": 2,
+	"INR": 2,
+	"JPY": 0,
+	"KRW": 0,
+	"KWD": 3,
+	"MXN": 2,
+	"NOK": 2,
+	"NZD": 2,
+	"PEN": 2,
+	"PLN": 2,
+	"PYG": 0,
+	"SEK": 2,
+	"SGD": 2,
+	"TRY": 2,
+	"USD": 2,
+	"UYU": 2,
+	"ZAR": 2,
+}
+
+// ParseCurrency normalizes an ISO 4217 code, an empty code resolves to the
+// default currency.
+func ParseCurrency(code string) (string, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		return DefaultCurrency, nil
+	}
+
+	if _, ok := currencyMinorUnits[code]; !ok {
+		return "", errx.ErrInvalidCurrency
+	}
+
+	return code, nil
+}
+
+// CurrencyOffset returns the offset representing one unit of the currency
+// in its minor unit, e.g. 100 for BRL and 1 for JPY.
+func CurrencyOffset(code string) int {
+	digits, ok := currencyMinorUnits[code]
+	if !ok {
+		digits = currencyMinorUnits[DefaultCurrency]
+	}
+
+	offset := 1
+	for range digits {
+		offset *= 10
+	}
+
+	return offset
+}
@@ -0,0 +1,161 @@
+package models
+
+import (
+	"time"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
+)
+
+type ReportGranularity string
+
+const (
+	// ReportWeekly buckets start on Mondays, as ISO weeks do.
+	ReportWeekly  ReportGranularity = "week"
+	ReportMonthly ReportGranularity = "month"
+	ReportYearly  ReportGranularity = "year"
+)
+
+// MaxReportBuckets bounds the size of a report, e.g. about 10 years of weeks.
+const MaxReportBuckets = 520
+
+func ParseReportGranularity(granularity string) (ReportGranularity, error) {
+	switch ReportGranularity(granularity) {
+	case "":
+		return ReportMonthly, nil
+	case ReportWeekly, ReportMonthly, ReportYearly:
+		return ReportGranularity(granularity), nil
+	default:
+		return "", errx.ErrInvalidReportGranularity
+	}
+}
+
+// BucketStart returns the start of the bucket containing t, in the given location.
+func (g ReportGranularity) BucketStart(t time.Time, location *time.Location) time.Time {
+	year, month, day := t.In(location).Date()
+
+	switch g {
+	case ReportWeekly:
+		// Monday is the first day of the week
+		weekday := (int(time.Date(year, month, day, 0, 0, 0, 0, location).Weekday()) + 6) % 7
+		return time.Date(year, month, day-weekday, 0, 0, 0, 0, location)
+	case ReportYearly:
+		return time.Date(year, time.January, 1, 0, 0, 0, 0, location)
+	default:
+		return time.Date(year, month, 1, 0, 0, 0, 0, location)
+	}
+}
+
+// NextBucket returns the start of the bucket following the one starting at start.
+func (g ReportGranularity) NextBucket(start time.Time) time.Time {
+	switch g {
+	case ReportWeekly:
+		return start.AddDate(0, 0, 7)
+	case ReportYearly:
+		return start.AddDate(1, 0, 0)
+	default:
+		return start.AddDate(0, 1, 0)
+	}
+}
+
+// EvolutionTotals are the active transactions of a bucket, summed up.
+// Transfers between wallets are neither income nor outcome, they are kept
+// apart as a signed amount so the balance still adds up.
+type EvolutionTotals struct {
+	Start     time.Time
+	Income    Monetary
+	Outcome   Monetary
+	Transfers Monetary
+}
+
+type EvolutionBucket struct {
+	Start          time.Time
+	End            time.Time
+	OpeningBalance Monetary
+	Income         Monetary
+	Outcome        Monetary
+	Transfers      Monetary
+	ClosingBalance Monetary
+}
+
+// EvolutionReport is the patrimonial evolution of a wallet between From
+// (inclusive) and To (exclusive).
+type EvolutionReport struct {
+	WalletId    string
+	Granularity ReportGranularity
+	Location    *time.Location
+	From        time.Time
+	To          time.Time
+	Buckets     []EvolutionBucket
+}
+
+// BuildEvolutionReport chains the totals into buckets from the opening
+// balance. Buckets without transactions are included, the first and last
+// ones are cut at From and To.
+func BuildEvolutionReport(walletId string, granularity ReportGranularity, location *time.Location, from, to time.Time, opening Monetary, totals []EvolutionTotals) (*EvolutionReport, error) {
+	if !from.Before(to) {
+		return nil, errx.ErrInvalidReportRange
+	}
+
+	byStart := make(map[time.Time]EvolutionTotals, len(totals))
+	for _, total := range totals {
+		start := granularity.BucketStart(total.Start, location)
+		if current, ok := byStart[start]; ok {
+			total.Income = current.Income.Sum(total.Income)
+			total.Outcome = current.Outcome.Sum(total.Outcome)
+			total.Transfers = current.Transfers.Sum(total.Transfers)
+		}
+		byStart[start] = total
+	}
+
+	zero := Monetary{Offset: opening.Offset, Currency: opening.Currency}
+	report := &EvolutionReport{
+		Granularity: granularity,
+		WalletId:    walletId,
+		Location:    location,
+		From:        from,
+		To:          to,
+		Buckets:     []EvolutionBucket{},
+	}
+
+	balance := opening
+	for start := granularity.BucketStart(from, location); start.Before(to); start = granularity.NextBucket(start) {
+		if len(report.Buckets) == MaxReportBuckets {
+			return nil, errx.ErrInvalidReportRange
+		}
+
+		bucket := EvolutionBucket{
+			Start:          maxTime(start, from),
+			End:            minTime(granularity.NextBucket(start), to),
+			OpeningBalance: balance,
+			Income:         zero,
+			Outcome:        zero,
+			Transfers:      zero,
+		}
+
+		if total, ok := byStart[start]; ok {
+			bucket.Income = zero.Sum(total.Income)
+			bucket.Outcome = zero.Sum(total.Outcome)
+			bucket.Transfers = zero.Sum(total.Transfers)
+		}
+
+		balance = balance.Sum(bucket.Income).Sub(bucket.Outcome).Sum(bucket.Transfers)
+		bucket.ClosingBalance = balance
+		report.Buckets = append(report.Buckets, bucket)
+	}
+
+	return report, nil
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
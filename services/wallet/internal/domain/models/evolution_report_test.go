@@ -0,0 +1,65 @@
+package models_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+)
+
+func TestEvolutionReport(t *testing.T) {
+	saoPaulo, err := time.LoadLocation("America/Sao_Paulo")
+	if err != nil {
+		t.Skipf("timezone database not available: %v", err)
+	}
+
+	brl := func(value int) models.Monetary {
+		return models.Monetary{Value: value, Offset: 100, Currency: "BRL"}
+	}
+
+	t.Run("should start weeks on monday in the member timezone", func(t *testing.T) {
+		// Monday 02:00 UTC is still Sunday in São Paulo
+		moment := time.Date(2026, time.March, 2, 2, 0, 0, 0, time.UTC)
+
+		start := models.ReportWeekly.BucketStart(moment, saoPaulo)
+		expected := time.Date(2026, time.February, 23, 0, 0, 0, 0, saoPaulo)
+		if !start.Equal(expected) {
+			t.Errorf("Expected %v, got %v", expected, start)
+		}
+	})
+
+	t.Run("should chain balances through empty buckets", func(t *testing.T) {
+		from := time.Date(2026, time.January, 1, 0, 0, 0, 0, saoPaulo)
+		to := time.Date(2026, time.April, 1, 0, 0, 0, 0, saoPaulo)
+
+		report, err := models.BuildEvolutionReport("wallet", models.ReportMonthly, saoPaulo, from, to, brl(10000), []models.EvolutionTotals{
+			{Start: time.Date(2026, time.January, 1, 0, 0, 0, 0, saoPaulo), Income: brl(5000), Outcome: brl(2000), Transfers: brl(0)},
+			{Start: time.Date(2026, time.March, 1, 0, 0, 0, 0, saoPaulo), Income: brl(0), Outcome: brl(1000), Transfers: brl(-3000)},
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if len(report.Buckets) != 3 {
+			t.Fatalf("Expected 3 buckets, got %v", len(report.Buckets))
+		}
+
+		expected := []struct{ opening, closing int }{{10000, 13000}, {13000, 13000}, {13000, 9000}}
+		for i, bucket := range report.Buckets {
+			if bucket.OpeningBalance.Value != expected[i].opening || bucket.ClosingBalance.Value != expected[i].closing {
+				t.Errorf("Bucket %v: expected %v -> %v, got %v -> %v", i, expected[i].opening, expected[i].closing, bucket.OpeningBalance.Value, bucket.ClosingBalance.Value)
+			}
+		}
+	})
+
+	t.Run("should reject empty ranges", func(t *testing.T) {
+		moment := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+		_, err := models.BuildEvolutionReport("wallet", models.ReportYearly, time.UTC, moment, moment, brl(0), nil)
+		if !errors.Is(err, errx.ErrInvalidReportRange) {
+			t.Errorf("Expected invalid report range error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,87 @@
+package models
+
+import (
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
+)
+
+// ExchangeRate is the price of one unit of Base in Quote, kept as a fraction
+// so inverse and cross rates stay exact.
+type ExchangeRate struct {
+	Base        string
+	Quote       string
+	Numerator   int
+	Denominator int
+}
+
+// ParseExchangeRate parses a decimal rate such as "5.1034", the price of one
+// unit of base in quote.
+func ParseExchangeRate(base, quote, rate string) (ExchangeRate, error) {
+	base, err := ParseCurrency(base)
+	if err != nil {
+		return ExchangeRate{}, err
+	}
+
+	quote, err = ParseCurrency(quote)
+	if err != nil {
+		return ExchangeRate{}, err
+	}
+
+	amount, err := ParseMonetary(rate, "", ".", "")
+	if err != nil || amount.Value <= 0 {
+		return ExchangeRate{}, errx.ErrInvalidExchangeRate
+	}
+
+	return newExchangeRate(base, quote, amount.Value, validOffset(amount.Offset)), nil
+}
+
+// IdentityRate converts a currency to itself.
+func IdentityRate(currency string) ExchangeRate {
+	return ExchangeRate{Base: currency, Quote: currency, Numerator: 1, Denominator: 1}
+}
+
+func (r ExchangeRate) Inverse() ExchangeRate {
+	return newExchangeRate(r.Quote, r.Base, r.Denominator, r.Numerator)
+}
+
+// Then chains the rate with another one whose base is this rate quote, e.g.
+// USD/BRL then BRL/EUR gives USD/EUR.
+func (r ExchangeRate) Then(next ExchangeRate) (ExchangeRate, error) {
+	if r.Quote != next.Base {
+		return ExchangeRate{}, errx.ErrCurrencyMismatch
+	}
+
+	return newExchangeRate(r.Base, next.Quote, r.Numerator*next.Numerator, r.Denominator*next.Denominator), nil
+}
+
+// Convert converts an amount in the rate base currency to the minor unit of
+// the quote currency, rounding with the given mode.
+func (m Monetary) Convert(rate ExchangeRate, mode RoundingMode) (Monetary, error) {
+	if m.Currency != rate.Base {
+		return Monetary{}, errx.ErrCurrencyMismatch
+	}
+
+	if rate.Base == rate.Quote {
+		return m, nil
+	}
+
+	offset := CurrencyOffset(rate.Quote)
+	numerator := m.Value * rate.Numerator * offset
+	denominator := validOffset(m.Offset) * rate.Denominator
+
+	return Monetary{
+		Value:    divRound(numerator, denominator, mode),
+		Offset:   offset,
+		Currency: rate.Quote,
+	}, nil
+}
+
+func newExchangeRate(base, quote string, numerator, denominator int) ExchangeRate {
+	divisor := gcd(numerator, denominator)
+
+	return ExchangeRate{
+		Base:        base,
+		Quote:       quote,
+		Numerator:   numerator / divisor,
+		Denominator: denominator / divisor,
+	}
+}
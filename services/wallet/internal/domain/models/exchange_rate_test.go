@@ -0,0 +1,57 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+)
+
+func TestExchangeRate(t *testing.T) {
+	usdBrl, err := models.ParseExchangeRate("USD", "BRL", "5.10")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	eurBrl, err := models.ParseExchangeRate("EUR", "BRL", "5.90")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	t.Run("should round the converted amount to the quote minor unit", func(t *testing.T) {
+		converted, err := models.Monetary{Value: 1999, Offset: 100, Currency: "USD"}.Convert(usdBrl, models.RoundHalfEven)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// 19.99 * 5.10 = 101.949
+		if converted.Value != 10195 || converted.Offset != 100 || converted.Currency != "BRL" {
+			t.Errorf("Expected 101.95 BRL, got %v", converted)
+		}
+	})
+
+	t.Run("should keep inverse and cross rates exact", func(t *testing.T) {
+		usdEur, err := usdBrl.Then(eurBrl.Inverse())
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if usdEur.Base != "USD" || usdEur.Quote != "EUR" || usdEur.Numerator != 51 || usdEur.Denominator != 59 {
+			t.Errorf("Expected USD/EUR to be 51/59, got %+v", usdEur)
+		}
+
+		converted, err := models.Monetary{Value: 5900, Offset: 100, Currency: "USD"}.Convert(usdEur, models.RoundHalfEven)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if converted.Value != 5100 {
+			t.Errorf("Expected 51.00 EUR, got %v", converted)
+		}
+	})
+
+	t.Run("should reject amounts in another currency", func(t *testing.T) {
+		_, err := models.Monetary{Value: 100, Offset: 100, Currency: "EUR"}.Convert(usdBrl, models.RoundHalfEven)
+		if err == nil {
+			t.Error("Expected an error converting EUR with a USD rate")
+		}
+	})
+}
@@ -0,0 +1,87 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
+)
+
+// IdempotencyKeyTTL is how long a key is remembered, it can be used for a new
+// request afterwards.
+const IdempotencyKeyTTL = 24 * time.Hour
+
+// IdempotencyLockTimeout is how long a request can run before its key is
+// taken over by a retry, e.g. when the instance running it went down before
+// completing it.
+const IdempotencyLockTimeout = time.Minute
+
+// MaxIdempotencyKeyLength limits the keys clients can send.
+const MaxIdempotencyKeyLength = 255
+
+// IdempotentRequest is a request sent with an Idempotency-Key header. Keys are
+// scoped to the member, the response is stored once the request completes so
+// retries get it back instead of running the request again.
+type IdempotentRequest struct {
+	Key      string
+	MemberId string
+	Method   string
+	Path     string
+	// Hash of the method, path and body, a key can only be reused for the
+	// same request
+	Fingerprint string
+
+	// Set once the request completes
+	StatusCode  int
+	ContentType string
+	Body        []byte
+
+	// Identifies the reservation, a retry taking the key over gets another
+	CreatedAt   time.Time
+	CompletedAt *time.Time
+}
+
+func NewIdempotentRequest(memberId, key, method, path string, body []byte) (*IdempotentRequest, error) {
+	if key == "" || len(key) > MaxIdempotencyKeyLength {
+		return nil, errx.ErrInvalidIdempotencyKey
+	}
+
+	hash := sha256.New()
+	hash.Write([]byte(method + " " + path + "\n"))
+	hash.Write(body)
+
+	return &IdempotentRequest{
+		Key:         key,
+		MemberId:    memberId,
+		Method:      method,
+		Path:        path,
+		Fingerprint: hex.EncodeToString(hash.Sum(nil)),
+		// Kept to the precision the database stores
+		CreatedAt: time.Now().UTC().Truncate(time.Microsecond),
+	}, nil
+}
+
+func (r *IdempotentRequest) IsCompleted() bool {
+	return r.CompletedAt != nil
+}
+
+// IsExpired reports whether the key can be used for a new request.
+func (r *IdempotentRequest) IsExpired(now time.Time) bool {
+	return now.Sub(r.CreatedAt) >= IdempotencyKeyTTL
+}
+
+// IsAbandoned reports whether the request is still in progress after
+// IdempotencyLockTimeout, a retry can then run it again.
+func (r *IdempotentRequest) IsAbandoned(now time.Time) bool {
+	return !r.IsCompleted() && now.Sub(r.CreatedAt) >= IdempotencyLockTimeout
+}
+
+// Complete stores the response to replay on retries.
+func (r *IdempotentRequest) Complete(statusCode int, contentType string, body []byte) {
+	now := time.Now()
+	r.StatusCode = statusCode
+	r.ContentType = contentType
+	r.Body = body
+	r.CompletedAt = &now
+}
@@ -0,0 +1,252 @@
+package models
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/events"
+)
+
+// InvitationTTL is how long an invitation can be answered.
+const InvitationTTL = 7 * 24 * time.Hour
+
+type InvitationStatus string
+
+const (
+	InvitationStatusPending  InvitationStatus = "pending"
+	InvitationStatusAccepted InvitationStatus = "accepted"
+	InvitationStatusDeclined InvitationStatus = "declined"
+	InvitationStatusRevoked  InvitationStatus = "revoked"
+	// InvitationStatusExpired is never stored, pending invitations past
+	// their expiry are reported as expired
+	InvitationStatusExpired InvitationStatus = "expired"
+)
+
+// Invitation offers someone access to a wallet. The invitee is only added
+// to the wallet once the invitation is accepted.
+type Invitation struct {
+	Id       string
+	WalletId string
+	// Name of the wallet when the invitation was sent
+	WalletName string
+	// Always lower case
+	Email string
+	// Nil while nobody with the email signed up
+	MemberId  *string
+	Role      WalletRole
+	Token     string
+	Status    InvitationStatus
+	InvitedBy string
+
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+	RespondedAt *time.Time
+
+	events []events.DomainEvent
+}
+
+// NewInvitation invites the email to the wallet. member is the one with the
+// email, nil when nobody signed up with it yet.
+func NewInvitation(wallet *Wallet, inviter Member, email string, member *Member, role WalletRole) (*Invitation, error) {
+	if err := wallet.CheckPermission(inviter.Id, PermissionManage); err != nil {
+		return nil, err
+	}
+
+	if _, err := ParseWalletRole(string(role)); err != nil {
+		return nil, err
+	}
+
+	if member != nil && wallet.IsMember(member.Id) {
+		return nil, errx.ErrMemberAlreadyInWallet
+	}
+
+	token, err := newInvitationToken()
+	if err != nil {
+		return nil, err
+	}
+
+	currentTime := time.Now()
+	invitation := &Invitation{
+		Id:         uuid.NewString(),
+		WalletId:   wallet.Id,
+		WalletName: wallet.Name,
+		Email:      NormalizeEmail(email),
+		Role:       role,
+		Token:      token,
+		Status:     InvitationStatusPending,
+		InvitedBy:  inviter.Id,
+		CreatedAt:  currentTime,
+		ExpiresAt:  currentTime.Add(InvitationTTL),
+	}
+	if member != nil {
+		invitation.MemberId = &member.Id
+	}
+
+	invitation.AddEvent(events.InvitationCreatedEvent{
+		InvitationId: invitation.Id,
+		WalletId:     invitation.WalletId,
+		WalletName:   invitation.WalletName,
+		Email:        invitation.Email,
+		MemberId:     invitation.MemberId,
+		Role:         string(invitation.Role),
+		InvitedBy:    inviter.Id,
+		InviterName:  strings.TrimSpace(inviter.FirstName + " " + inviter.LastName),
+		Token:        invitation.Token,
+		ExpiresAt:    invitation.ExpiresAt,
+		Timestamp:    currentTime,
+	})
+
+	return invitation, nil
+}
+
+// StatusAt returns the status of the invitation at the moment, pending
+// invitations past their expiry are expired.
+func (i *Invitation) StatusAt(now time.Time) InvitationStatus {
+	if i.IsPending() && !now.Before(i.ExpiresAt) {
+		return InvitationStatusExpired
+	}
+
+	return i.Status
+}
+
+func (i *Invitation) IsPending() bool {
+	return i.Status == InvitationStatusPending
+}
+
+// IsFor reports whether the member is the invitee, by id once the invitation
+// is linked to a member and by email before that.
+func (i *Invitation) IsFor(member Member) bool {
+	if i.MemberId != nil {
+		return *i.MemberId == member.Id
+	}
+
+	return i.Email == NormalizeEmail(member.Email)
+}
+
+// LinkMember resolves an invitation sent before the invitee signed up.
+func (i *Invitation) LinkMember(member Member) {
+	if i.MemberId == nil && i.IsPending() && i.Email == NormalizeEmail(member.Email) {
+		i.MemberId = &member.Id
+	}
+}
+
+// Accept adds the invitee to the wallet with the invited role. Members who
+// got access to the wallet some other way keep their current role.
+func (i *Invitation) Accept(wallet *Wallet, member Member) error {
+	if wallet.Id != i.WalletId {
+		return errx.ErrInvitationNotFound
+	}
+
+	if err := i.answer(member); err != nil {
+		return err
+	}
+
+	if !wallet.IsMember(member.Id) {
+		if err := wallet.AddUser(&member, i.Role); err != nil {
+			return err
+		}
+	}
+
+	i.Status = InvitationStatusAccepted
+	i.AddEvent(events.InvitationAcceptedEvent{
+		InvitationId: i.Id,
+		WalletId:     i.WalletId,
+		MemberId:     member.Id,
+		Timestamp:    *i.RespondedAt,
+	})
+	return nil
+}
+
+func (i *Invitation) Decline(member Member) error {
+	if err := i.answer(member); err != nil {
+		return err
+	}
+
+	i.Status = InvitationStatusDeclined
+	i.AddEvent(events.InvitationDeclinedEvent{
+		InvitationId: i.Id,
+		WalletId:     i.WalletId,
+		MemberId:     member.Id,
+		Timestamp:    *i.RespondedAt,
+	})
+	return nil
+}
+
+// Revoke withdraws a pending invitation, expired ones included. Only members
+// allowed to manage the wallet can revoke its invitations.
+func (i *Invitation) Revoke(wallet *Wallet, revokedBy string) error {
+	if wallet.Id != i.WalletId {
+		return errx.ErrInvitationNotFound
+	}
+
+	if err := wallet.CheckPermission(revokedBy, PermissionManage); err != nil {
+		return err
+	}
+
+	if !i.IsPending() {
+		return errx.ErrInvitationNotPending
+	}
+
+	currentTime := time.Now()
+	i.Status = InvitationStatusRevoked
+	i.RespondedAt = &currentTime
+	i.AddEvent(events.InvitationRevokedEvent{
+		InvitationId: i.Id,
+		WalletId:     i.WalletId,
+		RevokedBy:    revokedBy,
+		Timestamp:    currentTime,
+	})
+	return nil
+}
+
+// answer checks the member can still answer the invitation and links it to
+// the member.
+func (i *Invitation) answer(member Member) error {
+	// Other members are told the invitation does not exist
+	if !i.IsFor(member) {
+		return errx.ErrInvitationNotFound
+	}
+
+	currentTime := time.Now()
+	switch i.StatusAt(currentTime) {
+	case InvitationStatusPending:
+	case InvitationStatusExpired:
+		return errx.ErrInvitationExpired
+	default:
+		return errx.ErrInvitationNotPending
+	}
+
+	i.MemberId = &member.Id
+	i.RespondedAt = &currentTime
+	return nil
+}
+
+func (i *Invitation) AddEvent(event events.DomainEvent) {
+	i.events = append(i.events, event)
+}
+
+func (i *Invitation) Events() []events.DomainEvent {
+	return i.events
+}
+
+func (i *Invitation) ClearEvents() {
+	i.events = nil
+}
+
+// NormalizeEmail makes emails comparable, they are case insensitive.
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+func newInvitationToken() (string, error) {
+	data := make([]byte, 32)
+	if _, err := rand.Read(data); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
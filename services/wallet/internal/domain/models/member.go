@@ -19,6 +19,15 @@ type Member struct {
 	events []events.DomainEvent
 }
 
+// LastChangedAt is when the member data last changed in member-service, used
+// to discard copies older than the one already known.
+func (u *Member) LastChangedAt() time.Time {
+	if u.UpdatedAt != nil {
+		return *u.UpdatedAt
+	}
+	return u.CreatedAt
+}
+
 func (u *Member) AddEvent(event events.DomainEvent) {
 	u.events = append(u.events, event)
 }
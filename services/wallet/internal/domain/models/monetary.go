@@ -1,26 +1,373 @@
 package models
 
+import (
+	"strconv"
+	"strings"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
+)
+
+// Monetary is an exact decimal amount, Value expressed in units of 1/Offset
+// of the currency, e.g. {1050, 100, "BRL"} is R$ 10.50. Amounts with
+// different offsets are normalised to a common offset before any operation
+// so no precision is lost.
 type Monetary struct {
-	Value  int
-	Offset int
+	Value    int
+	Offset   int
+	Currency string
+}
+
+type RoundingMode int
+
+const (
+	// RoundHalfEven rounds to the nearest neighbour, ties to the even one.
+	RoundHalfEven RoundingMode = iota
+	// RoundHalfUp rounds to the nearest neighbour, ties away from zero.
+	RoundHalfUp
+	// RoundHalfDown rounds to the nearest neighbour, ties towards zero.
+	RoundHalfDown
+	// RoundDown truncates towards zero.
+	RoundDown
+	// RoundUp rounds away from zero.
+	RoundUp
+	// RoundFloor rounds towards negative infinity.
+	RoundFloor
+	// RoundCeiling rounds towards positive infinity.
+	RoundCeiling
+)
+
+// SameCurrency reports whether both amounts are in the same currency. An
+// amount without a currency is assumed to match.
+func (m Monetary) SameCurrency(amount Monetary) bool {
+	return m.Currency == "" || amount.Currency == "" || m.Currency == amount.Currency
 }
 
 func (m Monetary) Sum(amount Monetary) Monetary {
-	normalizedValue1 := m.Value / m.Offset
-	normalizedValue2 := amount.Value / amount.Offset
+	value1, value2, offset := normalize(m, amount)
 
 	return Monetary{
-		Value:  m.Offset * (normalizedValue1 + normalizedValue2),
-		Offset: m.Offset,
-	}
+		Value:    value1 + value2,
+		Offset:   offset,
+		Currency: m.Currency,
+	}.reduce(m.preferredOffset())
 }
 
 func (m Monetary) Sub(amount Monetary) Monetary {
-	normalizedValue1 := m.Value / m.Offset
-	normalizedValue2 := amount.Value / amount.Offset
+	value1, value2, offset := normalize(m, amount)
+
+	return Monetary{
+		Value:    value1 - value2,
+		Offset:   offset,
+		Currency: m.Currency,
+	}.reduce(m.preferredOffset())
+}
+
+// Cmp compares both amounts, returning -1, 0 or +1.
+func (m Monetary) Cmp(amount Monetary) int {
+	value1, value2, _ := normalize(m, amount)
+
+	switch {
+	case value1 < value2:
+		return -1
+	case value1 > value2:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func (m Monetary) Neg() Monetary {
+	m.Value = -m.Value
+	return m
+}
+
+func (m Monetary) IsZero() bool {
+	return m.Value == 0
+}
+
+// Rescale converts the amount to another offset, rounding with the given
+// mode when the target offset cannot represent it exactly.
+func (m Monetary) Rescale(offset int, mode RoundingMode) Monetary {
+	offset = validOffset(offset)
+	current := validOffset(m.Offset)
+	if offset == current {
+		m.Offset = current
+		return m
+	}
+
+	common := lcm(current, offset)
+	numerator := m.Value * (common / current)
+	denominator := common / offset
 
 	return Monetary{
-		Value:  m.Offset * (normalizedValue1 - normalizedValue2),
-		Offset: m.Offset,
+		Value:    divRound(numerator, denominator, mode),
+		Offset:   offset,
+		Currency: m.Currency,
+	}
+}
+
+// Round rescales the amount to the minor unit of its currency.
+func (m Monetary) Round(mode RoundingMode) Monetary {
+	return m.Rescale(CurrencyOffset(m.Currency), mode)
+}
+
+// Allocate distributes the amount proportionally to the given ratios without
+// losing any unit, the remainder is handed out one unit at a time starting
+// from the first share.
+func (m Monetary) Allocate(ratios ...int) ([]Monetary, error) {
+	total := 0
+	for _, ratio := range ratios {
+		if ratio < 0 {
+			return nil, errx.ErrInvalidInput
+		}
+		total += ratio
+	}
+
+	if len(ratios) == 0 || total == 0 {
+		return nil, errx.ErrInvalidInput
+	}
+
+	shares := make([]Monetary, len(ratios))
+	remainder := m.Value
+	for i, ratio := range ratios {
+		value := m.Value * ratio / total
+		shares[i] = Monetary{Value: value, Offset: validOffset(m.Offset), Currency: m.Currency}
+		remainder -= value
+	}
+
+	step := 1
+	if remainder < 0 {
+		step = -1
+	}
+	for i := 0; remainder != 0; i = (i + 1) % len(shares) {
+		if ratios[i] == 0 {
+			continue
+		}
+		shares[i].Value += step
+		remainder -= step
+	}
+
+	return shares, nil
+}
+
+// Split divides the amount in n parts as even as possible.
+func (m Monetary) Split(n int) ([]Monetary, error) {
+	if n <= 0 {
+		return nil, errx.ErrInvalidInput
+	}
+
+	ratios := make([]int, n)
+	for i := range ratios {
+		ratios[i] = 1
+	}
+
+	return m.Allocate(ratios...)
+}
+
+// Format renders the amount rounded to the currency minor unit using the
+// given separators, e.g. "1.234,56" for Brazilian notation.
+func (m Monetary) Format(decimalSeparator, thousandsSeparator string) string {
+	rounded := m.Round(RoundHalfEven)
+	digits := 0
+	for offset := rounded.Offset; offset > 1; offset /= 10 {
+		digits++
+	}
+
+	value := rounded.Value
+	sign := ""
+	if value < 0 {
+		sign = "-"
+		value = -value
+	}
+
+	text := strconv.Itoa(value)
+	if len(text) <= digits {
+		text = strings.Repeat("0", digits-len(text)+1) + text
+	}
+
+	integer := text[:len(text)-digits]
+	fraction := text[len(text)-digits:]
+
+	if thousandsSeparator != "" {
+		var grouped strings.Builder
+		for i, digit := range integer {
+			if i > 0 && (len(integer)-i)%3 == 0 {
+				grouped.WriteString(thousandsSeparator)
+			}
+			grouped.WriteRune(digit)
+		}
+		integer = grouped.String()
+	}
+
+	if digits == 0 {
+		return sign + integer
+	}
+
+	return sign + integer + decimalSeparator + fraction
+}
+
+func (m Monetary) String() string {
+	formatted := m.Format(".", "")
+	if m.Currency == "" {
+		return formatted
 	}
+
+	return formatted + " " + m.Currency
+}
+
+// maxFractionDigits bounds the fraction of a parsed amount, finer offsets
+// would overflow.
+const maxFractionDigits = 18
+
+// ParseMonetary parses a decimal amount such as "-1.234,56". The offset is
+// the finest between the currency minor unit and the informed fraction.
+func ParseMonetary(text string, currency string, decimalSeparator, thousandsSeparator string) (Monetary, error) {
+	text = strings.TrimSpace(text)
+	if thousandsSeparator != "" {
+		text = strings.ReplaceAll(text, thousandsSeparator, "")
+	}
+
+	negative := false
+	switch {
+	case strings.HasPrefix(text, "-"):
+		negative = true
+		text = text[1:]
+	case strings.HasPrefix(text, "+"):
+		text = text[1:]
+	}
+
+	integer, fraction, _ := strings.Cut(text, decimalSeparator)
+	if integer == "" && fraction == "" {
+		return Monetary{}, errx.ErrInvalidAmount
+	}
+
+	for _, digit := range integer + fraction {
+		if digit < '0' || digit > '9' {
+			return Monetary{}, errx.ErrInvalidAmount
+		}
+	}
+
+	if len(fraction) > maxFractionDigits {
+		return Monetary{}, errx.ErrInvalidAmount
+	}
+
+	offset := 1
+	for range fraction {
+		offset *= 10
+	}
+
+	value, err := strconv.Atoi(integer + fraction)
+	if integer+fraction == "" || err != nil {
+		return Monetary{}, errx.ErrInvalidAmount
+	}
+
+	if negative {
+		value = -value
+	}
+
+	amount := Monetary{Value: value, Offset: offset, Currency: currency}
+	if currencyOffset := CurrencyOffset(currency); currencyOffset > offset && currencyOffset%offset == 0 {
+		amount = amount.Rescale(currencyOffset, RoundHalfEven)
+	}
+
+	return amount, nil
+}
+
+// preferredOffset is the offset results are reduced to whenever they can be
+// represented exactly.
+func (m Monetary) preferredOffset() int {
+	if _, ok := currencyMinorUnits[m.Currency]; ok {
+		return CurrencyOffset(m.Currency)
+	}
+
+	return validOffset(m.Offset)
+}
+
+// reduce rescales the amount to a smaller offset only when it is exact.
+func (m Monetary) reduce(offset int) Monetary {
+	if m.Offset <= offset || m.Offset%offset != 0 {
+		return m
+	}
+
+	factor := m.Offset / offset
+	if m.Value%factor != 0 {
+		return m
+	}
+
+	return Monetary{Value: m.Value / factor, Offset: offset, Currency: m.Currency}
+}
+
+// normalize returns both values expressed in their least common offset.
+func normalize(a, b Monetary) (int, int, int) {
+	offsetA := validOffset(a.Offset)
+	offsetB := validOffset(b.Offset)
+	offset := lcm(offsetA, offsetB)
+
+	return a.Value * (offset / offsetA), b.Value * (offset / offsetB), offset
+}
+
+func validOffset(offset int) int {
+	if offset <= 0 {
+		return 1
+	}
+
+	return offset
+}
+
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+
+	return a
+}
+
+func lcm(a, b int) int {
+	return a / gcd(a, b) * b
+}
+
+// divRound divides numerator by a positive denominator using the rounding
+// mode to resolve the remainder.
+func divRound(numerator, denominator int, mode RoundingMode) int {
+	quotient := numerator / denominator
+	remainder := numerator % denominator
+	if remainder == 0 {
+		return quotient
+	}
+
+	sign := 1
+	if numerator < 0 {
+		sign = -1
+		remainder = -remainder
+	}
+
+	awayFromZero := false
+	switch mode {
+	case RoundDown:
+	case RoundUp:
+		awayFromZero = true
+	case RoundFloor:
+		awayFromZero = sign < 0
+	case RoundCeiling:
+		awayFromZero = sign > 0
+	default:
+		double := remainder * 2
+		switch {
+		case double > denominator:
+			awayFromZero = true
+		case double == denominator:
+			switch mode {
+			case RoundHalfUp:
+				awayFromZero = true
+			case RoundHalfEven:
+				awayFromZero = quotient%2 != 0
+			}
+		}
+	}
+
+	if awayFromZero {
+		return quotient + sign
+	}
+
+	return quotient
 }
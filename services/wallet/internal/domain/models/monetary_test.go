@@ -0,0 +1,111 @@
+package models_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+)
+
+func TestMonetary(t *testing.T) {
+	t.Run("should keep the cents when summing", func(t *testing.T) {
+		balance := models.Monetary{Value: 1050, Offset: 100, Currency: "BRL"}
+		result := balance.Sum(models.Monetary{Value: 25, Offset: 100, Currency: "BRL"})
+
+		if result.Value != 1075 || result.Offset != 100 {
+			t.Errorf("Expected 1075 with offset 100, got %v with offset %v", result.Value, result.Offset)
+		}
+	})
+
+	t.Run("should normalise mixed offsets without truncating", func(t *testing.T) {
+		balance := models.Monetary{Value: 1000, Offset: 100, Currency: "BRL"}
+		result := balance.Sub(models.Monetary{Value: 1505, Offset: 1000, Currency: "BRL"})
+
+		if result.Value != 8495 || result.Offset != 1000 {
+			t.Errorf("Expected 8495 with offset 1000, got %v with offset %v", result.Value, result.Offset)
+		}
+
+		result = result.Sum(models.Monetary{Value: 5, Offset: 1000, Currency: "BRL"})
+		if result.Value != 850 || result.Offset != 100 {
+			t.Errorf("Expected 850 with offset 100, got %v with offset %v", result.Value, result.Offset)
+		}
+	})
+
+	t.Run("should round with the given mode", func(t *testing.T) {
+		cases := []struct {
+			value    int
+			mode     models.RoundingMode
+			expected int
+		}{
+			{1005, models.RoundHalfEven, 100},
+			{1015, models.RoundHalfEven, 102},
+			{1005, models.RoundHalfUp, 101},
+			{1005, models.RoundHalfDown, 100},
+			{1001, models.RoundUp, 101},
+			{1009, models.RoundDown, 100},
+			{-1001, models.RoundFloor, -101},
+			{-1009, models.RoundCeiling, -100},
+		}
+
+		for _, c := range cases {
+			result := models.Monetary{Value: c.value, Offset: 1000, Currency: "BRL"}.Round(c.mode)
+			if result.Value != c.expected || result.Offset != 100 {
+				t.Errorf("Expected %v rounded with mode %v to be %v, got %v", c.value, c.mode, c.expected, result.Value)
+			}
+		}
+	})
+
+	t.Run("should split without losing cents", func(t *testing.T) {
+		shares, err := models.Monetary{Value: 1000, Offset: 100, Currency: "BRL"}.Split(3)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		expected := []int{334, 333, 333}
+		for i, share := range shares {
+			if share.Value != expected[i] {
+				t.Errorf("Expected share %v to be %v, got %v", i, expected[i], share.Value)
+			}
+		}
+	})
+
+	t.Run("should allocate by ratio", func(t *testing.T) {
+		shares, err := models.Monetary{Value: 5, Offset: 100}.Allocate(70, 30)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if shares[0].Value != 4 || shares[1].Value != 1 {
+			t.Errorf("Expected shares 4 and 1, got %v and %v", shares[0].Value, shares[1].Value)
+		}
+	})
+
+	t.Run("should format and parse", func(t *testing.T) {
+		amount := models.Monetary{Value: -123456789, Offset: 100, Currency: "BRL"}
+		if formatted := amount.Format(",", "."); formatted != "-1.234.567,89" {
+			t.Errorf("Expected -1.234.567,89, got %v", formatted)
+		}
+
+		if formatted := (models.Monetary{Value: 5, Offset: 100, Currency: "USD"}).String(); formatted != "0.05 USD" {
+			t.Errorf("Expected 0.05 USD, got %v", formatted)
+		}
+
+		parsed, err := models.ParseMonetary("1.234,5", "BRL", ",", ".")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if parsed.Value != 123450 || parsed.Offset != 100 {
+			t.Errorf("Expected 123450 with offset 100, got %v with offset %v", parsed.Value, parsed.Offset)
+		}
+
+		if _, err := models.ParseMonetary("0,0000000000000000001", "BRL", ",", "."); !errors.Is(err, errx.ErrInvalidAmount) {
+			t.Errorf("Expected invalid amount error for 19 fraction digits, got %v", err)
+		}
+
+		if _, err := models.ParseMonetary("12,3a", "BRL", ",", "."); !errors.Is(err, errx.ErrInvalidAmount) {
+			t.Errorf("Expected invalid amount error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,51 @@
+package models
+
+// NetWorth is the consolidated position of a member across wallets,
+// converted to a single currency with the current exchange rates.
+type NetWorth struct {
+	Currency string
+	Total    Monetary
+	Wallets  []NetWorthWallet
+	// Combined evolution of the wallets, its WalletId is empty
+	Evolution *EvolutionReport
+}
+
+type NetWorthWallet struct {
+	Wallet WalletSummary
+	// Rate from the wallet currency to the net worth currency
+	Rate ExchangeRate
+	// Balance converted to the net worth currency
+	Balance Monetary
+	// Share of the total in percentage points, truncated to two decimals. It
+	// may be negative or above 100 when some balances are negative
+	Share float64
+}
+
+// NewNetWorth adds up the converted balances of the wallets and computes the
+// share of each one in the total.
+func NewNetWorth(currency string, wallets []NetWorthWallet) *NetWorth {
+	netWorth := &NetWorth{
+		Currency: currency,
+		Total:    Monetary{Offset: CurrencyOffset(currency), Currency: currency},
+		Wallets:  wallets,
+	}
+
+	for _, wallet := range wallets {
+		netWorth.Total = netWorth.Total.Sum(wallet.Balance)
+	}
+
+	for i, wallet := range netWorth.Wallets {
+		balance, total, _ := normalize(wallet.Balance, netWorth.Total)
+		if total == 0 {
+			continue
+		}
+		if total < 0 {
+			balance, total = -balance, -total
+		}
+
+		// Basis points keep two decimals of the percentage
+		netWorth.Wallets[i].Share = float64(divRound(balance*10000, total, RoundDown)) / 100
+	}
+
+	return netWorth
+}
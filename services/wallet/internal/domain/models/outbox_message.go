@@ -0,0 +1,64 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/events"
+)
+
+// OutboxMessage is a domain event stored with the change that raised it and
+// published to the broker afterwards. EventId stays the same across retries
+// so consumers can discard repeated deliveries.
+type OutboxMessage struct {
+	Id          int64
+	EventId     string
+	EventType   string
+	AggregateId string
+	Payload     []byte
+	OccurredAt  time.Time
+
+	CreatedAt time.Time
+	// Failed publishing attempts so far and the reason of the last one
+	Attempts  int
+	LastError *string
+	SentAt    *time.Time
+	// Set once the message is given up on after too many failed attempts
+	FailedAt *time.Time
+}
+
+func NewOutboxMessage(event events.DomainEvent) (OutboxMessage, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return OutboxMessage{}, err
+	}
+
+	return OutboxMessage{
+		EventId:     uuid.NewString(),
+		EventType:   event.EventType(),
+		AggregateId: event.AggregateID(),
+		Payload:     payload,
+		OccurredAt:  event.OccurredAt(),
+		CreatedAt:   time.Now(),
+	}, nil
+}
+
+// IsPending reports whether the message still has to be published.
+func (m OutboxMessage) IsPending() bool {
+	return m.SentAt == nil && m.FailedAt == nil
+}
+
+// RecordFailure counts a failed attempt to publish the message. Once it
+// reaches maxAttempts the message is given up on and marked as failed, so it
+// no longer holds back the messages stored after it.
+func (m *OutboxMessage) RecordFailure(err error, maxAttempts int) {
+	lastError := err.Error()
+	m.Attempts++
+	m.LastError = &lastError
+
+	if m.Attempts >= maxAttempts {
+		currentTime := time.Now()
+		m.FailedAt = &currentTime
+	}
+}
@@ -0,0 +1,132 @@
+package models
+
+import (
+	"time"
+)
+
+type RecurrenceFrequency string
+
+const (
+	// RecurrenceWeekly occurs every week on the weekday of the start date.
+	RecurrenceWeekly RecurrenceFrequency = "weekly"
+	// RecurrenceMonthly occurs every month on DayOfMonth, clamped to the last
+	// day of shorter months.
+	RecurrenceMonthly RecurrenceFrequency = "monthly"
+	// RecurrenceYearly occurs every year on the anniversary of the start date.
+	RecurrenceYearly RecurrenceFrequency = "yearly"
+)
+
+type RecurringTransactionStatus string
+
+const (
+	// RecurringTransactionStatusActive definitions still have occurrences to materialise.
+	RecurringTransactionStatusActive RecurringTransactionStatus = "active"
+	// RecurringTransactionStatusFinished definitions went past their end date.
+	RecurringTransactionStatusFinished RecurringTransactionStatus = "finished"
+	// RecurringTransactionStatusCancelled definitions were stopped by a member.
+	RecurringTransactionStatusCancelled RecurringTransactionStatus = "cancelled"
+)
+
+// RecurringTransaction is the definition of a transaction registered on a
+// schedule. Occurrences are dates in UTC, NextOccurrence is the first one not
+// materialised yet.
+type RecurringTransaction struct {
+	Id       string
+	WalletId string
+
+	Amount      Monetary
+	CreatedBy   Member
+	Type        TransactionType
+	Description string
+	CategoryId  *string
+
+	Frequency  RecurrenceFrequency
+	DayOfMonth int
+	StartDate  time.Time
+	EndDate    *time.Time
+
+	Status         RecurringTransactionStatus
+	NextOccurrence time.Time
+	LastOccurrence *time.Time
+
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+}
+
+// RecurrenceSchedule describes when a recurring transaction occurs.
+type RecurrenceSchedule struct {
+	Frequency  RecurrenceFrequency
+	DayOfMonth int
+	StartDate  time.Time
+	EndDate    *time.Time
+}
+
+func (r RecurringTransaction) IsActive() bool {
+	return r.Status == "" || r.Status == RecurringTransactionStatusActive
+}
+
+// IsDue reports whether the next occurrence should be materialised at now.
+func (r RecurringTransaction) IsDue(now time.Time) bool {
+	return r.IsActive() && !r.NextOccurrence.After(now)
+}
+
+// OccurrenceAfter returns the occurrence following the given one.
+func (r RecurringTransaction) OccurrenceAfter(occurrence time.Time) time.Time {
+	switch r.Frequency {
+	case RecurrenceWeekly:
+		return occurrence.AddDate(0, 0, 7)
+	case RecurrenceYearly:
+		return clampedDate(occurrence.Year()+1, r.StartDate.Month(), r.StartDate.Day())
+	default:
+		return clampedDate(occurrence.Year(), occurrence.Month()+1, r.DayOfMonth)
+	}
+}
+
+// firstOccurrence returns the first occurrence on or after the start date.
+func (r RecurringTransaction) firstOccurrence() time.Time {
+	if r.Frequency != RecurrenceMonthly {
+		return r.StartDate
+	}
+
+	candidate := clampedDate(r.StartDate.Year(), r.StartDate.Month(), r.DayOfMonth)
+	if candidate.Before(r.StartDate) {
+		candidate = clampedDate(r.StartDate.Year(), r.StartDate.Month()+1, r.DayOfMonth)
+	}
+
+	return candidate
+}
+
+// advance moves the definition past the given occurrence, finishing it when
+// the following one is after the end date.
+func (r *RecurringTransaction) advance(occurrence time.Time, now time.Time) {
+	last := occurrence
+	r.LastOccurrence = &last
+	r.NextOccurrence = r.OccurrenceAfter(occurrence)
+	r.UpdatedAt = &now
+
+	if r.EndDate != nil && r.NextOccurrence.After(*r.EndDate) {
+		r.Status = RecurringTransactionStatusFinished
+	}
+}
+
+// RecurrenceDate truncates the time to its date in UTC, which is how
+// occurrences are compared and stored.
+func RecurrenceDate(t time.Time) time.Time {
+	year, month, day := t.UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// clampedDate builds a date in UTC, clamping the day to the last day of the
+// month. Months out of range are normalised, e.g. month 13 is January.
+func clampedDate(year int, month time.Month, day int) time.Time {
+	firstOfMonth := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	if day < 1 {
+		day = 1
+	}
+
+	return firstOfMonth.AddDate(0, 0, day-1)
+}
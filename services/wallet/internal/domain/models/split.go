@@ -0,0 +1,123 @@
+package models
+
+import (
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
+)
+
+type SplitMethod string
+
+const (
+	// SplitEqual divides the amount evenly among the members.
+	SplitEqual SplitMethod = "equal"
+	// SplitPercentage gives each member a percentage of the amount.
+	SplitPercentage SplitMethod = "percentage"
+	// SplitExact gives each member a fixed amount.
+	SplitExact SplitMethod = "exact"
+)
+
+// fullPercentage is 100% in hundredths of a percent.
+const fullPercentage = 10000
+
+func ParseSplitMethod(method string) (SplitMethod, error) {
+	switch SplitMethod(method) {
+	case SplitEqual, SplitPercentage, SplitExact:
+		return SplitMethod(method), nil
+	default:
+		return "", errx.ErrInvalidSplit
+	}
+}
+
+// TransactionSplit divides an expense among members of the wallet, each one
+// owing its share to the member who paid it.
+type TransactionSplit struct {
+	Method SplitMethod
+	Shares []SplitShare
+}
+
+type SplitShare struct {
+	MemberId string
+	// Hundredths of a percent, e.g. 2550 is 25.5%. Only used by percentage splits
+	Percentage int
+	// Part of the amount owed by the member. Given by exact splits, computed
+	// for the others
+	Amount Monetary
+}
+
+// Allocate computes the share of each member of the split for the amount.
+// Units that cannot be divided evenly go to the first shares.
+func (s TransactionSplit) Allocate(amount Monetary) (*TransactionSplit, error) {
+	if len(s.Shares) == 0 {
+		return nil, errx.ErrInvalidSplit
+	}
+
+	seen := map[string]bool{}
+	for _, share := range s.Shares {
+		if share.MemberId == "" || seen[share.MemberId] {
+			return nil, errx.ErrInvalidSplit
+		}
+		seen[share.MemberId] = true
+	}
+
+	shares := make([]SplitShare, len(s.Shares))
+	copy(shares, s.Shares)
+
+	switch s.Method {
+	case SplitEqual:
+		amounts, err := amount.Split(len(shares))
+		if err != nil {
+			return nil, errx.ErrInvalidSplit
+		}
+		for i := range shares {
+			shares[i].Percentage = 0
+			shares[i].Amount = amounts[i]
+		}
+	case SplitPercentage:
+		ratios := make([]int, len(shares))
+		total := 0
+		for i, share := range shares {
+			if share.Percentage <= 0 {
+				return nil, errx.ErrInvalidSplit
+			}
+			ratios[i] = share.Percentage
+			total += share.Percentage
+		}
+		if total != fullPercentage {
+			return nil, errx.ErrInvalidSplit
+		}
+
+		amounts, err := amount.Allocate(ratios...)
+		if err != nil {
+			return nil, errx.ErrInvalidSplit
+		}
+		for i := range shares {
+			shares[i].Amount = amounts[i]
+		}
+	case SplitExact:
+		total := Monetary{Offset: amount.Offset, Currency: amount.Currency}
+		for i, share := range shares {
+			if share.Amount.Value <= 0 || !amount.SameCurrency(share.Amount) {
+				return nil, errx.ErrInvalidSplit
+			}
+			shares[i].Percentage = 0
+			shares[i].Amount.Currency = amount.Currency
+			total = total.Sum(share.Amount)
+		}
+		if total.Cmp(amount) != 0 {
+			return nil, errx.ErrInvalidSplit
+		}
+	default:
+		return nil, errx.ErrInvalidSplit
+	}
+
+	return &TransactionSplit{Method: s.Method, Shares: shares}, nil
+}
+
+// MemberIds returns the members sharing the transaction.
+func (s TransactionSplit) MemberIds() []string {
+	ids := make([]string, len(s.Shares))
+	for i, share := range s.Shares {
+		ids[i] = share.MemberId
+	}
+
+	return ids
+}
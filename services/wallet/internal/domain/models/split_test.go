@@ -0,0 +1,218 @@
+package models_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+)
+
+func TestTransactionSplit(t *testing.T) {
+	amount := models.Monetary{Value: 1000, Offset: 100, Currency: "BRL"}
+
+	t.Run("should give the remaining cents of an equal split to the first shares", func(t *testing.T) {
+		split, err := models.TransactionSplit{
+			Method: models.SplitEqual,
+			Shares: []models.SplitShare{{MemberId: "a"}, {MemberId: "b"}, {MemberId: "c"}},
+		}.Allocate(amount)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		expected := []int{334, 333, 333}
+		for i, share := range split.Shares {
+			if share.Amount.Value != expected[i] {
+				t.Errorf("Expected share %v to be %v, got %v", i, expected[i], share.Amount.Value)
+			}
+		}
+	})
+
+	t.Run("should allocate percentages adding up to the amount", func(t *testing.T) {
+		split, err := models.TransactionSplit{
+			Method: models.SplitPercentage,
+			Shares: []models.SplitShare{{MemberId: "a", Percentage: 3333}, {MemberId: "b", Percentage: 6667}},
+		}.Allocate(amount)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if split.Shares[0].Amount.Value+split.Shares[1].Amount.Value != 1000 {
+			t.Errorf("Expected shares to add up to 1000, got %v and %v", split.Shares[0].Amount.Value, split.Shares[1].Amount.Value)
+		}
+	})
+
+	t.Run("should reject splits not adding up to the amount", func(t *testing.T) {
+		splits := []models.TransactionSplit{
+			{Method: models.SplitPercentage, Shares: []models.SplitShare{{MemberId: "a", Percentage: 5000}, {MemberId: "b", Percentage: 4000}}},
+			{Method: models.SplitExact, Shares: []models.SplitShare{
+				{MemberId: "a", Amount: models.Monetary{Value: 600, Offset: 100}},
+				{MemberId: "b", Amount: models.Monetary{Value: 300, Offset: 100}},
+			}},
+			{Method: models.SplitEqual, Shares: []models.SplitShare{{MemberId: "a"}, {MemberId: "a"}}},
+			{Method: models.SplitEqual},
+		}
+
+		for _, split := range splits {
+			if _, err := split.Allocate(amount); !errors.Is(err, errx.ErrInvalidSplit) {
+				t.Errorf("Expected invalid split error for %v, got %v", split, err)
+			}
+		}
+	})
+}
+
+func TestWalletSettlement(t *testing.T) {
+	alice := models.Member{Id: "alice", FirstName: "Alice"}
+	bob := models.Member{Id: "bob", FirstName: "Bob"}
+	carol := models.Member{Id: "carol", FirstName: "Carol"}
+
+	setup := func(t *testing.T) *models.Wallet {
+		wallet := models.CreateNewWallet("Trip", &alice, "BRL")
+		for _, member := range []models.Member{bob, carol} {
+			if err := wallet.AddUser(&member, models.WalletRoleEditor); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+		}
+
+		return wallet
+	}
+
+	brl := func(value int) models.Monetary {
+		return models.Monetary{Value: value, Offset: 100, Currency: "BRL"}
+	}
+
+	t.Run("should settle shared expenses with fewer payments than members", func(t *testing.T) {
+		wallet := setup(t)
+
+		// Alice pays 90.00 and Bob 30.00, both shared by everyone
+		if _, err := wallet.RegisterSplitTransaction(brl(9000), alice, "Hotel", nil, models.TransactionSplit{Method: models.SplitEqual}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if _, err := wallet.RegisterSplitTransaction(brl(3000), bob, "Dinner", nil, models.TransactionSplit{Method: models.SplitEqual}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		settlement := wallet.Settlement()
+
+		expected := []models.SettlementPayment{
+			{FromMemberId: carol.Id, ToMemberId: alice.Id, Amount: brl(4000)},
+			{FromMemberId: bob.Id, ToMemberId: alice.Id, Amount: brl(1000)},
+		}
+		if len(settlement.Payments) != len(expected) {
+			t.Fatalf("Expected %v payments, got %v", len(expected), settlement.Payments)
+		}
+		for i, payment := range settlement.Payments {
+			if payment.FromMemberId != expected[i].FromMemberId || payment.ToMemberId != expected[i].ToMemberId || payment.Amount.Cmp(expected[i].Amount) != 0 {
+				t.Errorf("Expected payment %v to be %v, got %v", i, expected[i], payment)
+			}
+		}
+	})
+
+	t.Run("should settle independent debts apart to make the fewest payments", func(t *testing.T) {
+		wallet := setup(t)
+		dave := models.Member{Id: "dave", FirstName: "Dave"}
+		erin := models.Member{Id: "erin", FirstName: "Erin"}
+		for _, member := range []models.Member{dave, erin} {
+			if err := wallet.AddUser(&member, models.WalletRoleEditor); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+		}
+
+		// Paying the largest debt to the largest credit would take 4 payments
+		if _, err := wallet.RegisterSplitTransaction(brl(4000), alice, "Hotel", nil, models.TransactionSplit{
+			Method: models.SplitExact,
+			Shares: []models.SplitShare{{MemberId: bob.Id, Amount: brl(2000)}, {MemberId: carol.Id, Amount: brl(2000)}},
+		}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if _, err := wallet.RegisterSplitTransaction(brl(3000), dave, "Dinner", nil, models.TransactionSplit{
+			Method: models.SplitExact,
+			Shares: []models.SplitShare{{MemberId: erin.Id, Amount: brl(3000)}},
+		}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		settlement := wallet.Settlement()
+
+		expected := []models.SettlementPayment{
+			{FromMemberId: erin.Id, ToMemberId: dave.Id, Amount: brl(3000)},
+			{FromMemberId: bob.Id, ToMemberId: alice.Id, Amount: brl(2000)},
+			{FromMemberId: carol.Id, ToMemberId: alice.Id, Amount: brl(2000)},
+		}
+		if len(settlement.Payments) != len(expected) {
+			t.Fatalf("Expected %v payments, got %v", len(expected), settlement.Payments)
+		}
+		for i, payment := range settlement.Payments {
+			if payment.FromMemberId != expected[i].FromMemberId || payment.ToMemberId != expected[i].ToMemberId || payment.Amount.Cmp(expected[i].Amount) != 0 {
+				t.Errorf("Expected payment %v to be %v, got %v", i, expected[i], payment)
+			}
+		}
+	})
+
+	t.Run("should zero the debt once settled up", func(t *testing.T) {
+		wallet := setup(t)
+
+		if _, err := wallet.RegisterSplitTransaction(brl(5000), alice, "Groceries", nil, models.TransactionSplit{
+			Method: models.SplitExact,
+			Shares: []models.SplitShare{{MemberId: alice.Id, Amount: brl(2000)}, {MemberId: bob.Id, Amount: brl(3000)}},
+		}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		settlement, err := wallet.RegisterSettlement(bob, alice.Id, nil, "")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if settlement.Amount.Cmp(brl(3000)) != 0 {
+			t.Errorf("Expected the suggested 30.00 to be settled, got %v", settlement.Amount)
+		}
+
+		if payments := wallet.Settlement().Payments; len(payments) != 0 {
+			t.Errorf("Expected nothing left to settle, got %v", payments)
+		}
+
+		if _, err := wallet.RegisterSettlement(bob, alice.Id, nil, ""); !errors.Is(err, errx.ErrNothingToSettle) {
+			t.Errorf("Expected nothing to settle error, got %v", err)
+		}
+	})
+
+	t.Run("should only split and settle among members", func(t *testing.T) {
+		wallet := setup(t)
+
+		_, err := wallet.RegisterSplitTransaction(brl(1000), alice, "Taxi", nil, models.TransactionSplit{
+			Method: models.SplitEqual,
+			Shares: []models.SplitShare{{MemberId: alice.Id}, {MemberId: "stranger"}},
+		})
+		if !errors.Is(err, errx.ErrInvalidSplit) {
+			t.Errorf("Expected invalid split error, got %v", err)
+		}
+
+		amount := brl(1000)
+		if _, err := wallet.RegisterSettlement(alice, alice.Id, &amount, ""); !errors.Is(err, errx.ErrInvalidSettlement) {
+			t.Errorf("Expected invalid settlement error, got %v", err)
+		}
+	})
+
+	t.Run("should not remove a member until the shared expenses are settled", func(t *testing.T) {
+		wallet := setup(t)
+
+		if _, err := wallet.RegisterSplitTransaction(brl(6000), alice, "Groceries", nil, models.TransactionSplit{Method: models.SplitEqual}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if err := wallet.RemoveMember(bob.Id, bob.Id); !errors.Is(err, errx.ErrMemberHasOpenBalance) {
+			t.Errorf("Expected member has open balance error, got %v", err)
+		}
+		if !wallet.IsMember(bob.Id) {
+			t.Fatalf("Expected the member to be kept in the wallet")
+		}
+
+		if _, err := wallet.RegisterSettlement(bob, alice.Id, nil, ""); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if err := wallet.RemoveMember(bob.Id, bob.Id); err != nil {
+			t.Errorf("Expected the settled member to be removed, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,209 @@
+package models
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
+)
+
+// MaxStatementRows bounds the size of an imported statement.
+const MaxStatementRows = 5000
+
+type SignConvention string
+
+const (
+	// SignNegativeIsWithdraw reads negative amounts as withdraws, as checking
+	// account statements do.
+	SignNegativeIsWithdraw SignConvention = "negative_is_withdraw"
+	// SignNegativeIsDeposit reads negative amounts as deposits, as credit card
+	// statements listing the charges as positive values do.
+	SignNegativeIsDeposit SignConvention = "negative_is_deposit"
+)
+
+// CSVMapping tells where each field is in a CSV statement. Columns are
+// header names or 1-based positions.
+type CSVMapping struct {
+	DateColumn        string
+	DescriptionColumn string
+	AmountColumn      string
+
+	// Layout written with YYYY, YY, MM and DD, e.g. DD/MM/YYYY. Defaults to YYYY-MM-DD
+	DateFormat string
+	// Defaults to "." with "," as the thousands separator, "," defaults the
+	// thousands separator to "." as in "1.234,56"
+	DecimalSeparator   string
+	ThousandsSeparator string
+	// Defaults to ','
+	Delimiter rune
+	HasHeader bool
+	// Defaults to SignNegativeIsWithdraw
+	SignConvention SignConvention
+}
+
+// ParseCSVStatement reads the rows of a CSV statement in the wallet
+// currency. Rows that cannot be read are returned with an Error, problems
+// with the file as a whole fail the parsing.
+func ParseCSVStatement(r io.Reader, mapping CSVMapping, currency string) ([]StatementRow, error) {
+	mapping, err := mapping.withDefaults()
+	if err != nil {
+		return nil, err
+	}
+
+	// Spreadsheets often save the file with a byte order mark
+	buffered := bufio.NewReader(r)
+	if bom, err := buffered.Peek(3); err == nil && string(bom) == "\uFEFF" {
+		buffered.Discard(3)
+	}
+
+	reader := csv.NewReader(buffered)
+	reader.Comma = mapping.Delimiter
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+
+	var header []string
+	if mapping.HasHeader {
+		header, err = reader.Read()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", errx.ErrInvalidStatement, err)
+		}
+	}
+
+	dateIndex, err := columnIndex(mapping.DateColumn, header)
+	if err != nil {
+		return nil, err
+	}
+	descriptionIndex, err := columnIndex(mapping.DescriptionColumn, header)
+	if err != nil {
+		return nil, err
+	}
+	amountIndex, err := columnIndex(mapping.AmountColumn, header)
+	if err != nil {
+		return nil, err
+	}
+
+	layout := strings.NewReplacer("YYYY", "2006", "YY", "06", "MM", "01", "DD", "02").Replace(mapping.DateFormat)
+
+	rows := []StatementRow{}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", errx.ErrInvalidStatement, err)
+		}
+
+		if len(rows) == MaxStatementRows {
+			return nil, fmt.Errorf("%w: more than %d rows", errx.ErrInvalidStatement, MaxStatementRows)
+		}
+
+		line, _ := reader.FieldPos(0)
+		row := StatementRow{Line: line}
+
+		if len(record) <= max(dateIndex, descriptionIndex, amountIndex) {
+			row.Error = "missing columns"
+			rows = append(rows, row)
+			continue
+		}
+
+		row.Description = strings.TrimSpace(record[descriptionIndex])
+
+		date, err := time.ParseInLocation(layout, strings.TrimSpace(record[dateIndex]), time.UTC)
+		if err != nil {
+			row.Error = "date must be formatted as " + mapping.DateFormat
+			rows = append(rows, row)
+			continue
+		}
+		row.Date = date
+
+		amount, err := ParseMonetary(record[amountIndex], currency, mapping.DecimalSeparator, mapping.ThousandsSeparator)
+		if err != nil || amount.IsZero() {
+			row.Error = "invalid amount"
+			rows = append(rows, row)
+			continue
+		}
+
+		negative := amount.Value < 0
+		if negative {
+			amount = amount.Neg()
+		}
+
+		row.Amount = amount
+		row.Type = TransactionTypeDeposit
+		if negative == (mapping.SignConvention == SignNegativeIsWithdraw) {
+			row.Type = TransactionTypeWithdraw
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+func (m CSVMapping) withDefaults() (CSVMapping, error) {
+	if m.DateColumn == "" || m.DescriptionColumn == "" || m.AmountColumn == "" {
+		return m, fmt.Errorf("%w: date, description and amount columns are required", errx.ErrInvalidStatementMapping)
+	}
+
+	if m.DateFormat == "" {
+		m.DateFormat = "YYYY-MM-DD"
+	}
+
+	switch m.DecimalSeparator {
+	case "", ".":
+		m.DecimalSeparator = "."
+		if m.ThousandsSeparator == "" {
+			m.ThousandsSeparator = ","
+		}
+	case ",":
+		if m.ThousandsSeparator == "" {
+			m.ThousandsSeparator = "."
+		}
+	default:
+		return m, fmt.Errorf("%w: the decimal separator must be \".\" or \",\"", errx.ErrInvalidStatementMapping)
+	}
+
+	if m.ThousandsSeparator == m.DecimalSeparator {
+		return m, fmt.Errorf("%w: the thousands and decimal separators must differ", errx.ErrInvalidStatementMapping)
+	}
+
+	if m.Delimiter == 0 {
+		m.Delimiter = ','
+	}
+
+	switch m.SignConvention {
+	case "":
+		m.SignConvention = SignNegativeIsWithdraw
+	case SignNegativeIsWithdraw, SignNegativeIsDeposit:
+	default:
+		return m, fmt.Errorf("%w: unknown sign convention %q", errx.ErrInvalidStatementMapping, m.SignConvention)
+	}
+
+	return m, nil
+}
+
+// columnIndex resolves a column by its 1-based position or its header name.
+func columnIndex(column string, header []string) (int, error) {
+	if position, err := strconv.Atoi(column); err == nil {
+		if position < 1 {
+			return 0, fmt.Errorf("%w: column positions start at 1", errx.ErrInvalidStatementMapping)
+		}
+		return position - 1, nil
+	}
+
+	for i, name := range header {
+		if strings.EqualFold(strings.TrimSpace(name), strings.TrimSpace(column)) {
+			return i, nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w: column %q not found in the header", errx.ErrInvalidStatementMapping, column)
+}
@@ -0,0 +1,48 @@
+package models_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+)
+
+func TestParseCSVStatement(t *testing.T) {
+	t.Run("should read columns by position with the inverted sign convention", func(t *testing.T) {
+		statement := "2026-03-05,Streaming,\"39.90\"\n2026-03-06,Refund,-10.00\n"
+
+		rows, err := models.ParseCSVStatement(strings.NewReader(statement), models.CSVMapping{
+			DateColumn:        "1",
+			DescriptionColumn: "2",
+			AmountColumn:      "3",
+			SignConvention:    models.SignNegativeIsDeposit,
+		}, "BRL")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if len(rows) != 2 {
+			t.Fatalf("Expected 2 rows, got %v", len(rows))
+		}
+		if rows[0].Type != models.TransactionTypeWithdraw || rows[0].Amount.Value != 3990 {
+			t.Errorf("Expected a withdraw of 39.90, got %v %v", rows[0].Type, rows[0].Amount)
+		}
+		if rows[1].Type != models.TransactionTypeDeposit || rows[1].Amount.Value != 1000 {
+			t.Errorf("Expected a deposit of 10.00, got %v %v", rows[1].Type, rows[1].Amount)
+		}
+	})
+
+	t.Run("should fail when a mapped column is not in the header", func(t *testing.T) {
+		_, err := models.ParseCSVStatement(strings.NewReader("Date,Memo,Amount\n"), models.CSVMapping{
+			DateColumn:        "Date",
+			DescriptionColumn: "Description",
+			AmountColumn:      "Amount",
+			HasHeader:         true,
+		}, "BRL")
+		if !errors.Is(err, errx.ErrInvalidStatementMapping) {
+			t.Errorf("Expected invalid statement mapping error, got %v", err)
+		}
+	})
+}
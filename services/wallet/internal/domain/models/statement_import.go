@@ -0,0 +1,284 @@
+package models
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/events"
+)
+
+type StatementFormat string
+
+const (
+	StatementFormatCSV StatementFormat = "csv"
+	// StatementFormatOFX also covers QFX files, which are OFX with Quicken extensions.
+	StatementFormatOFX StatementFormat = "ofx"
+)
+
+func ParseStatementFormat(format string) (StatementFormat, error) {
+	switch StatementFormat(strings.ToLower(format)) {
+	case "", StatementFormatCSV:
+		return StatementFormatCSV, nil
+	case StatementFormatOFX, "qfx":
+		return StatementFormatOFX, nil
+	default:
+		return "", errx.ErrInvalidStatementFormat
+	}
+}
+
+type StatementImportStatus string
+
+const (
+	// StatementImportStatusPreview imports were parsed but nothing was registered yet.
+	StatementImportStatusPreview StatementImportStatus = "preview"
+	// StatementImportStatusCommitted imports had their accepted rows registered.
+	StatementImportStatusCommitted StatementImportStatus = "committed"
+)
+
+// StatementImport is a bank statement uploaded to a wallet. Rows are
+// previewed first and only registered as transactions once committed.
+type StatementImport struct {
+	Id        string
+	WalletId  string
+	Format    StatementFormat
+	Status    StatementImportStatus
+	CreatedBy string
+	Rows      []StatementRow
+
+	CreatedAt   time.Time
+	CommittedAt *time.Time
+}
+
+// StatementRow is a line of the statement. Rows that could not be parsed
+// keep the reason in Error and cannot be committed.
+type StatementRow struct {
+	// Position of the row starting at 1, the line of CSV files and the
+	// entry order of OFX files
+	Line int
+	// Date of the entry at midnight UTC
+	Date        time.Time
+	Description string
+	// Always positive, the direction is given by Type
+	Amount Monetary
+	Type   TransactionType
+	Error  string
+	// Id given by the bank, such as the OFX FITID. Empty for CSV files
+	ExternalId string
+
+	// Id of the transaction or of the previously imported row this row
+	// seems to repeat
+	DuplicateOf *string
+	// Set when the ExternalId was already imported to the wallet, such
+	// rows are never registered again
+	AlreadyImported bool
+	// Set once the row is committed
+	TransactionId *string
+}
+
+func (r StatementRow) IsValid() bool {
+	return r.Error == ""
+}
+
+// IsDuplicate reports whether the row matches an existing entry.
+func (r StatementRow) IsDuplicate() bool {
+	return r.DuplicateOf != nil
+}
+
+// NewStatementImport creates the preview of a statement. Duplicates must be
+// flagged with DetectDuplicates before it is shown.
+func NewStatementImport(walletId string, format StatementFormat, createdBy string, rows []StatementRow) *StatementImport {
+	return &StatementImport{
+		Id:        uuid.NewString(),
+		WalletId:  walletId,
+		Format:    format,
+		Status:    StatementImportStatusPreview,
+		CreatedBy: createdBy,
+		Rows:      rows,
+		CreatedAt: time.Now(),
+	}
+}
+
+// DateRange returns the first and last dates of the valid rows, ok is false
+// when there are none.
+func (i *StatementImport) DateRange() (from, to time.Time, ok bool) {
+	for _, row := range i.Rows {
+		if !row.IsValid() {
+			continue
+		}
+		if !ok || row.Date.Before(from) {
+			from = row.Date
+		}
+		if !ok || row.Date.After(to) {
+			to = row.Date
+		}
+		ok = true
+	}
+
+	return from, to, ok
+}
+
+// ExternalIds returns the bank ids of the rows, without repetitions.
+func (i *StatementImport) ExternalIds() []string {
+	ids := []string{}
+	for _, row := range i.Rows {
+		if row.ExternalId != "" && !slices.Contains(ids, row.ExternalId) {
+			ids = append(ids, row.ExternalId)
+		}
+	}
+
+	return ids
+}
+
+// DetectDuplicates flags the rows already imported to the wallet. Rows whose
+// ExternalId was imported before, given as importedExternalIds by external
+// id to transaction id, are exact matches. The others are compared with the
+// active transactions registered on the same date (in UTC) and with the rows
+// committed by previous imports, by amount, type and description. Each
+// existing entry is matched at most once, so repeated purchases of the same
+// value on the same day are kept.
+func (i *StatementImport) DetectDuplicates(transactions []Transaction, previousRows []StatementRow, importedExternalIds map[string]string) {
+	type candidate struct {
+		id  string
+		row StatementRow
+	}
+
+	candidates := []candidate{}
+	for _, transaction := range transactions {
+		if !transaction.IsActive() {
+			continue
+		}
+		candidates = append(candidates, candidate{
+			id: transaction.Id,
+			row: StatementRow{
+				Date:        statementDate(transaction.CreatedAt),
+				Description: transaction.Description,
+				Amount:      transaction.Amount,
+				Type:        transaction.Type,
+			},
+		})
+	}
+	for _, row := range previousRows {
+		if row.TransactionId == nil {
+			continue
+		}
+		candidates = append(candidates, candidate{id: *row.TransactionId, row: row})
+	}
+
+	// Exact matches go first so they are not taken by a similar row
+	for index := range i.Rows {
+		row := &i.Rows[index]
+		row.DuplicateOf = nil
+		row.AlreadyImported = false
+
+		transactionId, ok := importedExternalIds[row.ExternalId]
+		if !row.IsValid() || row.ExternalId == "" || !ok {
+			continue
+		}
+
+		row.DuplicateOf = &transactionId
+		row.AlreadyImported = true
+		candidates = slices.DeleteFunc(candidates, func(c candidate) bool { return c.id == transactionId })
+	}
+
+	for index := range i.Rows {
+		row := &i.Rows[index]
+		if !row.IsValid() || row.AlreadyImported {
+			continue
+		}
+
+		match := slices.IndexFunc(candidates, func(c candidate) bool {
+			// Different bank ids are different entries, however similar
+			if row.ExternalId != "" && c.row.ExternalId != "" {
+				return false
+			}
+
+			return c.row.Date.Equal(row.Date) &&
+				c.row.Type == row.Type &&
+				c.row.Amount.Cmp(row.Amount) == 0 &&
+				normalizeDescription(c.row.Description) == normalizeDescription(row.Description)
+		})
+		if match < 0 {
+			continue
+		}
+
+		id := candidates[match].id
+		row.DuplicateOf = &id
+		candidates = slices.Delete(candidates, match, match+1)
+	}
+}
+
+// Commit registers the accepted rows as transactions of the wallet. Without
+// accepted lines every valid row that is not a duplicate is registered.
+// Lines of duplicated rows can be accepted explicitly, unless they were
+// already imported.
+func (i *StatementImport) Commit(wallet *Wallet, member Member, acceptedLines []int) ([]Transaction, error) {
+	if i.Status != StatementImportStatusPreview {
+		return nil, errx.ErrStatementImportCommitted
+	}
+
+	if wallet.Id != i.WalletId {
+		return nil, errx.ErrStatementImportNotFound
+	}
+
+	if err := wallet.CheckPermission(member.Id, PermissionWrite); err != nil {
+		return nil, err
+	}
+
+	for _, line := range acceptedLines {
+		index := slices.IndexFunc(i.Rows, func(row StatementRow) bool { return row.Line == line })
+		if index < 0 || !i.Rows[index].IsValid() || i.Rows[index].AlreadyImported {
+			return nil, errx.ErrInvalidStatementLine
+		}
+	}
+
+	transactions := []Transaction{}
+	for index := range i.Rows {
+		row := &i.Rows[index]
+
+		accepted := row.IsValid() && !row.IsDuplicate()
+		if acceptedLines != nil {
+			accepted = slices.Contains(acceptedLines, row.Line)
+		}
+		if !accepted {
+			continue
+		}
+
+		transaction, err := wallet.registerTransaction(row.Amount, member, row.Type, row.Description, nil, &transactionOrigin{occurredAt: row.Date}, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		row.TransactionId = &transaction.Id
+		transactions = append(transactions, *transaction)
+	}
+
+	currentTime := time.Now()
+	i.Status = StatementImportStatusCommitted
+	i.CommittedAt = &currentTime
+
+	wallet.AddEvent(events.StatementImportedEvent{
+		ImportId:         i.Id,
+		WalletId:         wallet.Id,
+		MemberId:         member.Id,
+		Format:           string(i.Format),
+		TransactionCount: len(transactions),
+		Timestamp:        currentTime,
+	})
+
+	return transactions, nil
+}
+
+// statementDate truncates a timestamp to its date in UTC.
+func statementDate(t time.Time) time.Time {
+	year, month, day := t.UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// normalizeDescription ignores case and repeated spaces, which banks are not
+// consistent about.
+func normalizeDescription(description string) string {
+	return strings.ToLower(strings.Join(strings.Fields(description), " "))
+}
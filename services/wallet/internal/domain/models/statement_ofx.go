@@ -0,0 +1,176 @@
+package models
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
+)
+
+// ParseOFXStatement reads the STMTTRN entries of an OFX or QFX statement,
+// either SGML (v1) or XML (v2). Both are read by the same tolerant scanner,
+// as v1 elements are not closed but the aggregates are. The FITID of each
+// entry is kept as the row ExternalId and the Line is the entry position.
+func ParseOFXStatement(r io.Reader, currency string) ([]StatementRow, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errx.ErrInvalidStatement, err)
+	}
+
+	// v1 files are usually Latin-1 (CHARSET:1252), v2 files are UTF-8
+	content := string(data)
+	if !utf8.ValidString(content) {
+		content = decodeLatin1(data)
+	}
+
+	start := strings.Index(strings.ToUpper(content), "<OFX>")
+	if start < 0 {
+		return nil, fmt.Errorf("%w: the <OFX> element was not found", errx.ErrInvalidStatement)
+	}
+
+	entries, statementCurrency, err := scanOFX(content[start:])
+	if err != nil {
+		return nil, err
+	}
+
+	if statementCurrency != "" {
+		code, err := ParseCurrency(statementCurrency)
+		if err != nil || code != currency {
+			return nil, errx.ErrCurrencyMismatch
+		}
+	}
+
+	if len(entries) > MaxStatementRows {
+		return nil, fmt.Errorf("%w: more than %d rows", errx.ErrInvalidStatement, MaxStatementRows)
+	}
+
+	rows := make([]StatementRow, len(entries))
+	for i, entry := range entries {
+		rows[i] = newOFXRow(i+1, entry, currency)
+	}
+
+	return rows, nil
+}
+
+// scanOFX collects the elements of every STMTTRN aggregate and the currency
+// of the statement.
+func scanOFX(body string) ([]map[string]string, string, error) {
+	entries := []map[string]string{}
+	var current map[string]string
+	var currency string
+
+	position := 0
+	for {
+		start := strings.IndexByte(body[position:], '<')
+		if start < 0 {
+			break
+		}
+		start += position
+
+		end := strings.IndexByte(body[start:], '>')
+		if end < 0 {
+			return nil, "", fmt.Errorf("%w: unterminated tag", errx.ErrInvalidStatement)
+		}
+		end += start
+
+		tag := strings.ToUpper(strings.TrimSpace(body[start+1 : end]))
+		text := body[end+1:]
+		if next := strings.IndexByte(text, '<'); next >= 0 {
+			text = text[:next]
+		}
+		value := strings.TrimSpace(html.UnescapeString(text))
+		position = end + 1
+
+		switch {
+		case tag == "STMTTRN":
+			if current != nil {
+				entries = append(entries, current)
+			}
+			current = map[string]string{}
+		case tag == "/STMTTRN":
+			if current != nil {
+				entries = append(entries, current)
+			}
+			current = nil
+		case strings.HasPrefix(tag, "/"), strings.HasPrefix(tag, "?"), strings.HasPrefix(tag, "!"):
+		case tag == "CURDEF":
+			currency = value
+		case current != nil:
+			// Nested aggregates such as PAYEE may repeat names, the first one wins
+			if _, ok := current[tag]; !ok {
+				current[tag] = value
+			}
+		}
+	}
+
+	if current != nil {
+		entries = append(entries, current)
+	}
+
+	return entries, currency, nil
+}
+
+func newOFXRow(line int, entry map[string]string, currency string) StatementRow {
+	row := StatementRow{
+		Line:        line,
+		ExternalId:  entry["FITID"],
+		Description: ofxDescription(entry["NAME"], entry["MEMO"]),
+	}
+
+	// DTPOSTED is YYYYMMDD optionally followed by the time and the timezone
+	posted := entry["DTPOSTED"]
+	if len(posted) < 8 {
+		row.Error = "invalid DTPOSTED"
+		return row
+	}
+	date, err := time.ParseInLocation("20060102", posted[:8], time.UTC)
+	if err != nil {
+		row.Error = "invalid DTPOSTED"
+		return row
+	}
+	row.Date = date
+
+	// Some Brazilian banks write the amounts with a decimal comma
+	amountText := entry["TRNAMT"]
+	if !strings.Contains(amountText, ".") {
+		amountText = strings.Replace(amountText, ",", ".", 1)
+	}
+	amount, err := ParseMonetary(amountText, currency, ".", "")
+	if err != nil || amount.IsZero() {
+		row.Error = "invalid TRNAMT"
+		return row
+	}
+
+	row.Type = TransactionTypeDeposit
+	if amount.Value < 0 {
+		row.Type = TransactionTypeWithdraw
+		amount = amount.Neg()
+	}
+	row.Amount = amount
+
+	return row
+}
+
+func ofxDescription(name, memo string) string {
+	switch {
+	case name == "":
+		return memo
+	case memo == "" || strings.EqualFold(name, memo):
+		return name
+	default:
+		return name + " - " + memo
+	}
+}
+
+func decodeLatin1(data []byte) string {
+	runes := make([]rune, len(data))
+	for i, b := range data {
+		runes[i] = rune(b)
+	}
+
+	return string(runes)
+}
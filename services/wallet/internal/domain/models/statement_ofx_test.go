@@ -0,0 +1,65 @@
+package models_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+)
+
+func TestParseOFXStatement(t *testing.T) {
+	t.Run("should read SGML statements with unclosed elements", func(t *testing.T) {
+		statement := "OFXHEADER:100\nDATA:OFXSGML\nVERSION:102\nCHARSET:1252\n\n" +
+			"<OFX><BANKMSGSRSV1><STMTTRNRS><STMTRS><CURDEF>BRL<BANKTRANLIST>\n" +
+			"<STMTTRN><TRNTYPE>DEBIT<DTPOSTED>20260305120000[-3:BRT]<TRNAMT>-1234,56<FITID>A1<MEMO>Mercado Central\n</STMTTRN>\n" +
+			"<STMTTRN><TRNTYPE>CREDIT<DTPOSTED>20260306<TRNAMT>8500.00<FITID>A2<NAME>ACME<MEMO>Sal\xe1rio\n</STMTTRN>\n" +
+			"</BANKTRANLIST></STMTRS></STMTTRNRS></BANKMSGSRSV1></OFX>"
+
+		rows, err := models.ParseOFXStatement(strings.NewReader(statement), "BRL")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if len(rows) != 2 {
+			t.Fatalf("Expected 2 rows, got %v", len(rows))
+		}
+		if rows[0].Type != models.TransactionTypeWithdraw || rows[0].Amount.Value != 123456 || rows[0].ExternalId != "A1" {
+			t.Errorf("Expected a withdraw of 1234.56 with FITID A1, got %+v", rows[0])
+		}
+		if !rows[0].Date.Equal(time.Date(2026, time.March, 5, 0, 0, 0, 0, time.UTC)) {
+			t.Errorf("Expected the 5th of March, got %v", rows[0].Date)
+		}
+		if rows[1].Type != models.TransactionTypeDeposit || rows[1].Description != "ACME - Salário" {
+			t.Errorf("Expected a deposit described as \"ACME - Salário\", got %+v", rows[1])
+		}
+	})
+
+	t.Run("should read XML statements", func(t *testing.T) {
+		statement := `<?xml version="1.0" encoding="UTF-8"?>
+<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE"?>
+<OFX><CREDITCARDMSGSRSV1><CCSTMTTRNRS><CCSTMTRS><CURDEF>USD</CURDEF><BANKTRANLIST>
+<STMTTRN><TRNTYPE>DEBIT</TRNTYPE><DTPOSTED>20260305</DTPOSTED><TRNAMT>-39.90</TRNAMT><FITID>X9</FITID><NAME>Books &amp; Co</NAME></STMTTRN>
+</BANKTRANLIST></CCSTMTRS></CCSTMTTRNRS></CREDITCARDMSGSRSV1></OFX>`
+
+		rows, err := models.ParseOFXStatement(strings.NewReader(statement), "USD")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if len(rows) != 1 || rows[0].Description != "Books & Co" || rows[0].Amount.Value != 3990 {
+			t.Errorf("Expected a single entry of 39.90 at Books & Co, got %+v", rows)
+		}
+	})
+
+	t.Run("should reject statements in another currency", func(t *testing.T) {
+		statement := "<OFX><STMTRS><CURDEF>USD<BANKTRANLIST></BANKTRANLIST></STMTRS></OFX>"
+
+		_, err := models.ParseOFXStatement(strings.NewReader(statement), "BRL")
+		if !errors.Is(err, errx.ErrCurrencyMismatch) {
+			t.Errorf("Expected currency mismatch error, got %v", err)
+		}
+	})
+}
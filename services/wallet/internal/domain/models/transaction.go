@@ -9,6 +9,20 @@ type TransactionType string
 const (
 	TransactionTypeDeposit  TransactionType = "deposit"
 	TransactionTypeWithdraw TransactionType = "withdraw"
+	// TransactionTypeSettlement records a member paying back another one.
+	// The money changes hands outside of the wallet, so the balance is kept
+	TransactionTypeSettlement TransactionType = "settlement"
+)
+
+type TransactionStatus string
+
+const (
+	// TransactionStatusActive transactions are the ones counted in the balance.
+	TransactionStatusActive TransactionStatus = "active"
+	// TransactionStatusVoided transactions were cancelled and only remain in the history.
+	TransactionStatusVoided TransactionStatus = "voided"
+	// TransactionStatusReplaced transactions were edited, ReplacedById points to the new version.
+	TransactionStatusReplaced TransactionStatus = "replaced"
 )
 
 type Transaction struct {
@@ -18,7 +32,65 @@ type Transaction struct {
 	CreatedBy   Member
 	Type        TransactionType
 	Description string
+	CategoryId  *string
+
+	Status       TransactionStatus
+	ReplacesId   *string
+	ReplacedById *string
+
+	// Set when the transaction materialises an occurrence of a recurring transaction
+	RecurringTransactionId *string
+	OccurrenceDate         *time.Time
+
+	// Set when the transaction is a leg of a transfer between wallets
+	TransferId *string
+
+	// Set when the expense is shared by members of the wallet, the creator
+	// being the one who paid it
+	Split *TransactionSplit
+	// Member paid back by a settlement, the creator being the one paying
+	SettledWithId *string
 
 	CreatedAt time.Time
 	UpdatedAt *time.Time
 }
+
+// TransactionChanges holds the fields to be changed when a transaction is
+// edited. Nil fields keep the current value.
+type TransactionChanges struct {
+	Amount        *Monetary
+	Type          *TransactionType
+	Description   *string
+	CategoryId    *string
+	ClearCategory bool
+	// Shares of the new split, without it the current split is kept and
+	// recomputed for the new amount
+	Split      *TransactionSplit
+	ClearSplit bool
+}
+
+func (t Transaction) IsActive() bool {
+	return t.Status == "" || t.Status == TransactionStatusActive
+}
+
+func (t Transaction) IsSettlement() bool {
+	return t.Type == TransactionTypeSettlement
+}
+
+// BalanceEffect is how much the transaction changes the wallet balance.
+func (t Transaction) BalanceEffect() Monetary {
+	switch t.Type {
+	case TransactionTypeDeposit:
+		return t.Amount
+	case TransactionTypeWithdraw:
+		return t.Amount.Neg()
+	default:
+		return Monetary{Offset: t.Amount.Offset, Currency: t.Amount.Currency}
+	}
+}
+
+// IsTransfer reports whether the transaction moves money between wallets,
+// which is neither an income nor an expense.
+func (t Transaction) IsTransfer() bool {
+	return t.TransferId != nil
+}
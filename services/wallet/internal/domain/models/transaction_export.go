@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// TransactionExportHeader describes an export of the wallet history between
+// From (inclusive) and To (exclusive), nil meaning unbounded.
+type TransactionExportHeader struct {
+	WalletId string
+	Currency string
+	From     *time.Time
+	To       *time.Time
+	// Balance right before the first exported transaction
+	OpeningBalance Monetary
+}
+
+// TransactionExportEntry is an active transaction with the balance of the
+// wallet right after it.
+type TransactionExportEntry struct {
+	Transaction Transaction
+	Balance     Monetary
+}
@@ -0,0 +1,107 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/events"
+)
+
+// Transfer moves money between two wallets. It is recorded as a withdraw in
+// the source wallet and a deposit in the destination one, both legs sharing
+// the transfer id.
+type Transfer struct {
+	Id           string
+	FromWalletId string
+	ToWalletId   string
+	Amount       Monetary
+	Description  string
+	CreatedBy    Member
+
+	Withdraw Transaction
+	Deposit  Transaction
+
+	CreatedAt time.Time
+}
+
+// RegisterTransfer registers both legs of a transfer. The member must be
+// allowed to write to both wallets and both must use the same currency.
+// Nothing is changed when any of the checks fails, but the wallets must be
+// saved together so the legs are never persisted apart.
+func RegisterTransfer(from, to *Wallet, amount Monetary, member Member, description string) (*Transfer, error) {
+	if from.Id == to.Id {
+		return nil, errx.ErrInvalidTransfer
+	}
+
+	if amount.Value <= 0 {
+		return nil, errors.New("invalid amount: must be greater than 0")
+	}
+
+	if err := from.CheckPermission(member.Id, PermissionWrite); err != nil {
+		return nil, err
+	}
+
+	if err := to.CheckPermission(member.Id, PermissionWrite); err != nil {
+		return nil, err
+	}
+
+	amount, err := from.inWalletCurrency(amount)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := to.inWalletCurrency(amount); err != nil {
+		return nil, err
+	}
+
+	withdrawDescription := description
+	depositDescription := description
+	if description == "" {
+		withdrawDescription = "Transfer to " + to.Name
+		depositDescription = "Transfer from " + from.Name
+	}
+
+	origin := &transactionOrigin{transferId: uuid.NewString()}
+
+	withdraw, err := from.registerTransaction(amount, member, TransactionTypeWithdraw, withdrawDescription, nil, origin, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	deposit, err := to.registerTransaction(amount, member, TransactionTypeDeposit, depositDescription, nil, origin, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	currentTime := time.Now()
+	transfer := &Transfer{
+		Id:           origin.transferId,
+		FromWalletId: from.Id,
+		ToWalletId:   to.Id,
+		Amount:       amount,
+		Description:  description,
+		CreatedBy:    member,
+		Withdraw:     *withdraw,
+		Deposit:      *deposit,
+		CreatedAt:    currentTime,
+	}
+
+	from.UpdatedAt = &currentTime
+	to.UpdatedAt = &currentTime
+
+	from.AddEvent(events.TransferRegisteredEvent{
+		TransferId:            transfer.Id,
+		FromWalletId:          from.Id,
+		ToWalletId:            to.Id,
+		WithdrawTransactionId: withdraw.Id,
+		DepositTransactionId:  deposit.Id,
+		MemberId:              member.Id,
+		Description:           description,
+		Amount:                amount.toEventAmount(),
+		Timestamp:             currentTime,
+	})
+
+	return transfer, nil
+}
@@ -2,9 +2,12 @@ package models
 
 import (
 	"errors"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
 	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/events"
 )
 
@@ -14,28 +17,219 @@ type Wallet struct {
 	Name      string
 	Balance   Monetary
 
-	Members      []Member
-	Transactions []Transaction
+	Members               []WalletMember
+	Transactions          []Transaction
+	Categories            []Category
+	RecurringTransactions []RecurringTransaction
+	Budgets               []Budget
 
 	CreatedAt time.Time
 	UpdatedAt *time.Time
 
-	events []events.DomainEvent
+	// Version of the stored wallet this one was loaded from, zero until it is
+	// saved for the first time. Saves fail with errx.ErrWalletConflict when
+	// the stored wallet has moved past it
+	Version int
+
+	events  []events.DomainEvent
+	changes WalletChanges
 }
 
-func (w *Wallet) AddUser(member *Member) {
-	w.Members = append(w.Members, *member)
+// AddUser grants the member access to the wallet with the given role.
+func (w *Wallet) AddUser(member *Member, role WalletRole) error {
+	if w.IsMember(member.Id) {
+		return errx.ErrMemberAlreadyInWallet
+	}
+
+	if _, err := ParseWalletRole(string(role)); err != nil {
+		return err
+	}
+
 	currentTime := time.Now()
+	w.Members = append(w.Members, WalletMember{
+		Member:     *member,
+		Role:       role,
+		AssignedAt: currentTime,
+	})
+	markChanged(&w.changes.Members, member.Id)
 
 	w.AddEvent(events.WalletSharedEvent{
 		WalletId:  w.Id,
 		MemberId:  member.Id,
+		Role:      string(role),
 		Timestamp: currentTime,
 	})
 	w.UpdatedAt = &currentTime
+	return nil
+}
+
+// RemoveMember revokes the access of a member to the wallet. removedBy is
+// the member performing the operation, which is the member itself when
+// leaving the wallet. Members with shared expenses left to settle cannot be
+// removed, former members could not settle them anymore. The recurring
+// transactions the member created are cancelled.
+func (w *Wallet) RemoveMember(memberId string, removedBy string) error {
+	if memberId == w.CreatorId {
+		return errx.ErrCannotRemoveCreator
+	}
+
+	for i, member := range w.Members {
+		if member.Id != memberId {
+			continue
+		}
+
+		if w.hasOpenBalance(memberId) {
+			return errx.ErrMemberHasOpenBalance
+		}
+
+		w.Members = append(w.Members[:i], w.Members[i+1:]...)
+		markRemoved(&w.changes.Members, &w.changes.RemovedMembers, memberId)
+		currentTime := time.Now()
+		w.cancelRecurringTransactionsOf(memberId, removedBy, currentTime)
+
+		w.AddEvent(events.WalletUnsharedEvent{
+			WalletId:  w.Id,
+			MemberId:  memberId,
+			RemovedBy: removedBy,
+			Timestamp: currentTime,
+		})
+		w.UpdatedAt = &currentTime
+		return nil
+	}
+
+	return errx.ErrMemberNotInWallet
+}
+
+// ChangeMemberRole grants another role to a member. Demoting it to a role
+// without write permission cancels the recurring transactions it created.
+func (w *Wallet) ChangeMemberRole(memberId string, role WalletRole, changedBy string) error {
+	if memberId == w.CreatorId {
+		return errx.ErrCannotChangeCreatorRole
+	}
+
+	if _, err := ParseWalletRole(string(role)); err != nil {
+		return err
+	}
+
+	for i := range w.Members {
+		if w.Members[i].Id != memberId {
+			continue
+		}
+
+		currentTime := time.Now()
+		previousRole := w.Members[i].Role
+		w.Members[i].Role = role
+		markChanged(&w.changes.Members, memberId)
+		if !role.Allows(PermissionWrite) {
+			w.cancelRecurringTransactionsOf(memberId, changedBy, currentTime)
+		}
+
+		w.AddEvent(events.WalletMemberRoleChangedEvent{
+			WalletId:     w.Id,
+			MemberId:     memberId,
+			PreviousRole: string(previousRole),
+			Role:         string(role),
+			ChangedBy:    changedBy,
+			Timestamp:    currentTime,
+		})
+		w.UpdatedAt = &currentTime
+		return nil
+	}
+
+	return errx.ErrMemberNotInWallet
+}
+
+func (w *Wallet) Rename(name string, renamedBy string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return errx.MissingRequiredFieldsError("Name")
+	}
+
+	currentTime := time.Now()
+	w.Name = name
+
+	w.AddEvent(events.WalletRenamedEvent{
+		WalletId:  w.Id,
+		Name:      name,
+		RenamedBy: renamedBy,
+		Timestamp: currentTime,
+	})
+	w.UpdatedAt = &currentTime
+	return nil
+}
+
+func (w *Wallet) IsMember(memberId string) bool {
+	_, ok := w.MemberRole(memberId)
+	return ok
+}
+
+// MemberRole returns the role the member holds in the wallet. The creator
+// is always an owner.
+func (w *Wallet) MemberRole(memberId string) (WalletRole, bool) {
+	if memberId != "" && w.CreatorId == memberId {
+		return WalletRoleOwner, true
+	}
+
+	for _, member := range w.Members {
+		if member.Id == memberId {
+			return member.Role, true
+		}
+	}
+
+	return "", false
+}
+
+func (w *Wallet) HasPermission(memberId string, permission WalletPermission) bool {
+	role, ok := w.MemberRole(memberId)
+	if !ok {
+		return false
+	}
+
+	return role.Allows(permission)
 }
 
-func (w *Wallet) RegisterNewTransaction(amount Monetary, creator Member, transactionType TransactionType, description string) (*Transaction, error) {
+// CheckPermission is the single authorization check used by the use cases,
+// it fails with errx.ErrInsufficientPermissions when the member's role does
+// not grant the permission.
+func (w *Wallet) CheckPermission(memberId string, permission WalletPermission) error {
+	if !w.HasPermission(memberId, permission) {
+		return errx.ErrInsufficientPermissions
+	}
+
+	return nil
+}
+
+func (w *Wallet) RegisterNewTransaction(amount Monetary, creator Member, transactionType TransactionType, description string, categoryId *string) (*Transaction, error) {
+	return w.registerTransaction(amount, creator, transactionType, description, categoryId, nil, nil)
+}
+
+// RegisterSplitTransaction registers an expense paid by the creator and
+// shared by members of the wallet. Equal splits without shares are shared by
+// every member.
+func (w *Wallet) RegisterSplitTransaction(amount Monetary, creator Member, description string, categoryId *string, split TransactionSplit) (*Transaction, error) {
+	return w.registerTransaction(amount, creator, TransactionTypeWithdraw, description, categoryId, nil, &transactionParties{split: &split})
+}
+
+// transactionOrigin links a registered transaction to what originated it,
+// either the occurrence of a recurring transaction, a transfer or a line of
+// an imported statement. Occurrences and statement lines are dated when they
+// occurred rather than when they are registered.
+type transactionOrigin struct {
+	recurringTransactionId string
+	occurrenceDate         time.Time
+	transferId             string
+	occurredAt             time.Time
+}
+
+// transactionParties are the members a transaction concerns besides its
+// creator, either the ones sharing an expense or the one paid back by a
+// settlement.
+type transactionParties struct {
+	split         *TransactionSplit
+	settledWithId string
+}
+
+func (w *Wallet) registerTransaction(amount Monetary, creator Member, transactionType TransactionType, description string, categoryId *string, origin *transactionOrigin, parties *transactionParties) (*Transaction, error) {
 	id := uuid.NewString()
 	currentTime := time.Now()
 
@@ -43,8 +237,39 @@ func (w *Wallet) RegisterNewTransaction(amount Monetary, creator Member, transac
 		return nil, errors.New("invalid amount: must be greater than 0")
 	}
 
-	if !w.IsMemberAllowedToRegisterTransactions(creator.Id) {
-		return nil, errors.New("user is not allowed to register transactions")
+	if err := w.CheckPermission(creator.Id, PermissionWrite); err != nil {
+		return nil, err
+	}
+
+	amount, err := w.inWalletCurrency(amount)
+	if err != nil {
+		return nil, err
+	}
+
+	var category *Category
+	if categoryId != nil {
+		found, err := w.FindCategory(*categoryId)
+		if err != nil {
+			return nil, err
+		}
+		category = found
+	}
+
+	var split *TransactionSplit
+	if parties != nil && parties.split != nil {
+		split, err = w.allocateSplit(transactionType, amount, *parties.split)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var settledWithId *string
+	if transactionType == TransactionTypeSettlement {
+		if parties == nil || !w.IsMember(parties.settledWithId) || parties.settledWithId == creator.Id || categoryId != nil {
+			return nil, errx.ErrInvalidSettlement
+		}
+		recipientId := parties.settledWithId
+		settledWithId = &recipientId
 	}
 
 	transaction := &Transaction{
@@ -53,33 +278,342 @@ func (w *Wallet) RegisterNewTransaction(amount Monetary, creator Member, transac
 		CreatedBy:   creator,
 		Type:        transactionType,
 		Description: description,
+		CategoryId:  categoryId,
+		Status:      TransactionStatusActive,
 		CreatedAt:   currentTime,
+
+		Split:         split,
+		SettledWithId: settledWithId,
+	}
+	if origin != nil && !origin.occurredAt.IsZero() {
+		transaction.CreatedAt = origin.occurredAt
+	}
+	if origin != nil && origin.recurringTransactionId != "" {
+		recurringTransactionId := origin.recurringTransactionId
+		occurrenceDate := origin.occurrenceDate
+		transaction.RecurringTransactionId = &recurringTransactionId
+		transaction.OccurrenceDate = &occurrenceDate
+	}
+	if origin != nil && origin.transferId != "" {
+		transferId := origin.transferId
+		transaction.TransferId = &transferId
 	}
 
 	w.Transactions = append(w.Transactions, *transaction)
+	markChanged(&w.changes.Transactions, transaction.Id)
+	w.applyToBalance(*transaction)
+
+	event := events.TransactionRegisteredEvent{
+		TransactionId: transaction.Id,
+		WalletId:      w.Id,
+		MemberId:      transaction.CreatedBy.Id,
+		Amount:        transaction.Amount.toEventAmount(),
+		Type:          string(transaction.Type),
+		Timestamp:     currentTime,
+		Description:   description,
+	}
+	if category != nil {
+		event.CategoryId = &category.Id
+		event.CategoryName = category.Name
+	}
+	if transaction.RecurringTransactionId != nil {
+		event.RecurringTransactionId = transaction.RecurringTransactionId
+	}
+	if transaction.TransferId != nil {
+		event.TransferId = transaction.TransferId
+	}
+	if transaction.Split != nil {
+		event.SplitMethod = string(transaction.Split.Method)
+		event.SplitMemberIds = transaction.Split.MemberIds()
+	}
+	event.SettledWithId = transaction.SettledWithId
+	w.AddEvent(event)
+	w.consumeBudgets(*transaction, false)
+
+	return transaction, nil
+}
+
+func (w *Wallet) FindTransaction(transactionId string) (*Transaction, error) {
+	for i := range w.Transactions {
+		if w.Transactions[i].Id == transactionId {
+			return &w.Transactions[i], nil
+		}
+	}
+
+	return nil, errx.ErrTransactionNotFound
+}
+
+// UpdateTransaction edits a transaction without rewriting history: the
+// original entry is kept and marked as replaced, a new entry carrying the
+// changes is appended and the balance is corrected by compensating the
+// original amount before applying the new one.
+func (w *Wallet) UpdateTransaction(transactionId string, editor Member, changes TransactionChanges) (*Transaction, error) {
+	if err := w.CheckPermission(editor.Id, PermissionWrite); err != nil {
+		return nil, err
+	}
+
+	original, err := w.FindTransaction(transactionId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !original.IsActive() {
+		return nil, errx.ErrTransactionNotActive
+	}
+
+	if original.IsTransfer() {
+		return nil, errx.ErrTransactionIsTransfer
+	}
+
+	if original.IsSettlement() {
+		return nil, errx.ErrTransactionIsSettlement
+	}
 
-	if transaction.Type == TransactionTypeDeposit {
-		w.Balance = w.Balance.Sum(transaction.Amount)
-	} else {
-		w.Balance = w.Balance.Sub(transaction.Amount)
+	originalId := original.Id
+	replacement := *original
+	replacement.Id = uuid.NewString()
+	replacement.Status = TransactionStatusActive
+	replacement.ReplacesId = &originalId
+	replacement.ReplacedById = nil
+
+	if changes.Amount != nil {
+		if changes.Amount.Value <= 0 {
+			return nil, errors.New("invalid amount: must be greater than 0")
+		}
+
+		amount, err := w.inWalletCurrency(*changes.Amount)
+		if err != nil {
+			return nil, err
+		}
+		replacement.Amount = amount
+	}
+
+	if changes.Type != nil {
+		if *changes.Type != TransactionTypeDeposit && *changes.Type != TransactionTypeWithdraw {
+			return nil, errx.ErrInvalidTransactionType
+		}
+		replacement.Type = *changes.Type
+	}
+
+	if changes.Description != nil {
+		replacement.Description = *changes.Description
+	}
+
+	switch {
+	case changes.ClearSplit:
+		replacement.Split = nil
+	case changes.Split != nil:
+		split, err := w.allocateSplit(replacement.Type, replacement.Amount, *changes.Split)
+		if err != nil {
+			return nil, err
+		}
+		replacement.Split = split
+	case replacement.Split != nil:
+		// The members sharing it were checked when it was split
+		if replacement.Type != TransactionTypeWithdraw {
+			return nil, errx.ErrInvalidSplit
+		}
+		split, err := replacement.Split.Allocate(replacement.Amount)
+		if err != nil {
+			return nil, err
+		}
+		replacement.Split = split
+	}
+
+	if changes.ClearCategory {
+		replacement.CategoryId = nil
+	} else if changes.CategoryId != nil {
+		if _, err := w.FindCategory(*changes.CategoryId); err != nil {
+			return nil, err
+		}
+		replacement.CategoryId = changes.CategoryId
+	}
+
+	currentTime := time.Now()
+	replacement.UpdatedAt = &currentTime
+
+	w.compensateBalance(*original)
+	w.consumeBudgets(*original, true)
+	replacementId := replacement.Id
+	original.Status = TransactionStatusReplaced
+	original.ReplacedById = &replacementId
+	original.UpdatedAt = &currentTime
+	markChanged(&w.changes.Transactions, original.Id)
+
+	w.Transactions = append(w.Transactions, replacement)
+	markChanged(&w.changes.Transactions, replacement.Id)
+	w.applyToBalance(replacement)
+	w.UpdatedAt = &currentTime
+
+	w.AddEvent(events.TransactionUpdatedEvent{
+		TransactionId:         replacement.Id,
+		PreviousTransactionId: transactionId,
+		WalletId:              w.Id,
+		MemberId:              editor.Id,
+		Description:           replacement.Description,
+		Amount:                replacement.Amount.toEventAmount(),
+		Type:                  string(replacement.Type),
+		CategoryId:            replacement.CategoryId,
+		Timestamp:             currentTime,
+	})
+	w.consumeBudgets(replacement, false)
+
+	return &replacement, nil
+}
+
+// VoidTransaction cancels a transaction. The entry stays in the history
+// marked as voided and its amount is compensated in the balance.
+func (w *Wallet) VoidTransaction(transactionId string, member Member) (*Transaction, error) {
+	if err := w.CheckPermission(member.Id, PermissionWrite); err != nil {
+		return nil, err
 	}
 
-	w.AddEvent(events.TransactionRegisteredEvent{
+	transaction, err := w.FindTransaction(transactionId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !transaction.IsActive() {
+		return nil, errx.ErrTransactionNotActive
+	}
+
+	if transaction.IsTransfer() {
+		return nil, errx.ErrTransactionIsTransfer
+	}
+
+	currentTime := time.Now()
+	w.compensateBalance(*transaction)
+	w.consumeBudgets(*transaction, true)
+	transaction.Status = TransactionStatusVoided
+	transaction.UpdatedAt = &currentTime
+	markChanged(&w.changes.Transactions, transaction.Id)
+	w.UpdatedAt = &currentTime
+
+	w.AddEvent(events.TransactionVoidedEvent{
 		TransactionId: transaction.Id,
 		WalletId:      w.Id,
-		MemberId:      transaction.CreatedBy.Id,
-		Amount: map[string]int{
-			"value":  transaction.Amount.Value,
-			"offset": transaction.Amount.Offset,
-		},
-		Type:        string(transaction.Type),
-		Timestamp:   currentTime,
-		Description: description,
+		MemberId:      member.Id,
+		Amount:        transaction.Amount.toEventAmount(),
+		Type:          string(transaction.Type),
+		Timestamp:     currentTime,
 	})
 
 	return transaction, nil
 }
 
+func (w *Wallet) applyToBalance(transaction Transaction) {
+	w.Balance = w.Balance.Sum(transaction.BalanceEffect())
+	w.changes.BalanceMovements = append(w.changes.BalanceMovements, BalanceMovement{TransactionId: transaction.Id})
+}
+
+// compensateBalance reverts the effect a transaction had on the balance.
+func (w *Wallet) compensateBalance(transaction Transaction) {
+	w.Balance = w.Balance.Sub(transaction.BalanceEffect())
+	w.changes.BalanceMovements = append(w.changes.BalanceMovements, BalanceMovement{TransactionId: transaction.Id, Reverted: true})
+}
+
+func (w *Wallet) FindCategory(categoryId string) (*Category, error) {
+	for i := range w.Categories {
+		if w.Categories[i].Id == categoryId {
+			return &w.Categories[i], nil
+		}
+	}
+
+	return nil, errx.ErrCategoryNotFound
+}
+
+func (w *Wallet) AddCategory(name string) (*Category, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errx.MissingRequiredFieldsError("Name")
+	}
+
+	if w.hasCategoryNamed(name, "") {
+		return nil, errx.ErrCategoryAlreadyExists
+	}
+
+	category := Category{
+		Id:        uuid.NewString(),
+		WalletId:  w.Id,
+		Name:      name,
+		CreatedAt: time.Now(),
+	}
+	w.Categories = append(w.Categories, category)
+	markChanged(&w.changes.Categories, category.Id)
+
+	return &category, nil
+}
+
+func (w *Wallet) RenameCategory(categoryId, name string) (*Category, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errx.MissingRequiredFieldsError("Name")
+	}
+
+	category, err := w.FindCategory(categoryId)
+	if err != nil {
+		return nil, err
+	}
+
+	if w.hasCategoryNamed(name, categoryId) {
+		return nil, errx.ErrCategoryAlreadyExists
+	}
+
+	currentTime := time.Now()
+	category.Name = name
+	category.UpdatedAt = &currentTime
+	markChanged(&w.changes.Categories, category.Id)
+
+	return category, nil
+}
+
+// RemoveCategory deletes the category from the catalog and detaches it from
+// every transaction that was using it.
+func (w *Wallet) RemoveCategory(categoryId string) error {
+	for i, category := range w.Categories {
+		if category.Id != categoryId {
+			continue
+		}
+
+		w.Categories = append(w.Categories[:i], w.Categories[i+1:]...)
+		markRemoved(&w.changes.Categories, &w.changes.RemovedCategories, categoryId)
+		for j := range w.Transactions {
+			if w.Transactions[j].CategoryId != nil && *w.Transactions[j].CategoryId == categoryId {
+				w.Transactions[j].CategoryId = nil
+				markChanged(&w.changes.Transactions, w.Transactions[j].Id)
+			}
+		}
+		for j := range w.RecurringTransactions {
+			if w.RecurringTransactions[j].CategoryId != nil && *w.RecurringTransactions[j].CategoryId == categoryId {
+				w.RecurringTransactions[j].CategoryId = nil
+				markChanged(&w.changes.RecurringTransactions, w.RecurringTransactions[j].Id)
+			}
+		}
+		// A category budget makes no sense without the category
+		w.Budgets = slices.DeleteFunc(w.Budgets, func(budget Budget) bool {
+			if budget.CategoryId == nil || *budget.CategoryId != categoryId {
+				return false
+			}
+			markRemoved(&w.changes.Budgets, &w.changes.RemovedBudgets, budget.Id)
+			return true
+		})
+
+		return nil
+	}
+
+	return errx.ErrCategoryNotFound
+}
+
+func (w *Wallet) hasCategoryNamed(name, ignoredId string) bool {
+	for _, category := range w.Categories {
+		if category.Id != ignoredId && strings.EqualFold(category.Name, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (w *Wallet) AddEvent(event events.DomainEvent) {
 	w.events = append(w.events, event)
 }
@@ -92,41 +626,68 @@ func (w *Wallet) ClearEvents() {
 	w.events = nil
 }
 
-func CreateNewWallet(name string, creator *Member) *Wallet {
+// inWalletCurrency rejects amounts in a currency other than the wallet one,
+// amounts without a currency are assumed to be in the wallet currency.
+func (w *Wallet) inWalletCurrency(amount Monetary) (Monetary, error) {
+	if !w.Balance.SameCurrency(amount) {
+		return Monetary{}, errx.ErrCurrencyMismatch
+	}
+
+	amount.Currency = w.Balance.Currency
+	return amount, nil
+}
+
+func (m Monetary) toEventAmount() events.Amount {
+	return events.Amount{
+		Value:    m.Value,
+		Offset:   m.Offset,
+		Currency: m.Currency,
+	}
+}
+
+// CreateNewWallet creates a wallet holding amounts in the given ISO 4217
+// currency, the currency is expected to be validated with ParseCurrency.
+func CreateNewWallet(name string, creator *Member, currency string) *Wallet {
+	if currency == "" {
+		currency = DefaultCurrency
+	}
+
 	walletId := uuid.NewString()
 	wallet := &Wallet{
 		Id:        walletId,
 		CreatorId: creator.Id,
 		Name:      name,
 		Balance: Monetary{
-			Value:  0,
-			Offset: 100,
+			Value:    0,
+			Offset:   CurrencyOffset(currency),
+			Currency: currency,
+		},
+		Members: []WalletMember{
+			{Member: *creator, Role: WalletRoleOwner, AssignedAt: time.Now()},
 		},
-		Members:      []Member{*creator},
-		Transactions: []Transaction{},
-		CreatedAt:    time.Now(),
+		Transactions:          []Transaction{},
+		Categories:            []Category{},
+		RecurringTransactions: []RecurringTransaction{},
+		Budgets:               []Budget{},
+		CreatedAt:             time.Now(),
+	}
+
+	for _, name := range DefaultCategoryNames {
+		wallet.Categories = append(wallet.Categories, Category{
+			Id:        uuid.NewString(),
+			WalletId:  walletId,
+			Name:      name,
+			CreatedAt: wallet.CreatedAt,
+		})
 	}
 
 	wallet.AddEvent(events.WalletCreatedEvent{
 		WalletId:  wallet.Id,
 		CreatorId: creator.Id,
 		Name:      name,
+		Currency:  currency,
 		Timestamp: wallet.CreatedAt,
 	})
 
 	return wallet
 }
-
-func (w *Wallet) IsMemberAllowedToRegisterTransactions(memberId string) bool {
-	if w.CreatorId == memberId {
-		return true
-	}
-
-	for _, member := range w.Members {
-		if member.Id == memberId {
-			return true
-		}
-	}
-
-	return false
-}
@@ -0,0 +1,162 @@
+package models
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/events"
+)
+
+// AddBudget sets a monthly spending limit for the wallet or, when categoryId
+// is informed, for one of its categories. Thresholds are the consumption
+// percentages notified with a BudgetThresholdReachedEvent.
+func (w *Wallet) AddBudget(member Member, limit Monetary, categoryId *string, thresholds []int) (*Budget, error) {
+	if err := w.CheckPermission(member.Id, PermissionWrite); err != nil {
+		return nil, err
+	}
+
+	if limit.Value <= 0 {
+		return nil, errx.ErrInvalidAmount
+	}
+
+	limit, err := w.inWalletCurrency(limit)
+	if err != nil {
+		return nil, err
+	}
+
+	if categoryId != nil {
+		if _, err := w.FindCategory(*categoryId); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, budget := range w.Budgets {
+		sameCategory := budget.CategoryId == nil && categoryId == nil ||
+			budget.CategoryId != nil && categoryId != nil && *budget.CategoryId == *categoryId
+		if sameCategory {
+			return nil, errx.ErrBudgetAlreadyExists
+		}
+	}
+
+	if len(thresholds) == 0 {
+		thresholds = DefaultBudgetThresholds
+	}
+	thresholds = slices.Clone(thresholds)
+	slices.Sort(thresholds)
+	thresholds = slices.Compact(thresholds)
+	if thresholds[0] <= 0 {
+		return nil, errx.ErrInvalidBudgetThreshold
+	}
+
+	currentTime := time.Now()
+	budget := Budget{
+		Id:         uuid.NewString(),
+		WalletId:   w.Id,
+		CategoryId: categoryId,
+		Limit:      limit,
+		Thresholds: thresholds,
+		Periods:    []BudgetPeriod{},
+		CreatedAt:  currentTime,
+	}
+
+	w.Budgets = append(w.Budgets, budget)
+	markChanged(&w.changes.Budgets, budget.Id)
+	w.UpdatedAt = &currentTime
+
+	return &budget, nil
+}
+
+func (w *Wallet) FindBudget(budgetId string) (*Budget, error) {
+	for i := range w.Budgets {
+		if w.Budgets[i].Id == budgetId {
+			return &w.Budgets[i], nil
+		}
+	}
+
+	return nil, errx.ErrBudgetNotFound
+}
+
+func (w *Wallet) RemoveBudget(budgetId string, member Member) error {
+	if err := w.CheckPermission(member.Id, PermissionWrite); err != nil {
+		return err
+	}
+
+	if _, err := w.FindBudget(budgetId); err != nil {
+		return err
+	}
+
+	currentTime := time.Now()
+	w.Budgets = slices.DeleteFunc(w.Budgets, func(budget Budget) bool {
+		return budget.Id == budgetId
+	})
+	markRemoved(&w.changes.Budgets, &w.changes.RemovedBudgets, budgetId)
+	w.UpdatedAt = &currentTime
+
+	return nil
+}
+
+// consumeBudgets adds a withdrawal to the consumption of the budgets it
+// applies to, or removes it when revert is set, e.g. when the transaction is
+// voided. Transfers between wallets are not spending and are ignored.
+// Thresholds are notified once per period, a threshold no longer reached
+// after a revert may be notified again.
+func (w *Wallet) consumeBudgets(transaction Transaction, revert bool) {
+	if transaction.Type != TransactionTypeWithdraw || transaction.IsTransfer() {
+		return
+	}
+
+	start := BudgetPeriodStart(transaction.CreatedAt)
+	currentTime := time.Now()
+
+	for i := range w.Budgets {
+		budget := &w.Budgets[i]
+		if !budget.Applies(transaction.CategoryId) {
+			continue
+		}
+
+		period := budget.FindPeriod(start)
+		if period == nil {
+			budget.Periods = append(budget.Periods, BudgetPeriod{
+				Start: start,
+				Spent: Monetary{Offset: budget.Limit.Offset, Currency: budget.Limit.Currency},
+			})
+			period = &budget.Periods[len(budget.Periods)-1]
+		}
+
+		if revert {
+			period.Spent = period.Spent.Sub(transaction.Amount)
+		} else {
+			period.Spent = period.Spent.Sum(transaction.Amount)
+		}
+		budget.UpdatedAt = &currentTime
+		markChanged(&w.changes.Budgets, budget.Id)
+
+		percentage := budget.consumedPercentage(period.Spent)
+		for _, threshold := range budget.Thresholds {
+			notified := slices.Contains(period.NotifiedThresholds, threshold)
+
+			switch {
+			case percentage >= threshold && !notified:
+				period.NotifiedThresholds = append(period.NotifiedThresholds, threshold)
+				w.AddEvent(events.BudgetThresholdReachedEvent{
+					BudgetId:      budget.Id,
+					WalletId:      w.Id,
+					CategoryId:    budget.CategoryId,
+					TransactionId: transaction.Id,
+					Period:        start.Format("2006-01"),
+					Threshold:     threshold,
+					Percentage:    percentage,
+					Limit:         budget.Limit.toEventAmount(),
+					Spent:         period.Spent.toEventAmount(),
+					Timestamp:     currentTime,
+				})
+			case percentage < threshold && notified:
+				period.NotifiedThresholds = slices.DeleteFunc(period.NotifiedThresholds, func(t int) bool {
+					return t == threshold
+				})
+			}
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package models
+
+import "slices"
+
+// WalletChanges lists, by id, the entities of the wallet added, changed or
+// removed since it was loaded or last saved, so repositories only write
+// those. The wallet itself is always written.
+type WalletChanges struct {
+	Members               []string
+	RemovedMembers        []string
+	Transactions          []string
+	Categories            []string
+	RemovedCategories     []string
+	RecurringTransactions []string
+	Budgets               []string
+	RemovedBudgets        []string
+	// BalanceMovements are the transactions that moved the balance, in
+	// order, so the daily snapshots follow it
+	BalanceMovements []BalanceMovement
+}
+
+// IsEmpty reports whether no entity of the wallet changed.
+func (c WalletChanges) IsEmpty() bool {
+	return len(c.Members) == 0 && len(c.RemovedMembers) == 0 &&
+		len(c.Transactions) == 0 &&
+		len(c.Categories) == 0 && len(c.RemovedCategories) == 0 &&
+		len(c.RecurringTransactions) == 0 &&
+		len(c.Budgets) == 0 && len(c.RemovedBudgets) == 0 &&
+		len(c.BalanceMovements) == 0
+}
+
+// Changes returns what must be written to persist the wallet. A wallet that
+// was never saved reports all of its entities.
+func (w *Wallet) Changes() WalletChanges {
+	if w.Version > 0 {
+		return w.changes
+	}
+
+	changes := WalletChanges{BalanceMovements: w.changes.BalanceMovements}
+	for _, member := range w.Members {
+		changes.Members = append(changes.Members, member.Id)
+	}
+	for _, transaction := range w.Transactions {
+		changes.Transactions = append(changes.Transactions, transaction.Id)
+	}
+	for _, category := range w.Categories {
+		changes.Categories = append(changes.Categories, category.Id)
+	}
+	for _, recurring := range w.RecurringTransactions {
+		changes.RecurringTransactions = append(changes.RecurringTransactions, recurring.Id)
+	}
+	for _, budget := range w.Budgets {
+		changes.Budgets = append(changes.Budgets, budget.Id)
+	}
+
+	return changes
+}
+
+// ClearChanges is called once the wallet is saved.
+func (w *Wallet) ClearChanges() {
+	w.changes = WalletChanges{}
+}
+
+// markChanged records the id once, keeping the order entities changed in.
+func markChanged(ids *[]string, id string) {
+	if !slices.Contains(*ids, id) {
+		*ids = append(*ids, id)
+	}
+}
+
+// markRemoved records the removal of an entity, dropping earlier changes to
+// it.
+func markRemoved(changed, removed *[]string, id string) {
+	*changed = slices.DeleteFunc(*changed, func(changedId string) bool { return changedId == id })
+	markChanged(removed, id)
+}
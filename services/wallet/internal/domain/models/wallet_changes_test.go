@@ -0,0 +1,77 @@
+package models_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+)
+
+func TestWalletChanges(t *testing.T) {
+	creator := &models.Member{Id: "member1", Email: "gabriel@example.com"}
+
+	t.Run("should report every entity of a new wallet", func(t *testing.T) {
+		wallet := models.CreateNewWallet("Household", creator, models.DefaultCurrency)
+
+		changes := wallet.Changes()
+		if !slices.Equal(changes.Members, []string{creator.Id}) {
+			t.Errorf("Expected the creator to be written, got %v", changes.Members)
+		}
+		if len(changes.Categories) != len(wallet.Categories) {
+			t.Errorf("Expected %v categories to be written, got %v", len(wallet.Categories), len(changes.Categories))
+		}
+	})
+
+	t.Run("should only report what changed after a save", func(t *testing.T) {
+		wallet := models.CreateNewWallet("Household", creator, models.DefaultCurrency)
+		first, err := wallet.RegisterNewTransaction(models.Monetary{Value: 1000, Offset: 100}, *creator, models.TransactionTypeDeposit, "Salary", nil)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		wallet.Version = 1
+		wallet.ClearChanges()
+
+		if !wallet.Changes().IsEmpty() {
+			t.Fatalf("Expected no changes, got %+v", wallet.Changes())
+		}
+
+		second, err := wallet.RegisterNewTransaction(models.Monetary{Value: 500, Offset: 100}, *creator, models.TransactionTypeWithdraw, "Groceries", nil)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if _, err := wallet.VoidTransaction(first.Id, *creator); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		changes := wallet.Changes()
+		if !slices.Equal(changes.Transactions, []string{second.Id, first.Id}) {
+			t.Errorf("Expected the new and the voided transactions, got %v", changes.Transactions)
+		}
+		if len(changes.Members) != 0 || len(changes.Categories) != 0 {
+			t.Errorf("Expected members and categories to be untouched, got %+v", changes)
+		}
+	})
+
+	t.Run("should report removed categories with their budgets", func(t *testing.T) {
+		wallet := models.CreateNewWallet("Household", creator, models.DefaultCurrency)
+		category := wallet.Categories[0]
+		budget, err := wallet.AddBudget(*creator, models.Monetary{Value: 1000, Offset: 100}, &category.Id, nil)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		wallet.Version = 1
+		wallet.ClearChanges()
+
+		if err := wallet.RemoveCategory(category.Id); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		changes := wallet.Changes()
+		if !slices.Equal(changes.RemovedCategories, []string{category.Id}) {
+			t.Errorf("Expected the category to be removed, got %v", changes.RemovedCategories)
+		}
+		if !slices.Equal(changes.RemovedBudgets, []string{budget.Id}) {
+			t.Errorf("Expected the budget to be removed, got %v", changes.RemovedBudgets)
+		}
+	})
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"time"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
+)
+
+type WalletRole string
+
+const (
+	// WalletRoleOwner can do everything, including sharing, unsharing and renaming the wallet.
+	WalletRoleOwner WalletRole = "owner"
+	// WalletRoleEditor can read the wallet and register transactions.
+	WalletRoleEditor WalletRole = "editor"
+	// WalletRoleViewer can only read the wallet.
+	WalletRoleViewer WalletRole = "viewer"
+)
+
+type WalletPermission string
+
+const (
+	PermissionView   WalletPermission = "view"
+	PermissionWrite  WalletPermission = "write"
+	PermissionManage WalletPermission = "manage"
+)
+
+var rolePermissions = map[WalletRole][]WalletPermission{
+	WalletRoleOwner:  {PermissionView, PermissionWrite, PermissionManage},
+	WalletRoleEditor: {PermissionView, PermissionWrite},
+	WalletRoleViewer: {PermissionView},
+}
+
+func ParseWalletRole(role string) (WalletRole, error) {
+	walletRole := WalletRole(role)
+	if _, ok := rolePermissions[walletRole]; !ok {
+		return "", errx.ErrInvalidWalletRole
+	}
+
+	return walletRole, nil
+}
+
+func (r WalletRole) Allows(permission WalletPermission) bool {
+	for _, p := range rolePermissions[r] {
+		if p == permission {
+			return true
+		}
+	}
+
+	return false
+}
+
+// WalletMember is a member with access to a wallet and the role it was
+// granted in that wallet.
+type WalletMember struct {
+	Member
+
+	Role       WalletRole
+	AssignedAt time.Time
+}
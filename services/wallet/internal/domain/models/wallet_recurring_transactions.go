@@ -0,0 +1,192 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/events"
+)
+
+// AddRecurringTransaction defines a transaction to be registered on the
+// given schedule on behalf of the creator.
+func (w *Wallet) AddRecurringTransaction(amount Monetary, creator Member, transactionType TransactionType, description string, categoryId *string, schedule RecurrenceSchedule) (*RecurringTransaction, error) {
+	if amount.Value <= 0 {
+		return nil, errors.New("invalid amount: must be greater than 0")
+	}
+
+	if transactionType != TransactionTypeDeposit && transactionType != TransactionTypeWithdraw {
+		return nil, errx.ErrInvalidTransactionType
+	}
+
+	if err := w.CheckPermission(creator.Id, PermissionWrite); err != nil {
+		return nil, err
+	}
+
+	amount, err := w.inWalletCurrency(amount)
+	if err != nil {
+		return nil, err
+	}
+
+	if categoryId != nil {
+		if _, err := w.FindCategory(*categoryId); err != nil {
+			return nil, err
+		}
+	}
+
+	currentTime := time.Now()
+	recurring := RecurringTransaction{
+		Id:          uuid.NewString(),
+		WalletId:    w.Id,
+		Amount:      amount,
+		CreatedBy:   creator,
+		Type:        transactionType,
+		Description: description,
+		CategoryId:  categoryId,
+		Frequency:   schedule.Frequency,
+		DayOfMonth:  schedule.DayOfMonth,
+		StartDate:   RecurrenceDate(schedule.StartDate),
+		Status:      RecurringTransactionStatusActive,
+		CreatedAt:   currentTime,
+	}
+
+	if schedule.StartDate.IsZero() {
+		recurring.StartDate = RecurrenceDate(currentTime)
+	}
+
+	if schedule.EndDate != nil {
+		endDate := RecurrenceDate(*schedule.EndDate)
+		recurring.EndDate = &endDate
+	}
+
+	switch recurring.Frequency {
+	case RecurrenceWeekly, RecurrenceYearly:
+		recurring.DayOfMonth = 0
+	case RecurrenceMonthly:
+		if recurring.DayOfMonth == 0 {
+			recurring.DayOfMonth = recurring.StartDate.Day()
+		}
+		if recurring.DayOfMonth < 1 || recurring.DayOfMonth > 31 {
+			return nil, errx.ErrInvalidRecurrence
+		}
+	default:
+		return nil, errx.ErrInvalidRecurrence
+	}
+
+	recurring.NextOccurrence = recurring.firstOccurrence()
+	if recurring.EndDate != nil && recurring.NextOccurrence.After(*recurring.EndDate) {
+		return nil, errx.ErrInvalidRecurrence
+	}
+
+	w.RecurringTransactions = append(w.RecurringTransactions, recurring)
+	markChanged(&w.changes.RecurringTransactions, recurring.Id)
+
+	w.AddEvent(events.RecurringTransactionCreatedEvent{
+		RecurringTransactionId: recurring.Id,
+		WalletId:               w.Id,
+		MemberId:               creator.Id,
+		Description:            description,
+		Amount:                 amount.toEventAmount(),
+		Type:                   string(transactionType),
+		Frequency:              string(recurring.Frequency),
+		NextOccurrence:         recurring.NextOccurrence,
+		EndDate:                recurring.EndDate,
+		Timestamp:              currentTime,
+	})
+	w.UpdatedAt = &currentTime
+
+	return &recurring, nil
+}
+
+func (w *Wallet) FindRecurringTransaction(recurringTransactionId string) (*RecurringTransaction, error) {
+	for i := range w.RecurringTransactions {
+		if w.RecurringTransactions[i].Id == recurringTransactionId {
+			return &w.RecurringTransactions[i], nil
+		}
+	}
+
+	return nil, errx.ErrRecurringTransactionNotFound
+}
+
+// CancelRecurringTransaction stops materialising new occurrences, the ones
+// already registered are kept.
+func (w *Wallet) CancelRecurringTransaction(recurringTransactionId string, member Member) (*RecurringTransaction, error) {
+	if err := w.CheckPermission(member.Id, PermissionWrite); err != nil {
+		return nil, err
+	}
+
+	recurring, err := w.FindRecurringTransaction(recurringTransactionId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !recurring.IsActive() {
+		return recurring, nil
+	}
+
+	currentTime := time.Now()
+	w.cancelRecurringTransaction(recurring, member.Id, currentTime)
+	w.UpdatedAt = &currentTime
+
+	return recurring, nil
+}
+
+// cancelRecurringTransactionsOf cancels the active definitions created by
+// the member, their occurrences are registered on its behalf and would fail
+// once it can no longer write to the wallet.
+func (w *Wallet) cancelRecurringTransactionsOf(memberId, cancelledBy string, currentTime time.Time) {
+	for i := range w.RecurringTransactions {
+		recurring := &w.RecurringTransactions[i]
+		if recurring.CreatedBy.Id == memberId && recurring.IsActive() {
+			w.cancelRecurringTransaction(recurring, cancelledBy, currentTime)
+		}
+	}
+}
+
+func (w *Wallet) cancelRecurringTransaction(recurring *RecurringTransaction, cancelledBy string, currentTime time.Time) {
+	recurring.Status = RecurringTransactionStatusCancelled
+	recurring.UpdatedAt = &currentTime
+	markChanged(&w.changes.RecurringTransactions, recurring.Id)
+
+	w.AddEvent(events.RecurringTransactionCancelledEvent{
+		RecurringTransactionId: recurring.Id,
+		WalletId:               w.Id,
+		MemberId:               cancelledBy,
+		Timestamp:              currentTime,
+	})
+}
+
+// RegisterRecurringOccurrence materialises the occurrence of a recurring
+// transaction, dated on the occurrence even when it is caught up later. Only
+// the next pending occurrence is accepted, so registering the same occurrence
+// twice fails with errx.ErrOccurrenceNotDue.
+func (w *Wallet) RegisterRecurringOccurrence(recurringTransactionId string, occurrence time.Time) (*Transaction, error) {
+	recurring, err := w.FindRecurringTransaction(recurringTransactionId)
+	if err != nil {
+		return nil, err
+	}
+
+	currentTime := time.Now()
+	occurrence = RecurrenceDate(occurrence)
+	if !recurring.IsDue(currentTime) || !occurrence.Equal(recurring.NextOccurrence) {
+		return nil, errx.ErrOccurrenceNotDue
+	}
+
+	transaction, err := w.registerTransaction(
+		recurring.Amount,
+		recurring.CreatedBy,
+		recurring.Type,
+		recurring.Description,
+		recurring.CategoryId,
+		&transactionOrigin{recurringTransactionId: recurring.Id, occurrenceDate: occurrence, occurredAt: occurrence},
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	recurring.advance(occurrence, currentTime)
+	markChanged(&w.changes.RecurringTransactions, recurring.Id)
+	return transaction, nil
+}
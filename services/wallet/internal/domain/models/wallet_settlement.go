@@ -0,0 +1,282 @@
+package models
+
+import (
+	"cmp"
+	"math/bits"
+	"slices"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
+)
+
+// MemberBalance is where a member stands in the expenses shared in a wallet.
+type MemberBalance struct {
+	MemberId string
+	// Shared expenses and settlements paid by the member
+	Paid Monetary
+	// Shares of expenses owed by the member and settlements paid to it
+	Owed Monetary
+	// Paid minus Owed, positive when the others owe the member
+	Net Monetary
+}
+
+// SettlementPayment is a payment that settles a debt between two members.
+type SettlementPayment struct {
+	FromMemberId string
+	ToMemberId   string
+	Amount       Monetary
+}
+
+// Settlement is who owes whom in a wallet. Following the payments zeroes
+// every balance.
+type Settlement struct {
+	WalletId string
+	Balances []MemberBalance
+	Payments []SettlementPayment
+}
+
+// Settlement computes the balances of the members from the active split
+// expenses and settlements, and the fewest payments to settle them.
+func (w *Wallet) Settlement() Settlement {
+	zero := Monetary{Offset: w.Balance.Offset, Currency: w.Balance.Currency}
+	balances := []MemberBalance{}
+	index := map[string]int{}
+
+	balanceOf := func(memberId string) *MemberBalance {
+		i, ok := index[memberId]
+		if !ok {
+			i = len(balances)
+			index[memberId] = i
+			balances = append(balances, MemberBalance{MemberId: memberId, Paid: zero, Owed: zero, Net: zero})
+		}
+		return &balances[i]
+	}
+
+	// Current members are listed even when they have nothing to settle
+	for _, member := range w.Members {
+		balanceOf(member.Id)
+	}
+
+	for _, transaction := range w.Transactions {
+		if !transaction.IsActive() {
+			continue
+		}
+
+		switch {
+		case transaction.Split != nil:
+			payer := balanceOf(transaction.CreatedBy.Id)
+			payer.Paid = payer.Paid.Sum(transaction.Amount)
+			for _, share := range transaction.Split.Shares {
+				member := balanceOf(share.MemberId)
+				member.Owed = member.Owed.Sum(share.Amount)
+			}
+		case transaction.IsSettlement() && transaction.SettledWithId != nil:
+			payer := balanceOf(transaction.CreatedBy.Id)
+			payer.Paid = payer.Paid.Sum(transaction.Amount)
+			recipient := balanceOf(*transaction.SettledWithId)
+			recipient.Owed = recipient.Owed.Sum(transaction.Amount)
+		}
+	}
+
+	for i := range balances {
+		balances[i].Net = balances[i].Paid.Sub(balances[i].Owed)
+	}
+
+	payments := []SettlementPayment{}
+	for _, group := range zeroSumGroups(balances) {
+		payments = append(payments, settleGroup(group)...)
+	}
+	slices.SortStableFunc(payments, func(a, b SettlementPayment) int {
+		if c := b.Amount.Cmp(a.Amount); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(a.FromMemberId, b.FromMemberId); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.ToMemberId, b.ToMemberId)
+	})
+
+	return Settlement{WalletId: w.Id, Balances: balances, Payments: payments}
+}
+
+// maxExactSettlementMembers bounds the members with open balances whose
+// payments are minimised exactly, the search doubles with each member. Above
+// it every open balance is settled together, in at most n-1 payments.
+const maxExactSettlementMembers = 16
+
+// settlementPosition is the net balance of a member still to be settled.
+type settlementPosition struct {
+	memberId string
+	amount   Monetary
+}
+
+// zeroSumGroups splits the members with open balances in as many groups
+// adding up to zero as possible. A group of k members settles in k-1
+// payments, so the most groups make the fewest payments.
+func zeroSumGroups(balances []MemberBalance) [][]settlementPosition {
+	open := []settlementPosition{}
+	offset := 1
+	for _, balance := range balances {
+		if !balance.Net.IsZero() {
+			open = append(open, settlementPosition{balance.MemberId, balance.Net})
+			offset = lcm(offset, validOffset(balance.Net.Offset))
+		}
+	}
+
+	if len(open) == 0 {
+		return nil
+	}
+	if len(open) > maxExactSettlementMembers {
+		return [][]settlementPosition{open}
+	}
+
+	values := make([]int, len(open))
+	for i, position := range open {
+		values[i] = position.amount.Rescale(offset, RoundHalfEven).Value
+	}
+
+	// sums[mask] is the net of the members in mask and groups[mask] the most
+	// zero-sum groups they can be ordered into, each closed by a prefix of
+	// the order adding up to zero
+	full := 1<<len(open) - 1
+	sums := make([]int, full+1)
+	groups := make([]int, full+1)
+	for mask := 1; mask <= full; mask++ {
+		sums[mask] = sums[mask&(mask-1)] + values[bits.TrailingZeros(uint(mask))]
+		for i := range open {
+			if rest := mask &^ (1 << i); rest != mask && groups[rest] > groups[mask] {
+				groups[mask] = groups[rest]
+			}
+		}
+		if sums[mask] == 0 {
+			groups[mask]++
+		}
+	}
+
+	result := [][]settlementPosition{}
+	group := []settlementPosition{}
+	for mask := full; mask != 0; {
+		target := groups[mask]
+		if sums[mask] == 0 {
+			target--
+		}
+
+		for i := range open {
+			if rest := mask &^ (1 << i); rest != mask && groups[rest] == target {
+				group = append(group, open[i])
+				mask = rest
+				break
+			}
+		}
+
+		if sums[mask] == 0 {
+			result = append(result, group)
+			group = []settlementPosition{}
+		}
+	}
+
+	return result
+}
+
+// settleGroup pays the largest debt to the largest credit until every
+// balance of the group is zero, taking at most one payment less than its
+// members.
+func settleGroup(group []settlementPosition) []SettlementPayment {
+	creditors := []settlementPosition{}
+	debtors := []settlementPosition{}
+	for _, position := range group {
+		if position.amount.Value > 0 {
+			creditors = append(creditors, position)
+		} else {
+			debtors = append(debtors, settlementPosition{position.memberId, position.amount.Neg()})
+		}
+	}
+
+	largestFirst := func(a, b settlementPosition) int {
+		if c := b.amount.Cmp(a.amount); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.memberId, b.memberId)
+	}
+	slices.SortFunc(creditors, largestFirst)
+	slices.SortFunc(debtors, largestFirst)
+
+	payments := []SettlementPayment{}
+	for c, d := 0, 0; c < len(creditors) && d < len(debtors); {
+		amount := creditors[c].amount
+		if debtors[d].amount.Cmp(amount) < 0 {
+			amount = debtors[d].amount
+		}
+
+		payments = append(payments, SettlementPayment{
+			FromMemberId: debtors[d].memberId,
+			ToMemberId:   creditors[c].memberId,
+			Amount:       amount,
+		})
+
+		creditors[c].amount = creditors[c].amount.Sub(amount)
+		debtors[d].amount = debtors[d].amount.Sub(amount)
+		if creditors[c].amount.IsZero() {
+			c++
+		}
+		if debtors[d].amount.IsZero() {
+			d++
+		}
+	}
+
+	return payments
+}
+
+// hasOpenBalance reports whether the member owes or is owed money for
+// shared expenses.
+func (w *Wallet) hasOpenBalance(memberId string) bool {
+	for _, balance := range w.Settlement().Balances {
+		if balance.MemberId == memberId {
+			return !balance.Net.IsZero()
+		}
+	}
+
+	return false
+}
+
+// RegisterSettlement records the payer paying back another member. Without
+// an amount, the payment suggested from the payer to the member is settled.
+func (w *Wallet) RegisterSettlement(payer Member, settledWithId string, amount *Monetary, description string) (*Transaction, error) {
+	if amount == nil {
+		payments := w.Settlement().Payments
+		index := slices.IndexFunc(payments, func(payment SettlementPayment) bool {
+			return payment.FromMemberId == payer.Id && payment.ToMemberId == settledWithId
+		})
+		if index < 0 {
+			return nil, errx.ErrNothingToSettle
+		}
+		amount = &payments[index].Amount
+	}
+
+	if description == "" {
+		description = "Settle up"
+	}
+
+	return w.registerTransaction(*amount, payer, TransactionTypeSettlement, description, nil, nil, &transactionParties{settledWithId: settledWithId})
+}
+
+// allocateSplit computes the shares of a split expense among members of the
+// wallet.
+func (w *Wallet) allocateSplit(transactionType TransactionType, amount Monetary, split TransactionSplit) (*TransactionSplit, error) {
+	if transactionType != TransactionTypeWithdraw {
+		return nil, errx.ErrInvalidSplit
+	}
+
+	if split.Method == SplitEqual && len(split.Shares) == 0 {
+		for _, member := range w.Members {
+			split.Shares = append(split.Shares, SplitShare{MemberId: member.Id})
+		}
+	}
+
+	for _, share := range split.Shares {
+		if !w.IsMember(share.MemberId) {
+			return nil, errx.ErrInvalidSplit
+		}
+	}
+
+	return split.Allocate(amount)
+}
@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+// WalletSummary is the header of a wallet with aggregates of its activity,
+// cheap enough to be listed without loading the transactions.
+type WalletSummary struct {
+	Id        string
+	Name      string
+	CreatorId string
+	Balance   Monetary
+	// Role of the member the wallets were listed for
+	Role WalletRole
+
+	// Active transactions only, voided and replaced entries are history
+	TransactionCount int
+	// Latest registration or change of a transaction, nil without any
+	LastActivityAt *time.Time
+
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+}
+
+// WalletDetail is a read model of a wallet with only its most recent
+// transactions. It is not an aggregate and cannot be saved back.
+type WalletDetail struct {
+	Id         string
+	Name       string
+	CreatorId  string
+	Balance    Monetary
+	Members    []WalletMember
+	Categories []Category
+
+	// Most recent transactions first, NextTransactionsCursor continues the
+	// listing when there are older ones
+	RecentTransactions     []Transaction
+	NextTransactionsCursor string
+
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+}
@@ -0,0 +1,20 @@
+package repository
+
+import "time"
+
+// OutboxStats describes the messages not sent yet. OldestPending is nil when
+// there are none. Failed messages were given up on and are not pending.
+type OutboxStats struct {
+	Pending       int
+	OldestPending *time.Time
+	Failed        int
+}
+
+// Lag is how long the oldest pending message has been waiting.
+func (s OutboxStats) Lag(now time.Time) time.Duration {
+	if s.OldestPending == nil {
+		return 0
+	}
+
+	return now.Sub(*s.OldestPending)
+}
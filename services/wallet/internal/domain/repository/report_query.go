@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+)
+
+// EvolutionQuery selects the active transactions created between From
+// (inclusive) and To (exclusive), grouped in buckets of the granularity as
+// seen in Location.
+type EvolutionQuery struct {
+	Granularity models.ReportGranularity
+	Location    *time.Location
+	From        time.Time
+	To          time.Time
+}
+
+type EvolutionAggregates struct {
+	// Balance right before From, in the wallet currency
+	Opening models.Monetary
+	// Only buckets with transactions, in no particular order
+	Totals []models.EvolutionTotals
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
 )
@@ -9,12 +10,99 @@ import (
 type Repositories struct {
 	Member interface {
 		FindByID(ctx context.Context, id string) (*models.Member, error)
+		// FindByIDs looks many members up at once, ids that do not exist
+		// are skipped
+		FindByIDs(ctx context.Context, ids []string) ([]models.Member, error)
 		FindByEmail(ctx context.Context, email string) (*models.Member, error)
 		ValidateToken(ctx context.Context, token string) (*models.Member, error)
+		// Upsert stores a copy of a member-service member, unless the stored
+		// one changed more recently
+		Upsert(ctx context.Context, member *models.Member) error
 	}
 	Wallet interface {
 		FindById(ctx context.Context, id string) (*models.Wallet, error)
-		FindByUserId(ctx context.Context, userId string) ([]models.Wallet, error)
+		// FindDetailById loads a read model of the wallet without its transactions
+		FindDetailById(ctx context.Context, id string) (*models.WalletDetail, error)
+		FindSummariesByUserId(ctx context.Context, userId string) ([]models.WalletSummary, error)
 		Save(ctx context.Context, wallet *models.Wallet) error
+		// SaveAll saves the wallets atomically, either all or none of them are persisted
+		SaveAll(ctx context.Context, wallets ...*models.Wallet) error
+		// SaveWithInvitation saves the wallet and the invitation atomically
+		SaveWithInvitation(ctx context.Context, wallet *models.Wallet, invitation *models.Invitation) error
+		// SaveWithStatementImport saves the wallet and the import atomically
+		SaveWithStatementImport(ctx context.Context, wallet *models.Wallet, statementImport *models.StatementImport) error
+		FindDueRecurringTransactions(ctx context.Context, until time.Time) ([]models.RecurringTransaction, error)
+		// FindMemberRole returns the role of the member without loading the
+		// wallet, errx.ErrMemberNotInWallet when the member has no access
+		FindMemberRole(ctx context.Context, walletId, memberId string) (models.WalletRole, error)
+		ListTransactions(ctx context.Context, walletId string, filter TransactionFilter) (*TransactionPage, error)
+		AggregateEvolution(ctx context.Context, walletId string, query EvolutionQuery) (*EvolutionAggregates, error)
+		// BalanceAt returns the balance right before the moment, in the
+		// wallet currency, from the daily snapshots and the active
+		// transactions of its day
+		BalanceAt(ctx context.Context, walletId string, at time.Time) (models.Monetary, error)
+		// StreamTransactions calls fn for each active transaction created
+		// between from (inclusive) and to (exclusive), oldest first, without
+		// loading them all at once. An error returned by fn stops the stream
+		StreamTransactions(ctx context.Context, walletId string, from, to *time.Time, fn func(models.Transaction) error) error
+		// RebuildBalanceSnapshots replaces the daily snapshots of the wallet
+		// with the ones computed from its active transactions
+		RebuildBalanceSnapshots(ctx context.Context, walletId string) error
+		// ListIds returns the id of every wallet
+		ListIds(ctx context.Context) ([]string, error)
+	}
+	StatementImport interface {
+		FindById(ctx context.Context, id string) (*models.StatementImport, error)
+		Save(ctx context.Context, statementImport *models.StatementImport) error
+		// FindCommittedRows returns the rows registered by earlier imports of
+		// the wallet dated between from and to, both inclusive
+		FindCommittedRows(ctx context.Context, walletId string, from, to time.Time) ([]models.StatementRow, error)
+		// FindImportedExternalIds returns, among the given bank ids, the ones
+		// already imported to the wallet mapped to their transaction id
+		FindImportedExternalIds(ctx context.Context, walletId string, externalIds []string) (map[string]string, error)
+	}
+	Invitation interface {
+		FindById(ctx context.Context, id string) (*models.Invitation, error)
+		FindByToken(ctx context.Context, token string) (*models.Invitation, error)
+		// FindPending returns the pending invitation of the wallet to the
+		// email, expired ones included, errx.ErrInvitationNotFound when there
+		// is none
+		FindPending(ctx context.Context, walletId, email string) (*models.Invitation, error)
+		// FindPendingFor returns the pending invitations of the member, the
+		// ones linked to it and the ones sent to its email not linked to
+		// anybody yet, expired ones included
+		FindPendingFor(ctx context.Context, member models.Member) ([]models.Invitation, error)
+		Save(ctx context.Context, invitation *models.Invitation) error
+	}
+	// Outbox is only available with the PostgreSQL repositories, the in
+	// memory ones publish the events right away
+	Outbox interface {
+		// Relay locks up to limit pending messages, oldest first, and hands
+		// them to publish in order. Published messages are marked as sent; the
+		// first failure is recorded on its message and stops the batch so the
+		// order is kept. A message failing maxAttempts times is marked as
+		// failed and skipped from then on. Only one relay runs at a time, the
+		// others publish nothing
+		Relay(ctx context.Context, limit, maxAttempts int, publish func(ctx context.Context, message models.OutboxMessage) error) (int, error)
+		Stats(ctx context.Context) (OutboxStats, error)
+	}
+	IdempotencyKey interface {
+		// Reserve stores the request as in progress. When the member already
+		// used the key and it has neither expired nor been abandoned, nothing
+		// is stored and the existing request is returned instead
+		Reserve(ctx context.Context, request *models.IdempotentRequest) (*models.IdempotentRequest, error)
+		// Complete stores the response of a reserved request. It fails with
+		// errx.ErrIdempotencyKeyTakenOver when a retry took the key over
+		Complete(ctx context.Context, request *models.IdempotentRequest) error
+		// Release forgets a reserved request so the key can be used again,
+		// unless a retry took the key over
+		Release(ctx context.Context, request *models.IdempotentRequest) error
+		// DeleteExpired removes the keys expired by now, returning how many
+		DeleteExpired(ctx context.Context, now time.Time) (int, error)
+	}
+	ExchangeRate interface {
+		// FindRate returns the rate converting base to quote,
+		// errx.ErrExchangeRateNotFound when none is known
+		FindRate(ctx context.Context, base, quote string) (*models.ExchangeRate, error)
 	}
 }
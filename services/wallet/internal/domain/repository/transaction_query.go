@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+)
+
+const (
+	DefaultTransactionPageSize = 50
+	MaxTransactionPageSize     = 200
+)
+
+// TransactionFilter narrows down the transactions of a wallet. Zero values
+// do not filter. Transactions are listed from the most recent to the oldest.
+type TransactionFilter struct {
+	// CreatedAt range, From is inclusive and To exclusive
+	From *time.Time
+	To   *time.Time
+
+	Type      models.TransactionType
+	Status    models.TransactionStatus
+	CreatedBy string
+
+	// Amount range, both inclusive
+	MinAmount *models.Monetary
+	MaxAmount *models.Monetary
+
+	// Case insensitive search on the description
+	Search string
+
+	// Cursor returned by the previous page, empty for the first one
+	Cursor string
+	Limit  int
+}
+
+type TransactionPage struct {
+	Transactions []models.Transaction
+	// Empty when there are no more transactions
+	NextCursor string
+}
+
+// PageSize returns the limit bounded to MaxTransactionPageSize.
+func (f TransactionFilter) PageSize() int {
+	if f.Limit <= 0 {
+		return DefaultTransactionPageSize
+	}
+
+	return min(f.Limit, MaxTransactionPageSize)
+}
+
+// Matches reports whether the transaction satisfies every filter but the
+// cursor, for repositories filtering in memory.
+func (f TransactionFilter) Matches(transaction models.Transaction) bool {
+	if f.From != nil && transaction.CreatedAt.Before(*f.From) {
+		return false
+	}
+	if f.To != nil && !transaction.CreatedAt.Before(*f.To) {
+		return false
+	}
+	if f.Type != "" && transaction.Type != f.Type {
+		return false
+	}
+	if f.Status != "" && transaction.Status != f.Status {
+		return false
+	}
+	if f.CreatedBy != "" && transaction.CreatedBy.Id != f.CreatedBy {
+		return false
+	}
+	if f.MinAmount != nil && transaction.Amount.Cmp(*f.MinAmount) < 0 {
+		return false
+	}
+	if f.MaxAmount != nil && transaction.Amount.Cmp(*f.MaxAmount) > 0 {
+		return false
+	}
+	if f.Search != "" && !strings.Contains(strings.ToLower(transaction.Description), strings.ToLower(f.Search)) {
+		return false
+	}
+
+	return true
+}
+
+// TransactionCursor is the position of the last transaction of a page.
+// Pages are keyed by (CreatedAt, Id) so new transactions do not shift them.
+type TransactionCursor struct {
+	CreatedAt time.Time
+	Id        string
+}
+
+func (c TransactionCursor) Encode() string {
+	return base64.RawURLEncoding.EncodeToString([]byte(c.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + c.Id))
+}
+
+// After reports whether the transaction comes after the cursor in the listing order.
+func (c TransactionCursor) After(transaction models.Transaction) bool {
+	if transaction.CreatedAt.Equal(c.CreatedAt) {
+		return transaction.Id < c.Id
+	}
+
+	return transaction.CreatedAt.Before(c.CreatedAt)
+}
+
+func DecodeTransactionCursor(cursor string) (*TransactionCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, errx.ErrInvalidCursor
+	}
+
+	createdAt, id, found := strings.Cut(string(data), "|")
+	if !found || id == "" {
+		return nil, errx.ErrInvalidCursor
+	}
+
+	timestamp, err := time.Parse(time.RFC3339Nano, createdAt)
+	if err != nil {
+		return nil, errx.ErrInvalidCursor
+	}
+
+	return &TransactionCursor{CreatedAt: timestamp, Id: id}, nil
+}
@@ -0,0 +1,51 @@
+package controllers
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/infra/controllers/presenter"
+	usecases "github.com/lopesgabriel/tellawl/services/wallet/internal/use-cases"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func (h *APIHandler) HandleAcceptInvitation(w http.ResponseWriter, r *http.Request) {
+	ctx, span := h.tracer.Start(r.Context(), "HandleAcceptInvitation")
+	defer span.End()
+
+	member := r.Context().Value(memberContextKey).(*models.Member)
+
+	vars := mux.Vars(r)
+	token := vars["token"]
+	if token == "" {
+		h.logger.Error(ctx, "Could not get invitation token from path")
+		WriteError(w, http.StatusInternalServerError, map[string]any{
+			"message": "Could not get invitation token from path",
+		})
+		return
+	}
+
+	span.SetAttributes(attribute.String("user_id", member.Id))
+	invitation, err := h.usecases.AcceptInvitation(ctx, usecases.AcceptInvitationUseCaseInput{
+		MemberId: member.Id,
+		Member:   member,
+		Token:    token,
+	})
+	if err != nil {
+		h.logger.Error(ctx, "Could not accept the invitation", slog.String("error", err.Error()))
+		WriteError(w, errorStatusCode(err, http.StatusInternalServerError), map[string]any{
+			"message": "Could not accept the invitation",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	httpInvitation := presenter.NewHTTPInvitation(*invitation, time.Now())
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(httpInvitation.ToJSON())
+}
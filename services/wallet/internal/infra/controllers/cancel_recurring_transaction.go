@@ -0,0 +1,51 @@
+package controllers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+	usecases "github.com/lopesgabriel/tellawl/services/wallet/internal/use-cases"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func (h *APIHandler) HandleCancelRecurringTransaction(w http.ResponseWriter, r *http.Request) {
+	ctx, span := h.tracer.Start(r.Context(), "HandleCancelRecurringTransaction")
+	defer span.End()
+
+	member := r.Context().Value(memberContextKey).(*models.Member)
+
+	vars := mux.Vars(r)
+	walletId := vars["wallet_id"]
+	recurringTransactionId := vars["recurring_transaction_id"]
+	if walletId == "" || recurringTransactionId == "" {
+		h.logger.Error(ctx, "Could not get wallet id or recurring transaction id from path")
+		WriteError(w, http.StatusInternalServerError, map[string]any{
+			"message": "Could not get wallet id or recurring transaction id from path",
+		})
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("wallet_id", walletId),
+		attribute.String("recurring_transaction_id", recurringTransactionId),
+		attribute.String("user_id", member.Id),
+	)
+	_, err := h.usecases.CancelRecurringTransaction(ctx, usecases.CancelRecurringTransactionUseCaseInput{
+		MemberId:               member.Id,
+		Member:                 member,
+		WalletId:               walletId,
+		RecurringTransactionId: recurringTransactionId,
+	})
+	if err != nil {
+		h.logger.Error(ctx, "Could not cancel the recurring transaction", slog.String("error", err.Error()))
+		WriteError(w, errorStatusCode(err, http.StatusBadRequest), map[string]any{
+			"message": "Could not cancel the recurring transaction",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,76 @@
+package controllers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/infra/controllers/presenter"
+	usecases "github.com/lopesgabriel/tellawl/services/wallet/internal/use-cases"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type changeMemberRoleRequest struct {
+	Role string `json:"role"`
+}
+
+func (h *APIHandler) HandleChangeMemberRole(w http.ResponseWriter, r *http.Request) {
+	ctx, span := h.tracer.Start(r.Context(), "HandleChangeMemberRole")
+	defer span.End()
+
+	member := r.Context().Value(memberContextKey).(*models.Member)
+
+	var data changeMemberRoleRequest
+	err := json.NewDecoder(r.Body).Decode(&data)
+	defer r.Body.Close()
+	if err != nil {
+		h.logger.Error(ctx, "Could not decode the request body", slog.String("error", err.Error()))
+		WriteError(w, http.StatusBadRequest, map[string]any{
+			"message": "Could not parse the request body, are you sending a JSON?",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	vars := mux.Vars(r)
+	walletId := vars["wallet_id"]
+	memberId := vars["member_id"]
+	if walletId == "" || memberId == "" {
+		h.logger.Error(ctx, "Could not get wallet id or member id from path")
+		WriteError(w, http.StatusInternalServerError, map[string]any{
+			"message": "Could not get wallet id or member id from path",
+		})
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("wallet_id", walletId),
+		attribute.String("member_id", memberId),
+		attribute.String("user_id", member.Id),
+		attribute.String("role", data.Role),
+	)
+
+	wallet, err := h.usecases.ChangeMemberRole(ctx, usecases.ChangeMemberRoleUseCaseInput{
+		OwnerId:  member.Id,
+		Owner:    member,
+		WalletId: walletId,
+		MemberId: memberId,
+		Role:     data.Role,
+	})
+	if err != nil {
+		h.logger.Error(ctx, "Could not change the member role", slog.String("error", err.Error()))
+		WriteError(w, errorStatusCode(err, http.StatusInternalServerError), map[string]any{
+			"message": "Could not change the member role",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	httpWallet := presenter.NewHTTPWallet(*wallet)
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(httpWallet.ToJSON())
+}
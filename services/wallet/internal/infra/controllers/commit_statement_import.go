@@ -0,0 +1,78 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/infra/controllers/presenter"
+	usecases "github.com/lopesgabriel/tellawl/services/wallet/internal/use-cases"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type commitStatementImportRequest struct {
+	// Without it every valid row not flagged as a duplicate is registered
+	AcceptedLines []int `json:"accepted_lines"`
+}
+
+func (h *APIHandler) HandleCommitStatementImport(w http.ResponseWriter, r *http.Request) {
+	ctx, span := h.tracer.Start(r.Context(), "HandleCommitStatementImport")
+	defer span.End()
+
+	member := r.Context().Value(memberContextKey).(*models.Member)
+
+	var data commitStatementImportRequest
+	// Read the requst body, it is optional
+	err := json.NewDecoder(r.Body).Decode(&data)
+	defer r.Body.Close()
+	if err != nil && !errors.Is(err, io.EOF) {
+		h.logger.Error(ctx, "Could not decode the request body", slog.String("error", err.Error()))
+		WriteError(w, http.StatusBadRequest, map[string]any{
+			"message": "Could not parse the request body, are you sending a JSON?",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	vars := mux.Vars(r)
+	walletId := vars["wallet_id"]
+	importId := vars["import_id"]
+	if walletId == "" || importId == "" {
+		h.logger.Error(ctx, "Could not get wallet or import id from path")
+		WriteError(w, http.StatusInternalServerError, map[string]any{
+			"message": "Could not get wallet or import id from path",
+		})
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("wallet_id", walletId),
+		attribute.String("import_id", importId),
+		attribute.String("user_id", member.Id),
+	)
+	statementImport, err := h.usecases.CommitStatementImport(ctx, usecases.CommitStatementImportUseCaseInput{
+		MemberId:      member.Id,
+		Member:        member,
+		WalletId:      walletId,
+		ImportId:      importId,
+		AcceptedLines: data.AcceptedLines,
+	})
+	if err != nil {
+		h.logger.Error(ctx, "Could not commit the statement import", slog.String("error", err.Error()))
+		WriteError(w, errorStatusCode(err, http.StatusInternalServerError), map[string]any{
+			"message": "Could not commit the statement import",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	httpStatementImport := presenter.NewHTTPStatementImport(*statementImport)
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(httpStatementImport.ToJSON())
+}
@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
@@ -9,6 +10,7 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/lopesgabriel/tellawl/packages/logger"
 	"github.com/lopesgabriel/tellawl/packages/tracing"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
 	usecases "github.com/lopesgabriel/tellawl/services/wallet/internal/use-cases"
 	"go.opentelemetry.io/contrib/instrumentation/github.com/gorilla/mux/otelmux"
 	"go.opentelemetry.io/otel/trace"
@@ -44,16 +46,92 @@ func (handler *APIHandler) registerEndpoints() *mux.Router {
 	router.HandleFunc("/health", handler.HandleHealthCheck).Methods("GET")
 
 	// Authenticated routes
-	router.Handle("/wallets", handler.jwtAuthMiddleware(
-		http.HandlerFunc(handler.HandleCreateWallet))).Methods("POST")
+	router.Handle("/wallets", handler.jwtAuthMiddleware(handler.idempotencyMiddleware(
+		http.HandlerFunc(handler.HandleCreateWallet)))).Methods("POST")
 	router.Handle("/wallets", handler.jwtAuthMiddleware(
 		http.HandlerFunc(handler.HandleListUserWallets))).Methods("GET")
-	router.Handle("/wallets/{wallet_id}/share", handler.jwtAuthMiddleware(
-		http.HandlerFunc(handler.HandleShareWallet))).Methods("POST")
+	router.Handle("/wallets/{wallet_id}", handler.jwtAuthMiddleware(
+		http.HandlerFunc(handler.HandleGetWallet))).Methods("GET")
+	router.Handle("/wallets/{wallet_id}", handler.jwtAuthMiddleware(
+		http.HandlerFunc(handler.HandleRenameWallet))).Methods("PATCH")
+	router.Handle("/wallets/{wallet_id}/share", handler.jwtAuthMiddleware(handler.idempotencyMiddleware(
+		http.HandlerFunc(handler.HandleShareWallet)))).Methods("POST")
+	router.Handle("/wallets/{wallet_id}/members/{member_id}", handler.jwtAuthMiddleware(
+		http.HandlerFunc(handler.HandleRemoveWalletMember))).Methods("DELETE")
+	router.Handle("/wallets/{wallet_id}/members/{member_id}", handler.jwtAuthMiddleware(
+		http.HandlerFunc(handler.HandleChangeMemberRole))).Methods("PATCH")
+
+	// Invitations
+	router.Handle("/me/invitations", handler.jwtAuthMiddleware(
+		http.HandlerFunc(handler.HandleListMyInvitations))).Methods("GET")
+	router.Handle("/invitations/{token}/accept", handler.jwtAuthMiddleware(handler.idempotencyMiddleware(
+		http.HandlerFunc(handler.HandleAcceptInvitation)))).Methods("POST")
+	router.Handle("/invitations/{token}/decline", handler.jwtAuthMiddleware(handler.idempotencyMiddleware(
+		http.HandlerFunc(handler.HandleDeclineInvitation)))).Methods("POST")
+	router.Handle("/wallets/{wallet_id}/invitations/{invitation_id}", handler.jwtAuthMiddleware(
+		http.HandlerFunc(handler.HandleRevokeInvitation))).Methods("DELETE")
 
 	// Transactions
 	router.Handle("/wallets/{wallet_id}/transactions", handler.jwtAuthMiddleware(
-		http.HandlerFunc(handler.HandleRegisterTransaction))).Methods("POST")
+		http.HandlerFunc(handler.HandleListTransactions))).Methods("GET")
+	router.Handle("/wallets/{wallet_id}/transactions", handler.jwtAuthMiddleware(handler.idempotencyMiddleware(
+		http.HandlerFunc(handler.HandleRegisterTransaction)))).Methods("POST")
+	router.Handle("/wallets/{wallet_id}/transactions/{transaction_id}", handler.jwtAuthMiddleware(
+		http.HandlerFunc(handler.HandleUpdateTransaction))).Methods("PATCH")
+	router.Handle("/wallets/{wallet_id}/transactions/{transaction_id}", handler.jwtAuthMiddleware(
+		http.HandlerFunc(handler.HandleVoidTransaction))).Methods("DELETE")
+
+	// Transfers
+	router.Handle("/wallets/{wallet_id}/transfers", handler.jwtAuthMiddleware(handler.idempotencyMiddleware(
+		http.HandlerFunc(handler.HandleRegisterTransfer)))).Methods("POST")
+
+	// Statement imports
+	router.Handle("/wallets/{wallet_id}/imports", handler.jwtAuthMiddleware(handler.idempotencyMiddleware(
+		http.HandlerFunc(handler.HandleCreateStatementImport)))).Methods("POST")
+	router.Handle("/wallets/{wallet_id}/imports/{import_id}", handler.jwtAuthMiddleware(
+		http.HandlerFunc(handler.HandleGetStatementImport))).Methods("GET")
+	router.Handle("/wallets/{wallet_id}/imports/{import_id}/commit", handler.jwtAuthMiddleware(handler.idempotencyMiddleware(
+		http.HandlerFunc(handler.HandleCommitStatementImport)))).Methods("POST")
+
+	// Exports
+	router.Handle("/wallets/{wallet_id}/export", handler.jwtAuthMiddleware(
+		http.HandlerFunc(handler.HandleExportTransactions))).Methods("GET")
+
+	// Recurring transactions
+	router.Handle("/wallets/{wallet_id}/recurring-transactions", handler.jwtAuthMiddleware(
+		http.HandlerFunc(handler.HandleListRecurringTransactions))).Methods("GET")
+	router.Handle("/wallets/{wallet_id}/recurring-transactions", handler.jwtAuthMiddleware(handler.idempotencyMiddleware(
+		http.HandlerFunc(handler.HandleCreateRecurringTransaction)))).Methods("POST")
+	router.Handle("/wallets/{wallet_id}/recurring-transactions/{recurring_transaction_id}", handler.jwtAuthMiddleware(
+		http.HandlerFunc(handler.HandleCancelRecurringTransaction))).Methods("DELETE")
+
+	// Reports
+	router.Handle("/wallets/{wallet_id}/reports/evolution", handler.jwtAuthMiddleware(
+		http.HandlerFunc(handler.HandleGetEvolutionReport))).Methods("GET")
+	router.Handle("/me/net-worth", handler.jwtAuthMiddleware(
+		http.HandlerFunc(handler.HandleGetNetWorth))).Methods("GET")
+
+	// Settlement
+	router.Handle("/wallets/{wallet_id}/settlement", handler.jwtAuthMiddleware(
+		http.HandlerFunc(handler.HandleGetSettlement))).Methods("GET")
+
+	// Budgets
+	router.Handle("/wallets/{wallet_id}/budgets", handler.jwtAuthMiddleware(
+		http.HandlerFunc(handler.HandleListBudgets))).Methods("GET")
+	router.Handle("/wallets/{wallet_id}/budgets", handler.jwtAuthMiddleware(handler.idempotencyMiddleware(
+		http.HandlerFunc(handler.HandleCreateBudget)))).Methods("POST")
+	router.Handle("/wallets/{wallet_id}/budgets/{budget_id}", handler.jwtAuthMiddleware(
+		http.HandlerFunc(handler.HandleDeleteBudget))).Methods("DELETE")
+
+	// Categories
+	router.Handle("/wallets/{wallet_id}/categories", handler.jwtAuthMiddleware(
+		http.HandlerFunc(handler.HandleListWalletCategories))).Methods("GET")
+	router.Handle("/wallets/{wallet_id}/categories", handler.jwtAuthMiddleware(handler.idempotencyMiddleware(
+		http.HandlerFunc(handler.HandleCreateCategory)))).Methods("POST")
+	router.Handle("/wallets/{wallet_id}/categories/{category_id}", handler.jwtAuthMiddleware(
+		http.HandlerFunc(handler.HandleUpdateCategory))).Methods("PATCH")
+	router.Handle("/wallets/{wallet_id}/categories/{category_id}", handler.jwtAuthMiddleware(
+		http.HandlerFunc(handler.HandleDeleteCategory))).Methods("DELETE")
 
 	return router
 }
@@ -72,6 +150,69 @@ func WriteError(w http.ResponseWriter, statusCode int, payload map[string]any) {
 	w.Write(result)
 }
 
+// errorStatusCode maps the domain errors returned by the use cases to an HTTP
+// status code, falling back to the given status for anything unknown.
+func errorStatusCode(err error, fallback int) int {
+	switch {
+	case errors.Is(err, errx.ErrInsufficientPermissions):
+		return http.StatusForbidden
+	case errors.Is(err, errx.ErrInvalidInput),
+		errors.Is(err, errx.ErrInvalidWalletRole),
+		errors.Is(err, errx.ErrInvalidCurrency),
+		errors.Is(err, errx.ErrCurrencyMismatch),
+		errors.Is(err, errx.ErrInvalidRecurrence),
+		errors.Is(err, errx.ErrInvalidBudgetThreshold),
+		errors.Is(err, errx.ErrInvalidTransfer),
+		errors.Is(err, errx.ErrInvalidTransactionType),
+		errors.Is(err, errx.ErrInvalidCursor),
+		errors.Is(err, errx.ErrInvalidReportGranularity),
+		errors.Is(err, errx.ErrInvalidReportRange),
+		errors.Is(err, errx.ErrInvalidTimezone),
+		errors.Is(err, errx.ErrInvalidExportFormat),
+		errors.Is(err, errx.ErrInvalidIdempotencyKey),
+		errors.Is(err, errx.ErrExchangeRateNotFound),
+		errors.Is(err, errx.ErrInvalidStatement),
+		errors.Is(err, errx.ErrInvalidStatementMapping),
+		errors.Is(err, errx.ErrInvalidStatementFormat),
+		errors.Is(err, errx.ErrInvalidStatementLine),
+		errors.Is(err, errx.ErrInvalidSplit),
+		errors.Is(err, errx.ErrInvalidSettlement):
+		return http.StatusBadRequest
+	case errors.Is(err, errx.ErrNotFound),
+		errors.Is(err, errx.ErrCategoryNotFound),
+		errors.Is(err, errx.ErrTransactionNotFound),
+		errors.Is(err, errx.ErrMemberNotInWallet),
+		errors.Is(err, errx.ErrRecurringTransactionNotFound),
+		errors.Is(err, errx.ErrBudgetNotFound),
+		errors.Is(err, errx.ErrStatementImportNotFound),
+		errors.Is(err, errx.ErrInvitationNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, errx.ErrCategoryAlreadyExists),
+		errors.Is(err, errx.ErrTransactionNotActive),
+		errors.Is(err, errx.ErrMemberAlreadyInWallet),
+		errors.Is(err, errx.ErrCannotRemoveCreator),
+		errors.Is(err, errx.ErrCannotChangeCreatorRole),
+		errors.Is(err, errx.ErrOccurrenceNotDue),
+		errors.Is(err, errx.ErrBudgetAlreadyExists),
+		errors.Is(err, errx.ErrTransactionIsTransfer),
+		errors.Is(err, errx.ErrStatementImportCommitted),
+		errors.Is(err, errx.ErrInvitationNotPending),
+		errors.Is(err, errx.ErrInvitationAlreadyPending),
+		errors.Is(err, errx.ErrNothingToSettle),
+		errors.Is(err, errx.ErrTransactionIsSettlement),
+		errors.Is(err, errx.ErrMemberHasOpenBalance),
+		errors.Is(err, errx.ErrWalletConflict),
+		errors.Is(err, errx.ErrIdempotencyKeyInProgress):
+		return http.StatusConflict
+	case errors.Is(err, errx.ErrIdempotencyKeyReused):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, errx.ErrInvitationExpired):
+		return http.StatusGone
+	default:
+		return fallback
+	}
+}
+
 func (handler *APIHandler) requestInterceptorMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Add("X-Server-Version", handler.version)
@@ -0,0 +1,82 @@
+package controllers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/infra/controllers/presenter"
+	usecases "github.com/lopesgabriel/tellawl/services/wallet/internal/use-cases"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type createBudgetRequest struct {
+	Amount     int     `json:"amount"`
+	Offset     int     `json:"offset"`
+	Currency   string  `json:"currency"`
+	CategoryId *string `json:"category_id"`
+	Thresholds []int   `json:"thresholds"`
+}
+
+func (h *APIHandler) HandleCreateBudget(w http.ResponseWriter, r *http.Request) {
+	ctx, span := h.tracer.Start(r.Context(), "HandleCreateBudget")
+	defer span.End()
+
+	member := r.Context().Value(memberContextKey).(*models.Member)
+
+	var data createBudgetRequest
+	// Read the requst body
+	err := json.NewDecoder(r.Body).Decode(&data)
+	defer r.Body.Close()
+	if err != nil {
+		h.logger.Error(ctx, "Could not decode the request body", slog.String("error", err.Error()))
+		WriteError(w, http.StatusBadRequest, map[string]any{
+			"message": "Could not parse the request body, are you sending a JSON?",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	vars := mux.Vars(r)
+	walletId := vars["wallet_id"]
+	if walletId == "" {
+		h.logger.Error(ctx, "Could not get wallet id from path")
+		WriteError(w, http.StatusInternalServerError, map[string]any{
+			"message": "Could not get wallet id from path",
+		})
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("wallet_id", walletId),
+		attribute.String("user_id", member.Id),
+	)
+	budget, err := h.usecases.CreateBudget(ctx, usecases.CreateBudgetUseCaseInput{
+		MemberId:   member.Id,
+		Member:     member,
+		WalletId:   walletId,
+		Amount:     data.Amount,
+		Offset:     data.Offset,
+		Currency:   data.Currency,
+		CategoryId: data.CategoryId,
+		Thresholds: data.Thresholds,
+	})
+	if err != nil {
+		h.logger.Error(ctx, "Could not create the budget", slog.String("error", err.Error()))
+		WriteError(w, errorStatusCode(err, http.StatusBadRequest), map[string]any{
+			"message": "Could not create the budget",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	httpBudget := presenter.NewHTTPBudget(*budget, time.Now())
+
+	w.Header().Add("Content-Type", "application/json")
+	w.Header().Add("Location", "/wallets/"+walletId+"/budgets/"+budget.Id)
+	w.WriteHeader(http.StatusCreated)
+	w.Write(httpBudget.ToJSON())
+}
@@ -0,0 +1,118 @@
+package controllers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/infra/controllers/presenter"
+	usecases "github.com/lopesgabriel/tellawl/services/wallet/internal/use-cases"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type createRecurringTransactionRequest struct {
+	Amount          int     `json:"amount"`
+	Offset          int     `json:"offset"`
+	Currency        string  `json:"currency"`
+	TransactionType string  `json:"transaction_type"`
+	Description     string  `json:"description"`
+	CategoryId      *string `json:"category_id"`
+	Frequency       string  `json:"frequency"`
+	DayOfMonth      int     `json:"day_of_month"`
+	StartDate       string  `json:"start_date"`
+	EndDate         *string `json:"end_date"`
+}
+
+func (h *APIHandler) HandleCreateRecurringTransaction(w http.ResponseWriter, r *http.Request) {
+	ctx, span := h.tracer.Start(r.Context(), "HandleCreateRecurringTransaction")
+	defer span.End()
+
+	member := r.Context().Value(memberContextKey).(*models.Member)
+
+	var data createRecurringTransactionRequest
+	// Read the requst body
+	err := json.NewDecoder(r.Body).Decode(&data)
+	defer r.Body.Close()
+	if err != nil {
+		h.logger.Error(ctx, "Could not decode the request body", slog.String("error", err.Error()))
+		WriteError(w, http.StatusBadRequest, map[string]any{
+			"message": "Could not parse the request body, are you sending a JSON?",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	var startDate time.Time
+	if data.StartDate != "" {
+		startDate, err = time.Parse(time.DateOnly, data.StartDate)
+		if err != nil {
+			WriteError(w, http.StatusBadRequest, map[string]any{
+				"message": "start_date must be formatted as YYYY-MM-DD",
+				"error":   err.Error(),
+			})
+			return
+		}
+	}
+
+	var endDate *time.Time
+	if data.EndDate != nil {
+		parsed, err := time.Parse(time.DateOnly, *data.EndDate)
+		if err != nil {
+			WriteError(w, http.StatusBadRequest, map[string]any{
+				"message": "end_date must be formatted as YYYY-MM-DD",
+				"error":   err.Error(),
+			})
+			return
+		}
+		endDate = &parsed
+	}
+
+	vars := mux.Vars(r)
+	walletId := vars["wallet_id"]
+	if walletId == "" {
+		h.logger.Error(ctx, "Could not get wallet id from path")
+		WriteError(w, http.StatusInternalServerError, map[string]any{
+			"message": "Could not get wallet id from path",
+		})
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("wallet_id", walletId),
+		attribute.String("user_id", member.Id),
+		attribute.String("frequency", data.Frequency),
+	)
+	recurring, err := h.usecases.CreateRecurringTransaction(ctx, usecases.CreateRecurringTransactionUseCaseInput{
+		MemberId:        member.Id,
+		Member:          member,
+		WalletId:        walletId,
+		Amount:          data.Amount,
+		Offset:          data.Offset,
+		Currency:        data.Currency,
+		TransactionType: data.TransactionType,
+		Description:     data.Description,
+		CategoryId:      data.CategoryId,
+		Frequency:       data.Frequency,
+		DayOfMonth:      data.DayOfMonth,
+		StartDate:       startDate,
+		EndDate:         endDate,
+	})
+	if err != nil {
+		h.logger.Error(ctx, "Could not create the recurring transaction", slog.String("error", err.Error()))
+		WriteError(w, errorStatusCode(err, http.StatusBadRequest), map[string]any{
+			"message": "Could not create the recurring transaction",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	httpRecurring := presenter.NewHTTPRecurringTransaction(*recurring)
+
+	w.Header().Add("Content-Type", "application/json")
+	w.Header().Add("Location", "/wallets/"+walletId+"/recurring-transactions/"+recurring.Id)
+	w.WriteHeader(http.StatusCreated)
+	w.Write(httpRecurring.ToJSON())
+}
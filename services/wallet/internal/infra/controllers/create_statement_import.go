@@ -0,0 +1,138 @@
+package controllers
+
+import (
+	"log/slog"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gorilla/mux"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/infra/controllers/presenter"
+	usecases "github.com/lopesgabriel/tellawl/services/wallet/internal/use-cases"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// maxStatementSize bounds the uploaded statements, about 5 MB.
+const maxStatementSize = 5 << 20
+
+// HandleCreateStatementImport receives a statement as multipart/form-data,
+// the file in the "file" field and the format and CSV column mapping in the
+// other fields. Without a format it is guessed from the file extension.
+func (h *APIHandler) HandleCreateStatementImport(w http.ResponseWriter, r *http.Request) {
+	ctx, span := h.tracer.Start(r.Context(), "HandleCreateStatementImport")
+	defer span.End()
+
+	member := r.Context().Value(memberContextKey).(*models.Member)
+
+	vars := mux.Vars(r)
+	walletId := vars["wallet_id"]
+	if walletId == "" {
+		h.logger.Error(ctx, "Could not get wallet id from path")
+		WriteError(w, http.StatusInternalServerError, map[string]any{
+			"message": "Could not get wallet id from path",
+		})
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxStatementSize)
+	if err := r.ParseMultipartForm(maxStatementSize); err != nil {
+		WriteError(w, http.StatusBadRequest, map[string]any{
+			"message": "The statement must be sent as multipart/form-data with up to 5 MB",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, map[string]any{
+			"message": "file is required",
+			"error":   err.Error(),
+		})
+		return
+	}
+	defer file.Close()
+
+	mapping, err := parseCSVMapping(r)
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, map[string]any{
+			"message": "Invalid column mapping",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	format := r.FormValue("format")
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(header.Filename)), ".")
+		if format != "ofx" && format != "qfx" {
+			format = "csv"
+		}
+	}
+
+	span.SetAttributes(
+		attribute.String("wallet_id", walletId),
+		attribute.String("user_id", member.Id),
+		attribute.String("format", format),
+		attribute.String("file_name", header.Filename),
+		attribute.Int64("file_size", header.Size),
+	)
+	statementImport, err := h.usecases.PreviewStatementImport(ctx, usecases.PreviewStatementImportUseCaseInput{
+		MemberId:   member.Id,
+		Member:     member,
+		WalletId:   walletId,
+		Format:     format,
+		Content:    file,
+		CSVMapping: mapping,
+	})
+	if err != nil {
+		h.logger.Error(ctx, "Could not import the statement", slog.String("error", err.Error()))
+		WriteError(w, errorStatusCode(err, http.StatusInternalServerError), map[string]any{
+			"message": "Could not import the statement",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	httpStatementImport := presenter.NewHTTPStatementImport(*statementImport)
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	w.Write(httpStatementImport.ToJSON())
+}
+
+// parseCSVMapping reads the column mapping from the form. The file is
+// expected to have a header unless has_header is false.
+func parseCSVMapping(r *http.Request) (models.CSVMapping, error) {
+	mapping := models.CSVMapping{
+		DateColumn:         r.FormValue("date_column"),
+		DescriptionColumn:  r.FormValue("description_column"),
+		AmountColumn:       r.FormValue("amount_column"),
+		DateFormat:         r.FormValue("date_format"),
+		DecimalSeparator:   r.FormValue("decimal_separator"),
+		ThousandsSeparator: r.FormValue("thousands_separator"),
+		SignConvention:     models.SignConvention(r.FormValue("sign_convention")),
+		HasHeader:          true,
+	}
+
+	if value := r.FormValue("has_header"); value != "" {
+		hasHeader, err := strconv.ParseBool(value)
+		if err != nil {
+			return mapping, err
+		}
+		mapping.HasHeader = hasHeader
+	}
+
+	if value := r.FormValue("delimiter"); value != "" {
+		delimiter, size := utf8.DecodeRuneInString(value)
+		if size != len(value) {
+			return mapping, strconv.ErrSyntax
+		}
+		mapping.Delimiter = delimiter
+	}
+
+	return mapping, nil
+}
@@ -12,6 +12,7 @@ import (
 
 type createWalletRequest struct {
 	WalletName string `json:"name"`
+	Currency   string `json:"currency"`
 }
 
 func (h *APIHandler) HandleCreateWallet(w http.ResponseWriter, r *http.Request) {
@@ -38,6 +39,7 @@ func (h *APIHandler) HandleCreateWallet(w http.ResponseWriter, r *http.Request)
 		CreatorID: creatorId,
 		Creator:   member,
 		Name:      data.WalletName,
+		Currency:  data.Currency,
 	})
 	if err != nil {
 		h.logger.Error(ctx, "Could not create the wallet", slog.String("error", err.Error()))
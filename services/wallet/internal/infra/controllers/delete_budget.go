@@ -0,0 +1,51 @@
+package controllers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+	usecases "github.com/lopesgabriel/tellawl/services/wallet/internal/use-cases"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func (h *APIHandler) HandleDeleteBudget(w http.ResponseWriter, r *http.Request) {
+	ctx, span := h.tracer.Start(r.Context(), "HandleDeleteBudget")
+	defer span.End()
+
+	member := r.Context().Value(memberContextKey).(*models.Member)
+
+	vars := mux.Vars(r)
+	walletId := vars["wallet_id"]
+	budgetId := vars["budget_id"]
+	if walletId == "" || budgetId == "" {
+		h.logger.Error(ctx, "Could not get wallet id or budget id from path")
+		WriteError(w, http.StatusInternalServerError, map[string]any{
+			"message": "Could not get wallet id or budget id from path",
+		})
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("wallet_id", walletId),
+		attribute.String("budget_id", budgetId),
+		attribute.String("user_id", member.Id),
+	)
+	err := h.usecases.DeleteBudget(ctx, usecases.DeleteBudgetUseCaseInput{
+		MemberId: member.Id,
+		Member:   member,
+		WalletId: walletId,
+		BudgetId: budgetId,
+	})
+	if err != nil {
+		h.logger.Error(ctx, "Could not delete the budget", slog.String("error", err.Error()))
+		WriteError(w, errorStatusCode(err, http.StatusInternalServerError), map[string]any{
+			"message": "Could not delete the budget",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
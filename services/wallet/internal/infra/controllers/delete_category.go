@@ -0,0 +1,51 @@
+package controllers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+	usecases "github.com/lopesgabriel/tellawl/services/wallet/internal/use-cases"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func (h *APIHandler) HandleDeleteCategory(w http.ResponseWriter, r *http.Request) {
+	ctx, span := h.tracer.Start(r.Context(), "HandleDeleteCategory")
+	defer span.End()
+
+	member := r.Context().Value(memberContextKey).(*models.Member)
+
+	vars := mux.Vars(r)
+	walletId := vars["wallet_id"]
+	categoryId := vars["category_id"]
+	if walletId == "" || categoryId == "" {
+		h.logger.Error(ctx, "Could not get wallet id or category id from path")
+		WriteError(w, http.StatusInternalServerError, map[string]any{
+			"message": "Could not get wallet id or category id from path",
+		})
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("wallet_id", walletId),
+		attribute.String("category_id", categoryId),
+		attribute.String("user_id", member.Id),
+	)
+	err := h.usecases.DeleteCategory(ctx, usecases.DeleteCategoryUseCaseInput{
+		MemberId:   member.Id,
+		Member:     member,
+		WalletId:   walletId,
+		CategoryId: categoryId,
+	})
+	if err != nil {
+		h.logger.Error(ctx, "Could not delete the category", slog.String("error", err.Error()))
+		WriteError(w, errorStatusCode(err, http.StatusInternalServerError), map[string]any{
+			"message": "Could not delete the category",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,120 @@
+package controllers
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/infra/controllers/presenter"
+	usecases "github.com/lopesgabriel/tellawl/services/wallet/internal/use-cases"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// HandleExportTransactions streams the wallet history as a file download.
+// Once the first bytes are sent the status can no longer change, errors
+// after that point only cut the download short.
+func (h *APIHandler) HandleExportTransactions(w http.ResponseWriter, r *http.Request) {
+	ctx, span := h.tracer.Start(r.Context(), "HandleExportTransactions")
+	defer span.End()
+
+	member := r.Context().Value(memberContextKey).(*models.Member)
+
+	vars := mux.Vars(r)
+	walletId := vars["wallet_id"]
+	if walletId == "" {
+		h.logger.Error(ctx, "Could not get wallet id from path")
+		WriteError(w, http.StatusInternalServerError, map[string]any{
+			"message": "Could not get wallet id from path",
+		})
+		return
+	}
+
+	query := r.URL.Query()
+	format := query.Get("format")
+
+	var from, to *time.Time
+	if value := query.Get("from"); value != "" {
+		parsed, _, err := parseTimeParam(value, time.UTC)
+		if err != nil {
+			WriteError(w, http.StatusBadRequest, map[string]any{
+				"message": "Invalid query parameters",
+				"error":   err.Error(),
+			})
+			return
+		}
+		from = &parsed
+	}
+	if value := query.Get("to"); value != "" {
+		parsed, dateOnly, err := parseTimeParam(value, time.UTC)
+		if err != nil {
+			WriteError(w, http.StatusBadRequest, map[string]any{
+				"message": "Invalid query parameters",
+				"error":   err.Error(),
+			})
+			return
+		}
+		if dateOnly {
+			parsed = parsed.AddDate(0, 0, 1)
+		}
+		to = &parsed
+	}
+
+	download := &exportResponseWriter{ResponseWriter: w}
+	exporter, err := presenter.NewTransactionExporter(format, download)
+	if err != nil {
+		WriteError(w, errorStatusCode(err, http.StatusBadRequest), map[string]any{
+			"message": "Invalid query parameters",
+			"error":   err.Error(),
+		})
+		return
+	}
+	download.contentType = exporter.ContentType()
+	download.filename = fmt.Sprintf("wallet-%s.%s", walletId, exporter.FileExtension())
+
+	span.SetAttributes(
+		attribute.String("wallet_id", walletId),
+		attribute.String("user_id", member.Id),
+		attribute.String("export.format", exporter.FileExtension()),
+	)
+	err = h.usecases.ExportTransactions(ctx, usecases.ExportTransactionsUseCaseInput{
+		MemberId: member.Id,
+		Member:   member,
+		WalletId: walletId,
+		From:     from,
+		To:       to,
+	}, exporter)
+	if err != nil && download.started {
+		h.logger.Error(ctx, "The transactions export was interrupted", slog.String("error", err.Error()))
+		return
+	}
+	if err != nil {
+		h.logger.Error(ctx, "Could not export the transactions", slog.String("error", err.Error()))
+		WriteError(w, errorStatusCode(err, http.StatusInternalServerError), map[string]any{
+			"message": "Could not export the transactions",
+			"error":   err.Error(),
+		})
+	}
+}
+
+// exportResponseWriter only sends the download headers with the first bytes
+// of the export, so errors found before that can still be answered as JSON.
+type exportResponseWriter struct {
+	http.ResponseWriter
+	contentType string
+	filename    string
+	started     bool
+}
+
+func (w *exportResponseWriter) Write(data []byte) (int, error) {
+	if !w.started {
+		w.started = true
+		w.Header().Set("Content-Type", w.contentType)
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", w.filename))
+		w.WriteHeader(http.StatusOK)
+	}
+
+	return w.ResponseWriter.Write(data)
+}
@@ -0,0 +1,115 @@
+package controllers
+
+import (
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/infra/controllers/presenter"
+	usecases "github.com/lopesgabriel/tellawl/services/wallet/internal/use-cases"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func (h *APIHandler) HandleGetEvolutionReport(w http.ResponseWriter, r *http.Request) {
+	ctx, span := h.tracer.Start(r.Context(), "HandleGetEvolutionReport")
+	defer span.End()
+
+	member := r.Context().Value(memberContextKey).(*models.Member)
+
+	vars := mux.Vars(r)
+	walletId := vars["wallet_id"]
+	if walletId == "" {
+		h.logger.Error(ctx, "Could not get wallet id from path")
+		WriteError(w, http.StatusInternalServerError, map[string]any{
+			"message": "Could not get wallet id from path",
+		})
+		return
+	}
+
+	query := r.URL.Query()
+	params, message, err := parseReportParams(query)
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, map[string]any{
+			"message": message,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("wallet_id", walletId),
+		attribute.String("user_id", member.Id),
+		attribute.String("granularity", params.Granularity),
+		attribute.String("timezone", params.Timezone),
+	)
+	report, err := h.usecases.GetEvolutionReport(ctx, usecases.GetEvolutionReportUseCaseInput{
+		MemberId:    member.Id,
+		Member:      member,
+		WalletId:    walletId,
+		Granularity: params.Granularity,
+		Timezone:    params.Timezone,
+		From:        params.From,
+		To:          params.To,
+	})
+	if err != nil {
+		h.logger.Error(ctx, "Could not build the evolution report", slog.String("error", err.Error()))
+		WriteError(w, errorStatusCode(err, http.StatusInternalServerError), map[string]any{
+			"message": "Could not build the evolution report",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	httpReport := presenter.NewHTTPEvolutionReport(*report)
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(httpReport.ToJSON())
+}
+
+type reportParams struct {
+	Granularity string
+	Timezone    string
+	From        *time.Time
+	To          *time.Time
+}
+
+// parseReportParams reads the granularity, tz, from and to parameters of the
+// reports. Dates are midnight in the member timezone and a date in "to"
+// includes the whole day. On failure it also returns the message to answer.
+func parseReportParams(query url.Values) (reportParams, string, error) {
+	parsed := reportParams{
+		Granularity: query.Get("granularity"),
+		Timezone:    query.Get("tz"),
+	}
+
+	location, err := time.LoadLocation(parsed.Timezone)
+	if err != nil {
+		return reportParams{}, "tz must be an IANA timezone, e.g. America/Sao_Paulo", errx.ErrInvalidTimezone
+	}
+
+	if value := query.Get("from"); value != "" {
+		from, _, err := parseTimeParam(value, location)
+		if err != nil {
+			return reportParams{}, "from must be formatted as YYYY-MM-DD or RFC 3339", err
+		}
+		parsed.From = &from
+	}
+
+	if value := query.Get("to"); value != "" {
+		to, dateOnly, err := parseTimeParam(value, location)
+		if err != nil {
+			return reportParams{}, "to must be formatted as YYYY-MM-DD or RFC 3339", err
+		}
+		if dateOnly {
+			to = to.AddDate(0, 0, 1)
+		}
+		parsed.To = &to
+	}
+
+	return parsed, "", nil
+}
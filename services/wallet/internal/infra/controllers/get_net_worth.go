@@ -0,0 +1,78 @@
+package controllers
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/infra/controllers/presenter"
+	usecases "github.com/lopesgabriel/tellawl/services/wallet/internal/use-cases"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func (h *APIHandler) HandleGetNetWorth(w http.ResponseWriter, r *http.Request) {
+	ctx, span := h.tracer.Start(r.Context(), "HandleGetNetWorth")
+	defer span.End()
+
+	member := r.Context().Value(memberContextKey).(*models.Member)
+
+	query := r.URL.Query()
+	params, message, err := parseReportParams(query)
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, map[string]any{
+			"message": message,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	includeWalletIds := parseListParam(query["wallet_id"])
+	excludeWalletIds := parseListParam(query["exclude_wallet_id"])
+
+	span.SetAttributes(
+		attribute.String("user_id", member.Id),
+		attribute.String("base_currency", query.Get("base_currency")),
+		attribute.StringSlice("included_wallets", includeWalletIds),
+		attribute.StringSlice("excluded_wallets", excludeWalletIds),
+	)
+	netWorth, err := h.usecases.GetNetWorth(ctx, usecases.GetNetWorthUseCaseInput{
+		MemberId:         member.Id,
+		Member:           member,
+		BaseCurrency:     query.Get("base_currency"),
+		IncludeWalletIds: includeWalletIds,
+		ExcludeWalletIds: excludeWalletIds,
+		Granularity:      params.Granularity,
+		Timezone:         params.Timezone,
+		From:             params.From,
+		To:               params.To,
+	})
+	if err != nil {
+		h.logger.Error(ctx, "Could not compute the net worth", slog.String("error", err.Error()))
+		WriteError(w, errorStatusCode(err, http.StatusInternalServerError), map[string]any{
+			"message": "Could not compute the net worth",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	httpNetWorth := presenter.NewHTTPNetWorth(*netWorth)
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(httpNetWorth.ToJSON())
+}
+
+// parseListParam accepts both repeated parameters and comma separated values.
+func parseListParam(values []string) []string {
+	items := []string{}
+	for _, value := range values {
+		for item := range strings.SplitSeq(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+	}
+
+	return items
+}
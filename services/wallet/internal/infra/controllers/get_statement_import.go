@@ -0,0 +1,56 @@
+package controllers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/infra/controllers/presenter"
+	usecases "github.com/lopesgabriel/tellawl/services/wallet/internal/use-cases"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func (h *APIHandler) HandleGetStatementImport(w http.ResponseWriter, r *http.Request) {
+	ctx, span := h.tracer.Start(r.Context(), "HandleGetStatementImport")
+	defer span.End()
+
+	member := r.Context().Value(memberContextKey).(*models.Member)
+
+	vars := mux.Vars(r)
+	walletId := vars["wallet_id"]
+	importId := vars["import_id"]
+	if walletId == "" || importId == "" {
+		h.logger.Error(ctx, "Could not get wallet or import id from path")
+		WriteError(w, http.StatusInternalServerError, map[string]any{
+			"message": "Could not get wallet or import id from path",
+		})
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("wallet_id", walletId),
+		attribute.String("import_id", importId),
+		attribute.String("user_id", member.Id),
+	)
+	statementImport, err := h.usecases.GetStatementImport(ctx, usecases.GetStatementImportUseCaseInput{
+		MemberId: member.Id,
+		Member:   member,
+		WalletId: walletId,
+		ImportId: importId,
+	})
+	if err != nil {
+		h.logger.Error(ctx, "Could not retrieve the statement import", slog.String("error", err.Error()))
+		WriteError(w, errorStatusCode(err, http.StatusInternalServerError), map[string]any{
+			"message": "Could not retrieve the statement import",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	httpStatementImport := presenter.NewHTTPStatementImport(*statementImport)
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(httpStatementImport.ToJSON())
+}
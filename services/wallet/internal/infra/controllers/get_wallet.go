@@ -0,0 +1,68 @@
+package controllers
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/infra/controllers/presenter"
+	usecases "github.com/lopesgabriel/tellawl/services/wallet/internal/use-cases"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func (h *APIHandler) HandleGetWallet(w http.ResponseWriter, r *http.Request) {
+	ctx, span := h.tracer.Start(r.Context(), "HandleGetWallet")
+	defer span.End()
+
+	member := r.Context().Value(memberContextKey).(*models.Member)
+
+	vars := mux.Vars(r)
+	walletId := vars["wallet_id"]
+	if walletId == "" {
+		h.logger.Error(ctx, "Could not get wallet id from path")
+		WriteError(w, http.StatusInternalServerError, map[string]any{
+			"message": "Could not get wallet id from path",
+		})
+		return
+	}
+
+	var recentTransactions int
+	if value := r.URL.Query().Get("transactions_limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil {
+			WriteError(w, http.StatusBadRequest, map[string]any{
+				"message": "transactions_limit must be a number",
+				"error":   err.Error(),
+			})
+			return
+		}
+		recentTransactions = limit
+	}
+
+	span.SetAttributes(
+		attribute.String("wallet_id", walletId),
+		attribute.String("user_id", member.Id),
+	)
+	wallet, err := h.usecases.GetWallet(ctx, usecases.GetWalletUseCaseInput{
+		MemberId:           member.Id,
+		Member:             member,
+		WalletId:           walletId,
+		RecentTransactions: recentTransactions,
+	})
+	if err != nil {
+		h.logger.Error(ctx, "Could not get the wallet", slog.String("error", err.Error()))
+		WriteError(w, errorStatusCode(err, http.StatusInternalServerError), map[string]any{
+			"message": "Could not get the wallet",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	httpWallet := presenter.NewHTTPWalletDetail(*wallet)
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(httpWallet.ToJSON())
+}
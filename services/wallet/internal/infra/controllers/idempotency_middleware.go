@@ -0,0 +1,123 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+	usecases "github.com/lopesgabriel/tellawl/services/wallet/internal/use-cases"
+)
+
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// maxIdempotentBodySize bounds the bodies read to fingerprint the request,
+// above the limit of every endpoint.
+const maxIdempotentBodySize = 10 << 20
+
+// idempotencyMiddleware makes retries of a request sent with an
+// Idempotency-Key header return the first response instead of running again.
+// It must run after jwtAuthMiddleware, keys belong to the member.
+func (h *APIHandler) idempotencyMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(idempotencyKeyHeader)
+		if key == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ctx := r.Context()
+		member := ctx.Value(memberContextKey).(*models.Member)
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxIdempotentBodySize))
+		if err != nil {
+			statusCode := http.StatusBadRequest
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				statusCode = http.StatusRequestEntityTooLarge
+			}
+			WriteError(w, statusCode, map[string]any{
+				"message": "Could not read the request body",
+				"error":   err.Error(),
+			})
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		request, replay, err := h.usecases.BeginIdempotentRequest(ctx, usecases.BeginIdempotentRequestUseCaseInput{
+			MemberId: member.Id,
+			Key:      key,
+			Method:   r.Method,
+			Path:     r.URL.Path,
+			Body:     body,
+		})
+		if err != nil {
+			h.logger.Error(ctx, "Could not use the idempotency key", slog.String("error", err.Error()))
+			WriteError(w, errorStatusCode(err, http.StatusInternalServerError), map[string]any{
+				"message": "Could not use the idempotency key",
+				"error":   err.Error(),
+			})
+			return
+		}
+
+		if replay {
+			w.Header().Set("Idempotent-Replayed", "true")
+			if request.ContentType != "" {
+				w.Header().Set("Content-Type", request.ContentType)
+			}
+			w.WriteHeader(request.StatusCode)
+			w.Write(request.Body)
+			return
+		}
+
+		// The response is stored even if the client is gone, its retry will
+		// ask for it. Panics release the key
+		storeCtx := context.WithoutCancel(ctx)
+		completed := false
+		defer func() {
+			if !completed {
+				h.usecases.ReleaseIdempotentRequest(storeCtx, request)
+			}
+		}()
+
+		recorder := &responseRecorder{ResponseWriter: w, statusCode: http.StatusOK}
+		next.ServeHTTP(recorder, r)
+
+		err = h.usecases.CompleteIdempotentRequest(storeCtx, usecases.CompleteIdempotentRequestUseCaseInput{
+			Request:     request,
+			StatusCode:  recorder.statusCode,
+			ContentType: recorder.Header().Get("Content-Type"),
+			Body:        recorder.body.Bytes(),
+		})
+		if err != nil {
+			h.logger.Error(ctx, "Could not store the idempotent response", slog.String("error", err.Error()))
+			return
+		}
+		completed = true
+	})
+}
+
+// responseRecorder keeps a copy of the response written to the client.
+type responseRecorder struct {
+	http.ResponseWriter
+	statusCode  int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (r *responseRecorder) WriteHeader(statusCode int) {
+	if !r.wroteHeader {
+		r.statusCode = statusCode
+		r.wroteHeader = true
+	}
+	r.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (r *responseRecorder) Write(data []byte) (int, error) {
+	r.wroteHeader = true
+	r.body.Write(data)
+	return r.ResponseWriter.Write(data)
+}
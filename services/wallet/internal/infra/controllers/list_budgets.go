@@ -0,0 +1,60 @@
+package controllers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/infra/controllers/presenter"
+	usecases "github.com/lopesgabriel/tellawl/services/wallet/internal/use-cases"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func (h *APIHandler) HandleListBudgets(w http.ResponseWriter, r *http.Request) {
+	ctx, span := h.tracer.Start(r.Context(), "HandleListBudgets")
+	defer span.End()
+
+	member := r.Context().Value(memberContextKey).(*models.Member)
+
+	vars := mux.Vars(r)
+	walletId := vars["wallet_id"]
+	if walletId == "" {
+		h.logger.Error(ctx, "Could not get wallet id from path")
+		WriteError(w, http.StatusInternalServerError, map[string]any{
+			"message": "Could not get wallet id from path",
+		})
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("wallet_id", walletId),
+		attribute.String("user_id", member.Id),
+	)
+	budgets, err := h.usecases.ListBudgets(ctx, usecases.ListBudgetsUseCaseInput{
+		MemberId: member.Id,
+		Member:   member,
+		WalletId: walletId,
+	})
+	if err != nil {
+		h.logger.Error(ctx, "Could not list the budgets", slog.String("error", err.Error()))
+		WriteError(w, errorStatusCode(err, http.StatusInternalServerError), map[string]any{
+			"message": "Could not list the budgets",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	now := time.Now()
+	httpBudgets := make([]presenter.HTTPBudget, len(budgets))
+	for i, budget := range budgets {
+		httpBudgets[i] = presenter.NewHTTPBudget(budget, now)
+	}
+
+	jsonData, _ := json.Marshal(httpBudgets)
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(jsonData)
+}
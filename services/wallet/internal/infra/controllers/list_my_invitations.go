@@ -0,0 +1,45 @@
+package controllers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/infra/controllers/presenter"
+	usecases "github.com/lopesgabriel/tellawl/services/wallet/internal/use-cases"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func (h *APIHandler) HandleListMyInvitations(w http.ResponseWriter, r *http.Request) {
+	ctx, span := h.tracer.Start(r.Context(), "HandleListMyInvitations")
+	defer span.End()
+
+	member := r.Context().Value(memberContextKey).(*models.Member)
+
+	span.SetAttributes(attribute.String("user_id", member.Id))
+	invitations, err := h.usecases.ListMyInvitations(ctx, usecases.ListMyInvitationsUseCaseInput{
+		MemberId: member.Id,
+		Member:   member,
+	})
+	if err != nil {
+		h.logger.Error(ctx, "Could not list the invitations", slog.String("error", err.Error()))
+		WriteError(w, errorStatusCode(err, http.StatusInternalServerError), map[string]any{
+			"message": "Could not list the invitations",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	now := time.Now()
+	httpInvitations := make([]presenter.HTTPInvitation, len(invitations))
+	for i, invitation := range invitations {
+		httpInvitations[i] = presenter.NewHTTPReceivedInvitation(invitation, now)
+	}
+
+	jsonData, _ := json.Marshal(httpInvitations)
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(jsonData)
+}
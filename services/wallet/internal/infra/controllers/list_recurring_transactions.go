@@ -0,0 +1,58 @@
+package controllers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/infra/controllers/presenter"
+	usecases "github.com/lopesgabriel/tellawl/services/wallet/internal/use-cases"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func (h *APIHandler) HandleListRecurringTransactions(w http.ResponseWriter, r *http.Request) {
+	ctx, span := h.tracer.Start(r.Context(), "HandleListRecurringTransactions")
+	defer span.End()
+
+	member := r.Context().Value(memberContextKey).(*models.Member)
+
+	vars := mux.Vars(r)
+	walletId := vars["wallet_id"]
+	if walletId == "" {
+		h.logger.Error(ctx, "Could not get wallet id from path")
+		WriteError(w, http.StatusInternalServerError, map[string]any{
+			"message": "Could not get wallet id from path",
+		})
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("wallet_id", walletId),
+		attribute.String("user_id", member.Id),
+	)
+	recurringTransactions, err := h.usecases.ListRecurringTransactions(ctx, usecases.ListRecurringTransactionsUseCaseInput{
+		MemberId: member.Id,
+		Member:   member,
+		WalletId: walletId,
+	})
+	if err != nil {
+		h.logger.Error(ctx, "Could not list the recurring transactions", slog.String("error", err.Error()))
+		WriteError(w, errorStatusCode(err, http.StatusInternalServerError), map[string]any{
+			"message": "Could not list the recurring transactions",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	httpRecurringTransactions := make([]presenter.HTTPRecurringTransaction, len(recurringTransactions))
+	for i, recurring := range recurringTransactions {
+		httpRecurringTransactions[i] = presenter.NewHTTPRecurringTransaction(recurring)
+	}
+
+	jsonData, _ := json.Marshal(httpRecurringTransactions)
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(jsonData)
+}
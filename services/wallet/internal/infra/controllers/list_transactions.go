@@ -0,0 +1,136 @@
+package controllers
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/repository"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/infra/controllers/presenter"
+	usecases "github.com/lopesgabriel/tellawl/services/wallet/internal/use-cases"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func (h *APIHandler) HandleListTransactions(w http.ResponseWriter, r *http.Request) {
+	ctx, span := h.tracer.Start(r.Context(), "HandleListTransactions")
+	defer span.End()
+
+	member := r.Context().Value(memberContextKey).(*models.Member)
+
+	vars := mux.Vars(r)
+	walletId := vars["wallet_id"]
+	if walletId == "" {
+		h.logger.Error(ctx, "Could not get wallet id from path")
+		WriteError(w, http.StatusInternalServerError, map[string]any{
+			"message": "Could not get wallet id from path",
+		})
+		return
+	}
+
+	filter, err := parseTransactionFilter(r)
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, map[string]any{
+			"message": "Invalid query parameters",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("wallet_id", walletId),
+		attribute.String("user_id", member.Id),
+	)
+	page, err := h.usecases.ListTransactions(ctx, usecases.ListTransactionsUseCaseInput{
+		MemberId: member.Id,
+		Member:   member,
+		WalletId: walletId,
+		Filter:   filter,
+	})
+	if err != nil {
+		h.logger.Error(ctx, "Could not list the transactions", slog.String("error", err.Error()))
+		WriteError(w, errorStatusCode(err, http.StatusInternalServerError), map[string]any{
+			"message": "Could not list the transactions",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	httpPage := presenter.NewHTTPTransactionPage(*page)
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(httpPage.ToJSON())
+}
+
+// parseTransactionFilter reads the filters from the query string. Dates
+// accept RFC 3339 timestamps or YYYY-MM-DD, a date in "to" includes the
+// whole day. Amounts are decimals such as 10.50.
+func parseTransactionFilter(r *http.Request) (repository.TransactionFilter, error) {
+	query := r.URL.Query()
+	filter := repository.TransactionFilter{
+		Type:      models.TransactionType(query.Get("type")),
+		Status:    models.TransactionStatus(query.Get("status")),
+		CreatedBy: query.Get("created_by"),
+		Search:    query.Get("search"),
+		Cursor:    query.Get("cursor"),
+	}
+
+	if value := query.Get("from"); value != "" {
+		from, _, err := parseTimeParam(value, time.UTC)
+		if err != nil {
+			return filter, err
+		}
+		filter.From = &from
+	}
+
+	if value := query.Get("to"); value != "" {
+		to, dateOnly, err := parseTimeParam(value, time.UTC)
+		if err != nil {
+			return filter, err
+		}
+		if dateOnly {
+			to = to.AddDate(0, 0, 1)
+		}
+		filter.To = &to
+	}
+
+	if value := query.Get("min_amount"); value != "" {
+		amount, err := models.ParseMonetary(value, "", ".", "")
+		if err != nil {
+			return filter, err
+		}
+		filter.MinAmount = &amount
+	}
+
+	if value := query.Get("max_amount"); value != "" {
+		amount, err := models.ParseMonetary(value, "", ".", "")
+		if err != nil {
+			return filter, err
+		}
+		filter.MaxAmount = &amount
+	}
+
+	if value := query.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil {
+			return filter, err
+		}
+		filter.Limit = limit
+	}
+
+	return filter, nil
+}
+
+// parseTimeParam parses an RFC 3339 timestamp or a YYYY-MM-DD date, taken as
+// midnight in the location. It also reports whether the value was a date.
+func parseTimeParam(value string, location *time.Location) (time.Time, bool, error) {
+	if date, err := time.ParseInLocation(time.DateOnly, value, location); err == nil {
+		return date, true, nil
+	}
+
+	timestamp, err := time.Parse(time.RFC3339, value)
+	return timestamp, false, err
+}
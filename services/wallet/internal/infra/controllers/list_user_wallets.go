@@ -31,9 +31,9 @@ func (h *APIHandler) HandleListUserWallets(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	httpWallets := make([]presenter.HTTPWallet, len(wallets))
+	httpWallets := make([]presenter.HTTPWalletSummary, len(wallets))
 	for i, wallet := range wallets {
-		httpWallets[i] = presenter.NewHTTPWallet(wallet)
+		httpWallets[i] = presenter.NewHTTPWalletSummary(wallet)
 	}
 
 	jsonData, _ := json.Marshal(httpWallets)
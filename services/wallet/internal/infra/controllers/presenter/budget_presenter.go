@@ -0,0 +1,61 @@
+package presenter
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+)
+
+type HTTPBudget struct {
+	Id         string             `json:"id"`
+	CategoryId *string            `json:"category_id"`
+	Limit      HTTPMonetary       `json:"limit"`
+	Thresholds []int              `json:"thresholds"`
+	Periods    []HTTPBudgetPeriod `json:"periods"`
+	CreatedAt  time.Time          `json:"created_at"`
+	UpdatedAt  *time.Time         `json:"updated_at"`
+}
+
+type HTTPBudgetPeriod struct {
+	Period     string       `json:"period"`
+	Limit      HTTPMonetary `json:"limit"`
+	Spent      HTTPMonetary `json:"spent"`
+	Remaining  HTTPMonetary `json:"remaining"`
+	Percentage int          `json:"percentage"`
+}
+
+// NewHTTPBudget presents the budget with the period of now first, followed
+// by the past periods.
+func NewHTTPBudget(budget models.Budget, now time.Time) HTTPBudget {
+	summaries := budget.Summaries(now)
+	periods := make([]HTTPBudgetPeriod, len(summaries))
+	for i, summary := range summaries {
+		periods[i] = HTTPBudgetPeriod{
+			Period:     summary.Start.Format("2006-01"),
+			Limit:      NewHTTPMonetary(summary.Limit),
+			Spent:      NewHTTPMonetary(summary.Spent),
+			Remaining:  NewHTTPMonetary(summary.Remaining),
+			Percentage: summary.Percentage,
+		}
+	}
+
+	return HTTPBudget{
+		Id:         budget.Id,
+		CategoryId: budget.CategoryId,
+		Limit:      NewHTTPMonetary(budget.Limit),
+		Thresholds: budget.Thresholds,
+		Periods:    periods,
+		CreatedAt:  budget.CreatedAt,
+		UpdatedAt:  budget.UpdatedAt,
+	}
+}
+
+func (b HTTPBudget) ToJSON() []byte {
+	data, err := json.Marshal(b)
+	if err != nil {
+		return []byte{}
+	}
+
+	return data
+}
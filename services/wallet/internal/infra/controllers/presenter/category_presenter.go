@@ -0,0 +1,33 @@
+package presenter
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+)
+
+type HTTPCategory struct {
+	Id        string     `json:"id"`
+	Name      string     `json:"name"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at"`
+}
+
+func NewHTTPCategory(category models.Category) HTTPCategory {
+	return HTTPCategory{
+		Id:        category.Id,
+		Name:      category.Name,
+		CreatedAt: category.CreatedAt,
+		UpdatedAt: category.UpdatedAt,
+	}
+}
+
+func (c HTTPCategory) ToJSON() []byte {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return []byte{}
+	}
+
+	return data
+}
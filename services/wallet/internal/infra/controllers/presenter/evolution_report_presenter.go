@@ -0,0 +1,60 @@
+package presenter
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+)
+
+type HTTPEvolutionReport struct {
+	WalletId    string                `json:"wallet_id"`
+	Granularity string                `json:"granularity"`
+	Timezone    string                `json:"timezone"`
+	From        time.Time             `json:"from"`
+	To          time.Time             `json:"to"`
+	Buckets     []HTTPEvolutionBucket `json:"buckets"`
+}
+
+type HTTPEvolutionBucket struct {
+	Start          time.Time    `json:"start"`
+	End            time.Time    `json:"end"`
+	OpeningBalance HTTPMonetary `json:"opening_balance"`
+	Income         HTTPMonetary `json:"income"`
+	Outcome        HTTPMonetary `json:"outcome"`
+	Transfers      HTTPMonetary `json:"transfers"`
+	ClosingBalance HTTPMonetary `json:"closing_balance"`
+}
+
+func NewHTTPEvolutionReport(report models.EvolutionReport) HTTPEvolutionReport {
+	buckets := make([]HTTPEvolutionBucket, len(report.Buckets))
+	for i, bucket := range report.Buckets {
+		buckets[i] = HTTPEvolutionBucket{
+			Start:          bucket.Start,
+			End:            bucket.End,
+			OpeningBalance: NewHTTPMonetary(bucket.OpeningBalance),
+			Income:         NewHTTPMonetary(bucket.Income),
+			Outcome:        NewHTTPMonetary(bucket.Outcome),
+			Transfers:      NewHTTPMonetary(bucket.Transfers),
+			ClosingBalance: NewHTTPMonetary(bucket.ClosingBalance),
+		}
+	}
+
+	return HTTPEvolutionReport{
+		WalletId:    report.WalletId,
+		Granularity: string(report.Granularity),
+		Timezone:    report.Location.String(),
+		From:        report.From,
+		To:          report.To,
+		Buckets:     buckets,
+	}
+}
+
+func (r HTTPEvolutionReport) ToJSON() []byte {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return []byte{}
+	}
+
+	return data
+}
@@ -0,0 +1,58 @@
+package presenter
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+)
+
+type HTTPInvitation struct {
+	Id          string     `json:"id"`
+	WalletId    string     `json:"wallet_id"`
+	WalletName  string     `json:"wallet_name"`
+	Email       string     `json:"email"`
+	MemberId    *string    `json:"member_id"`
+	Role        string     `json:"role"`
+	Status      string     `json:"status"`
+	InvitedBy   string     `json:"invited_by"`
+	Token       string     `json:"token,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	RespondedAt *time.Time `json:"responded_at"`
+}
+
+// NewHTTPInvitation presents the invitation without its token, which only the
+// invitee gets to see.
+func NewHTTPInvitation(invitation models.Invitation, now time.Time) HTTPInvitation {
+	return HTTPInvitation{
+		Id:          invitation.Id,
+		WalletId:    invitation.WalletId,
+		WalletName:  invitation.WalletName,
+		Email:       invitation.Email,
+		MemberId:    invitation.MemberId,
+		Role:        string(invitation.Role),
+		Status:      string(invitation.StatusAt(now)),
+		InvitedBy:   invitation.InvitedBy,
+		CreatedAt:   invitation.CreatedAt,
+		ExpiresAt:   invitation.ExpiresAt,
+		RespondedAt: invitation.RespondedAt,
+	}
+}
+
+// NewHTTPReceivedInvitation presents an invitation to its invitee, with the
+// token used to accept or decline it.
+func NewHTTPReceivedInvitation(invitation models.Invitation, now time.Time) HTTPInvitation {
+	httpInvitation := NewHTTPInvitation(invitation, now)
+	httpInvitation.Token = invitation.Token
+	return httpInvitation
+}
+
+func (i HTTPInvitation) ToJSON() []byte {
+	data, err := json.Marshal(i)
+	if err != nil {
+		return []byte{}
+	}
+
+	return data
+}
@@ -5,13 +5,17 @@ import (
 )
 
 type HTTPMonetary struct {
-	Value  int `json:"value"`
-	Offset int `json:"offset"`
+	Value     int    `json:"value"`
+	Offset    int    `json:"offset"`
+	Currency  string `json:"currency"`
+	Formatted string `json:"formatted"`
 }
 
 func NewHTTPMonetary(monetary models.Monetary) HTTPMonetary {
 	return HTTPMonetary{
-		Value:  monetary.Value,
-		Offset: monetary.Offset,
+		Value:     monetary.Value,
+		Offset:    monetary.Offset,
+		Currency:  monetary.Currency,
+		Formatted: monetary.String(),
 	}
 }
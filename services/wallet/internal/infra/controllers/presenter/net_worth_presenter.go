@@ -0,0 +1,68 @@
+package presenter
+
+import (
+	"encoding/json"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+)
+
+type HTTPNetWorth struct {
+	Currency  string              `json:"currency"`
+	Total     HTTPMonetary        `json:"total"`
+	Wallets   []HTTPNetWorthEntry `json:"wallets"`
+	Evolution HTTPEvolutionReport `json:"evolution"`
+}
+
+type HTTPNetWorthEntry struct {
+	Wallet           HTTPWalletSummary `json:"wallet"`
+	ExchangeRate     HTTPExchangeRate  `json:"exchange_rate"`
+	ConvertedBalance HTTPMonetary      `json:"converted_balance"`
+	SharePercentage  float64           `json:"share_percentage"`
+}
+
+// HTTPExchangeRate is the price of one unit of base in quote, as the exact
+// fraction numerator / denominator.
+type HTTPExchangeRate struct {
+	Base        string `json:"base"`
+	Quote       string `json:"quote"`
+	Numerator   int    `json:"numerator"`
+	Denominator int    `json:"denominator"`
+}
+
+func NewHTTPNetWorth(netWorth models.NetWorth) HTTPNetWorth {
+	wallets := make([]HTTPNetWorthEntry, len(netWorth.Wallets))
+	for i, wallet := range netWorth.Wallets {
+		wallets[i] = HTTPNetWorthEntry{
+			Wallet: NewHTTPWalletSummary(wallet.Wallet),
+			ExchangeRate: HTTPExchangeRate{
+				Base:        wallet.Rate.Base,
+				Quote:       wallet.Rate.Quote,
+				Numerator:   wallet.Rate.Numerator,
+				Denominator: wallet.Rate.Denominator,
+			},
+			ConvertedBalance: NewHTTPMonetary(wallet.Balance),
+			SharePercentage:  wallet.Share,
+		}
+	}
+
+	httpNetWorth := HTTPNetWorth{
+		Currency: netWorth.Currency,
+		Total:    NewHTTPMonetary(netWorth.Total),
+		Wallets:  wallets,
+	}
+
+	if netWorth.Evolution != nil {
+		httpNetWorth.Evolution = NewHTTPEvolutionReport(*netWorth.Evolution)
+	}
+
+	return httpNetWorth
+}
+
+func (n HTTPNetWorth) ToJSON() []byte {
+	data, err := json.Marshal(n)
+	if err != nil {
+		return []byte{}
+	}
+
+	return data
+}
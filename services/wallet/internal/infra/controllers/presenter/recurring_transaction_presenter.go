@@ -0,0 +1,70 @@
+package presenter
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+)
+
+type HTTPRecurringTransaction struct {
+	Id              string       `json:"id"`
+	Amount          HTTPMonetary `json:"amount"`
+	CreatedBy       HTTPMember   `json:"created_by"`
+	TransactionType string       `json:"transaction_type"`
+	Description     string       `json:"description"`
+	CategoryId      *string      `json:"category_id"`
+	Frequency       string       `json:"frequency"`
+	DayOfMonth      int          `json:"day_of_month,omitempty"`
+	StartDate       string       `json:"start_date"`
+	EndDate         *string      `json:"end_date"`
+	Status          string       `json:"status"`
+	NextOccurrence  *string      `json:"next_occurrence"`
+	LastOccurrence  *string      `json:"last_occurrence"`
+	CreatedAt       time.Time    `json:"created_at"`
+	UpdatedAt       *time.Time   `json:"updated_at"`
+}
+
+func NewHTTPRecurringTransaction(recurring models.RecurringTransaction) HTTPRecurringTransaction {
+	httpRecurring := HTTPRecurringTransaction{
+		Id:              recurring.Id,
+		Amount:          NewHTTPMonetary(recurring.Amount),
+		CreatedBy:       NewHTTPMember(recurring.CreatedBy),
+		TransactionType: string(recurring.Type),
+		Description:     recurring.Description,
+		CategoryId:      recurring.CategoryId,
+		Frequency:       string(recurring.Frequency),
+		DayOfMonth:      recurring.DayOfMonth,
+		StartDate:       recurring.StartDate.Format(time.DateOnly),
+		EndDate:         formatDate(recurring.EndDate),
+		Status:          string(recurring.Status),
+		LastOccurrence:  formatDate(recurring.LastOccurrence),
+		CreatedAt:       recurring.CreatedAt,
+		UpdatedAt:       recurring.UpdatedAt,
+	}
+
+	// Finished and cancelled definitions have no upcoming occurrence
+	if recurring.IsActive() {
+		httpRecurring.NextOccurrence = formatDate(&recurring.NextOccurrence)
+	}
+
+	return httpRecurring
+}
+
+func (r HTTPRecurringTransaction) ToJSON() []byte {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return []byte{}
+	}
+
+	return data
+}
+
+func formatDate(date *time.Time) *string {
+	if date == nil {
+		return nil
+	}
+
+	formatted := date.Format(time.DateOnly)
+	return &formatted
+}
@@ -0,0 +1,62 @@
+package presenter
+
+import (
+	"encoding/json"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+)
+
+type HTTPSettlement struct {
+	WalletId string                  `json:"wallet_id"`
+	Balances []HTTPMemberBalance     `json:"balances"`
+	Payments []HTTPSettlementPayment `json:"payments"`
+}
+
+type HTTPMemberBalance struct {
+	MemberId string       `json:"member_id"`
+	Paid     HTTPMonetary `json:"paid"`
+	Owed     HTTPMonetary `json:"owed"`
+	Net      HTTPMonetary `json:"net"`
+}
+
+type HTTPSettlementPayment struct {
+	FromMemberId string       `json:"from_member_id"`
+	ToMemberId   string       `json:"to_member_id"`
+	Amount       HTTPMonetary `json:"amount"`
+}
+
+func NewHTTPSettlement(settlement models.Settlement) HTTPSettlement {
+	balances := make([]HTTPMemberBalance, len(settlement.Balances))
+	for i, balance := range settlement.Balances {
+		balances[i] = HTTPMemberBalance{
+			MemberId: balance.MemberId,
+			Paid:     NewHTTPMonetary(balance.Paid),
+			Owed:     NewHTTPMonetary(balance.Owed),
+			Net:      NewHTTPMonetary(balance.Net),
+		}
+	}
+
+	payments := make([]HTTPSettlementPayment, len(settlement.Payments))
+	for i, payment := range settlement.Payments {
+		payments[i] = HTTPSettlementPayment{
+			FromMemberId: payment.FromMemberId,
+			ToMemberId:   payment.ToMemberId,
+			Amount:       NewHTTPMonetary(payment.Amount),
+		}
+	}
+
+	return HTTPSettlement{
+		WalletId: settlement.WalletId,
+		Balances: balances,
+		Payments: payments,
+	}
+}
+
+func (s HTTPSettlement) ToJSON() []byte {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return []byte{}
+	}
+
+	return data
+}
@@ -0,0 +1,103 @@
+package presenter
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+)
+
+type HTTPStatementImport struct {
+	Id          string                     `json:"id"`
+	WalletId    string                     `json:"wallet_id"`
+	Format      string                     `json:"format"`
+	Status      string                     `json:"status"`
+	CreatedBy   string                     `json:"created_by"`
+	Summary     HTTPStatementImportSummary `json:"summary"`
+	Rows        []HTTPStatementRow         `json:"rows"`
+	CreatedAt   time.Time                  `json:"created_at"`
+	CommittedAt *time.Time                 `json:"committed_at"`
+}
+
+type HTTPStatementImportSummary struct {
+	Rows            int `json:"rows"`
+	Invalid         int `json:"invalid"`
+	Duplicates      int `json:"duplicates"`
+	AlreadyImported int `json:"already_imported"`
+	Committed       int `json:"committed"`
+}
+
+type HTTPStatementRow struct {
+	Line            int           `json:"line"`
+	Date            *string       `json:"date"`
+	Description     string        `json:"description"`
+	Amount          *HTTPMonetary `json:"amount"`
+	Type            *string       `json:"type"`
+	Error           *string       `json:"error"`
+	ExternalId      *string       `json:"external_id"`
+	DuplicateOf     *string       `json:"duplicate_of"`
+	AlreadyImported bool          `json:"already_imported"`
+	TransactionId   *string       `json:"transaction_id"`
+}
+
+func NewHTTPStatementImport(statementImport models.StatementImport) HTTPStatementImport {
+	summary := HTTPStatementImportSummary{Rows: len(statementImport.Rows)}
+	rows := make([]HTTPStatementRow, len(statementImport.Rows))
+
+	for i, row := range statementImport.Rows {
+		rows[i] = HTTPStatementRow{
+			Line:            row.Line,
+			Description:     row.Description,
+			DuplicateOf:     row.DuplicateOf,
+			AlreadyImported: row.AlreadyImported,
+			TransactionId:   row.TransactionId,
+		}
+		if row.ExternalId != "" {
+			rows[i].ExternalId = &row.ExternalId
+		}
+
+		if !row.IsValid() {
+			summary.Invalid++
+			rows[i].Error = &row.Error
+			continue
+		}
+
+		date := row.Date.Format(time.DateOnly)
+		amount := NewHTTPMonetary(row.Amount)
+		transactionType := string(row.Type)
+		rows[i].Date = &date
+		rows[i].Amount = &amount
+		rows[i].Type = &transactionType
+
+		if row.IsDuplicate() {
+			summary.Duplicates++
+		}
+		if row.AlreadyImported {
+			summary.AlreadyImported++
+		}
+		if row.TransactionId != nil {
+			summary.Committed++
+		}
+	}
+
+	return HTTPStatementImport{
+		Id:          statementImport.Id,
+		WalletId:    statementImport.WalletId,
+		Format:      string(statementImport.Format),
+		Status:      string(statementImport.Status),
+		CreatedBy:   statementImport.CreatedBy,
+		Summary:     summary,
+		Rows:        rows,
+		CreatedAt:   statementImport.CreatedAt,
+		CommittedAt: statementImport.CommittedAt,
+	}
+}
+
+func (i HTTPStatementImport) ToJSON() []byte {
+	data, err := json.Marshal(i)
+	if err != nil {
+		return []byte{}
+	}
+
+	return data
+}
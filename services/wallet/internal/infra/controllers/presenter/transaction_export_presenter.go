@@ -0,0 +1,261 @@
+package presenter
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+)
+
+// TransactionExporter encodes an export straight to the writer, entry by
+// entry, so the whole history is never kept in memory.
+type TransactionExporter interface {
+	WriteHeader(header models.TransactionExportHeader) error
+	WriteEntry(entry models.TransactionExportEntry) error
+	Close(closingBalance models.Monetary) error
+	ContentType() string
+	FileExtension() string
+}
+
+// NewTransactionExporter returns the exporter of the format, csv when empty.
+func NewTransactionExporter(format string, w io.Writer) (TransactionExporter, error) {
+	switch strings.ToLower(format) {
+	case "", "csv":
+		return &csvTransactionExporter{writer: csv.NewWriter(w)}, nil
+	case "json":
+		return &jsonTransactionExporter{writer: bufio.NewWriter(w)}, nil
+	case "ofx":
+		return &ofxTransactionExporter{writer: bufio.NewWriter(w)}, nil
+	default:
+		return nil, errx.ErrInvalidExportFormat
+	}
+}
+
+func authorName(member models.Member) string {
+	return strings.TrimSpace(member.FirstName + " " + member.LastName)
+}
+
+// csvTransactionExporter writes one row per transaction. Amounts are plain
+// decimals with a dot, the type gives the direction.
+type csvTransactionExporter struct {
+	writer *csv.Writer
+}
+
+func (e *csvTransactionExporter) ContentType() string {
+	return "text/csv; charset=utf-8"
+}
+
+func (e *csvTransactionExporter) FileExtension() string {
+	return "csv"
+}
+
+func (e *csvTransactionExporter) WriteHeader(header models.TransactionExportHeader) error {
+	return e.writer.Write([]string{"id", "date", "type", "description", "author", "amount", "currency", "balance"})
+}
+
+func (e *csvTransactionExporter) WriteEntry(entry models.TransactionExportEntry) error {
+	transaction := entry.Transaction
+
+	return e.writer.Write([]string{
+		transaction.Id,
+		transaction.CreatedAt.UTC().Format(time.RFC3339),
+		string(transaction.Type),
+		transaction.Description,
+		authorName(transaction.CreatedBy),
+		transaction.Amount.Format(".", ""),
+		transaction.Amount.Currency,
+		entry.Balance.Format(".", ""),
+	})
+}
+
+func (e *csvTransactionExporter) Close(closingBalance models.Monetary) error {
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+type HTTPExportedTransaction struct {
+	Id              string       `json:"id"`
+	CreatedAt       time.Time    `json:"created_at"`
+	TransactionType string       `json:"transaction_type"`
+	Description     string       `json:"description"`
+	AuthorId        string       `json:"author_id"`
+	AuthorName      string       `json:"author_name"`
+	Amount          HTTPMonetary `json:"amount"`
+	Balance         HTTPMonetary `json:"balance"`
+	CategoryId      *string      `json:"category_id"`
+	TransferId      *string      `json:"transfer_id"`
+}
+
+// jsonTransactionExporter writes a single object whose transactions array is
+// filled as the entries arrive, the closing balance comes last.
+type jsonTransactionExporter struct {
+	writer *bufio.Writer
+	count  int
+}
+
+func (e *jsonTransactionExporter) ContentType() string {
+	return "application/json"
+}
+
+func (e *jsonTransactionExporter) FileExtension() string {
+	return "json"
+}
+
+func (e *jsonTransactionExporter) WriteHeader(header models.TransactionExportHeader) error {
+	data, err := json.Marshal(struct {
+		WalletId       string       `json:"wallet_id"`
+		Currency       string       `json:"currency"`
+		From           *time.Time   `json:"from"`
+		To             *time.Time   `json:"to"`
+		OpeningBalance HTTPMonetary `json:"opening_balance"`
+	}{
+		WalletId:       header.WalletId,
+		Currency:       header.Currency,
+		From:           header.From,
+		To:             header.To,
+		OpeningBalance: NewHTTPMonetary(header.OpeningBalance),
+	})
+	if err != nil {
+		return err
+	}
+
+	// Reopen the object to append the transactions
+	e.writer.Write(data[:len(data)-1])
+	_, err = e.writer.WriteString(`,"transactions":[`)
+	return err
+}
+
+func (e *jsonTransactionExporter) WriteEntry(entry models.TransactionExportEntry) error {
+	transaction := entry.Transaction
+	data, err := json.Marshal(HTTPExportedTransaction{
+		Id:              transaction.Id,
+		CreatedAt:       transaction.CreatedAt,
+		TransactionType: string(transaction.Type),
+		Description:     transaction.Description,
+		AuthorId:        transaction.CreatedBy.Id,
+		AuthorName:      authorName(transaction.CreatedBy),
+		Amount:          NewHTTPMonetary(transaction.Amount),
+		Balance:         NewHTTPMonetary(entry.Balance),
+		CategoryId:      transaction.CategoryId,
+		TransferId:      transaction.TransferId,
+	})
+	if err != nil {
+		return err
+	}
+
+	if e.count > 0 {
+		e.writer.WriteByte(',')
+	}
+	e.count++
+
+	_, err = e.writer.Write(data)
+	return err
+}
+
+func (e *jsonTransactionExporter) Close(closingBalance models.Monetary) error {
+	data, err := json.Marshal(NewHTTPMonetary(closingBalance))
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(e.writer, `],"transaction_count":%d,"closing_balance":%s}`, e.count, data)
+	return e.writer.Flush()
+}
+
+// ofxTransactionExporter writes an OFX 2.2 bank statement whose account id is
+// the wallet id and whose FITIDs are the transaction ids, so importing it back
+// into a wallet never repeats entries. OFX has no room for the author or the
+// running balance, only the closing balance is given as the ledger balance.
+type ofxTransactionExporter struct {
+	writer *bufio.Writer
+	header models.TransactionExportHeader
+	// The transaction list starts with its date range, written once the
+	// first transaction is known
+	listStarted bool
+}
+
+func (e *ofxTransactionExporter) ContentType() string {
+	return "application/x-ofx"
+}
+
+func (e *ofxTransactionExporter) FileExtension() string {
+	return "ofx"
+}
+
+func (e *ofxTransactionExporter) WriteHeader(header models.TransactionExportHeader) error {
+	e.header = header
+	now := time.Now()
+
+	e.writer.WriteString("<?xml version=\"1.0\" encoding=\"UTF-8\" standalone=\"no\"?>\n")
+	e.writer.WriteString("<?OFX OFXHEADER=\"200\" VERSION=\"220\" SECURITY=\"NONE\" OLDFILEUID=\"NONE\" NEWFILEUID=\"NONE\"?>\n")
+	e.writer.WriteString("<OFX>\n")
+	e.writer.WriteString("<SIGNONMSGSRSV1><SONRS><STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS>")
+	fmt.Fprintf(e.writer, "<DTSERVER>%s</DTSERVER><LANGUAGE>POR</LANGUAGE></SONRS></SIGNONMSGSRSV1>\n", ofxDate(now))
+	e.writer.WriteString("<BANKMSGSRSV1><STMTTRNRS><TRNUID>0</TRNUID><STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS>\n")
+	fmt.Fprintf(e.writer, "<STMTRS><CURDEF>%s</CURDEF>\n", header.Currency)
+	e.writer.WriteString("<BANKACCTFROM><BANKID>TELLAWL</BANKID><ACCTID>")
+	xml.EscapeText(e.writer, []byte(header.WalletId))
+	_, err := e.writer.WriteString("</ACCTID><ACCTTYPE>CHECKING</ACCTTYPE></BANKACCTFROM>\n")
+	return err
+}
+
+func (e *ofxTransactionExporter) startList(start time.Time) {
+	end := time.Now()
+	if e.header.To != nil {
+		end = *e.header.To
+	}
+	if e.header.From != nil {
+		start = *e.header.From
+	}
+
+	fmt.Fprintf(e.writer, "<BANKTRANLIST><DTSTART>%s</DTSTART><DTEND>%s</DTEND>\n", ofxDate(start), ofxDate(end))
+	e.listStarted = true
+}
+
+func (e *ofxTransactionExporter) WriteEntry(entry models.TransactionExportEntry) error {
+	transaction := entry.Transaction
+	if !e.listStarted {
+		e.startList(transaction.CreatedAt)
+	}
+
+	trnType, amount := "CREDIT", transaction.Amount
+	if transaction.Type == models.TransactionTypeWithdraw {
+		trnType, amount = "DEBIT", amount.Neg()
+	}
+
+	fmt.Fprintf(e.writer, "<STMTTRN><TRNTYPE>%s</TRNTYPE><DTPOSTED>%s</DTPOSTED><TRNAMT>%s</TRNAMT><FITID>",
+		trnType, ofxDate(transaction.CreatedAt), amount.Format(".", ""))
+	xml.EscapeText(e.writer, []byte(transaction.Id))
+	e.writer.WriteString("</FITID><NAME>")
+	xml.EscapeText(e.writer, []byte(transaction.Description))
+	_, err := e.writer.WriteString("</NAME></STMTTRN>\n")
+	return err
+}
+
+func (e *ofxTransactionExporter) Close(closingBalance models.Monetary) error {
+	if !e.listStarted {
+		e.startList(time.Now())
+	}
+
+	asOf := time.Now()
+	if e.header.To != nil {
+		asOf = *e.header.To
+	}
+
+	e.writer.WriteString("</BANKTRANLIST>\n")
+	fmt.Fprintf(e.writer, "<LEDGERBAL><BALAMT>%s</BALAMT><DTASOF>%s</DTASOF></LEDGERBAL>\n", closingBalance.Format(".", ""), ofxDate(asOf))
+	e.writer.WriteString("</STMTRS></STMTTRNRS></BANKMSGSRSV1>\n</OFX>\n")
+	return e.writer.Flush()
+}
+
+// ofxDate formats a moment in UTC with the OFX timezone suffix.
+func ofxDate(t time.Time) string {
+	return t.UTC().Format("20060102150405") + "[0:GMT]"
+}
@@ -0,0 +1,37 @@
+package presenter
+
+import (
+	"encoding/json"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/repository"
+)
+
+type HTTPTransactionPage struct {
+	Transactions []HTTPTransaction `json:"transactions"`
+	NextCursor   *string           `json:"next_cursor"`
+}
+
+func NewHTTPTransactionPage(page repository.TransactionPage) HTTPTransactionPage {
+	httpPage := HTTPTransactionPage{
+		Transactions: make([]HTTPTransaction, len(page.Transactions)),
+	}
+
+	for i, transaction := range page.Transactions {
+		httpPage.Transactions[i] = NewHTTPTransaction(transaction)
+	}
+
+	if page.NextCursor != "" {
+		httpPage.NextCursor = &page.NextCursor
+	}
+
+	return httpPage
+}
+
+func (p HTTPTransactionPage) ToJSON() []byte {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return []byte{}
+	}
+
+	return data
+}
@@ -13,6 +13,15 @@ type HTTPTransaction struct {
 	CreatedBy       HTTPMember   `json:"created_by"`
 	TransactionType string       `json:"transaction_type"`
 	Description     string       `json:"description"`
+	CategoryId      *string      `json:"category_id"`
+	Status          string       `json:"status"`
+	ReplacesId      *string      `json:"replaces_id"`
+	ReplacedById    *string      `json:"replaced_by_id"`
+	RecurringId     *string      `json:"recurring_transaction_id"`
+	OccurrenceDate  *string      `json:"occurrence_date"`
+	TransferId      *string      `json:"transfer_id"`
+	Split           *HTTPSplit   `json:"split"`
+	SettledWithId   *string      `json:"settled_with_id"`
 	CreatedAt       time.Time    `json:"created_at"`
 	UpdatedAt       *time.Time   `json:"updated_at"`
 }
@@ -24,13 +33,54 @@ func NewHTTPTransaction(transaction models.Transaction) HTTPTransaction {
 		CreatedBy:       NewHTTPMember(transaction.CreatedBy),
 		TransactionType: string(transaction.Type),
 		Description:     transaction.Description,
+		CategoryId:      transaction.CategoryId,
+		Status:          string(transaction.Status),
+		ReplacesId:      transaction.ReplacesId,
+		ReplacedById:    transaction.ReplacedById,
 		CreatedAt:       transaction.CreatedAt,
 		UpdatedAt:       transaction.UpdatedAt,
+		RecurringId:     transaction.RecurringTransactionId,
+		OccurrenceDate:  formatDate(transaction.OccurrenceDate),
+		TransferId:      transaction.TransferId,
+		SettledWithId:   transaction.SettledWithId,
+	}
+
+	if transaction.Split != nil {
+		split := NewHTTPSplit(*transaction.Split)
+		httpTransaction.Split = &split
 	}
 
 	return httpTransaction
 }
 
+type HTTPSplit struct {
+	Method string           `json:"method"`
+	Shares []HTTPSplitShare `json:"shares"`
+}
+
+type HTTPSplitShare struct {
+	MemberId string `json:"member_id"`
+	// Only set for percentage splits, e.g. 25.5
+	Percentage *float64     `json:"percentage,omitempty"`
+	Amount     HTTPMonetary `json:"amount"`
+}
+
+func NewHTTPSplit(split models.TransactionSplit) HTTPSplit {
+	shares := make([]HTTPSplitShare, len(split.Shares))
+	for i, share := range split.Shares {
+		shares[i] = HTTPSplitShare{
+			MemberId: share.MemberId,
+			Amount:   NewHTTPMonetary(share.Amount),
+		}
+		if split.Method == models.SplitPercentage {
+			percentage := float64(share.Percentage) / 100
+			shares[i].Percentage = &percentage
+		}
+	}
+
+	return HTTPSplit{Method: string(split.Method), Shares: shares}
+}
+
 func (t HTTPTransaction) ToJSON() []byte {
 	data, err := json.Marshal(t)
 	if err != nil {
@@ -0,0 +1,43 @@
+package presenter
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+)
+
+type HTTPTransfer struct {
+	Id           string          `json:"id"`
+	FromWalletId string          `json:"from_wallet_id"`
+	ToWalletId   string          `json:"to_wallet_id"`
+	Amount       HTTPMonetary    `json:"amount"`
+	Description  string          `json:"description"`
+	CreatedBy    HTTPMember      `json:"created_by"`
+	Withdraw     HTTPTransaction `json:"withdraw"`
+	Deposit      HTTPTransaction `json:"deposit"`
+	CreatedAt    time.Time       `json:"created_at"`
+}
+
+func NewHTTPTransfer(transfer models.Transfer) HTTPTransfer {
+	return HTTPTransfer{
+		Id:           transfer.Id,
+		FromWalletId: transfer.FromWalletId,
+		ToWalletId:   transfer.ToWalletId,
+		Amount:       NewHTTPMonetary(transfer.Amount),
+		Description:  transfer.Description,
+		CreatedBy:    NewHTTPMember(transfer.CreatedBy),
+		Withdraw:     NewHTTPTransaction(transfer.Withdraw),
+		Deposit:      NewHTTPTransaction(transfer.Deposit),
+		CreatedAt:    transfer.CreatedAt,
+	}
+}
+
+func (t HTTPTransfer) ToJSON() []byte {
+	data, err := json.Marshal(t)
+	if err != nil {
+		return []byte{}
+	}
+
+	return data
+}
@@ -37,3 +37,17 @@ func (w HTTPMember) ToJSON() []byte {
 
 	return data
 }
+
+type HTTPWalletMember struct {
+	HTTPMember
+	Role       string    `json:"role"`
+	AssignedAt time.Time `json:"assigned_at"`
+}
+
+func NewHTTPWalletMember(member models.WalletMember) HTTPWalletMember {
+	return HTTPWalletMember{
+		HTTPMember: NewHTTPMember(member.Member),
+		Role:       string(member.Role),
+		AssignedAt: member.AssignedAt,
+	}
+}
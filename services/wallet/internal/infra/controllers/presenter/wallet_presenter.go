@@ -8,28 +8,34 @@ import (
 )
 
 type HTTPWallet struct {
-	Id           string            `json:"id"`
-	Name         string            `json:"name"`
-	CreatorId    string            `json:"creator_id"`
-	Balance      HTTPMonetary      `json:"balance"`
-	Transactions []HTTPTransaction `json:"transactions"`
-	Members      []HTTPMember      `json:"members"`
-	CreatedAt    time.Time         `json:"created_at"`
-	UpdatedAt    *time.Time        `json:"updated_at"`
+	Id           string             `json:"id"`
+	Name         string             `json:"name"`
+	CreatorId    string             `json:"creator_id"`
+	Balance      HTTPMonetary       `json:"balance"`
+	Transactions []HTTPTransaction  `json:"transactions"`
+	Members      []HTTPWalletMember `json:"members"`
+	Categories   []HTTPCategory     `json:"categories"`
+	CreatedAt    time.Time          `json:"created_at"`
+	UpdatedAt    *time.Time         `json:"updated_at"`
 }
 
 func NewHTTPWallet(wallet models.Wallet) HTTPWallet {
-	members := make([]HTTPMember, len(wallet.Members))
+	members := make([]HTTPWalletMember, len(wallet.Members))
 	transactions := make([]HTTPTransaction, len(wallet.Transactions))
+	categories := make([]HTTPCategory, len(wallet.Categories))
 
 	for i, member := range wallet.Members {
-		members[i] = NewHTTPMember(member)
+		members[i] = NewHTTPWalletMember(member)
 	}
 
 	for i, transaction := range wallet.Transactions {
 		transactions[i] = NewHTTPTransaction(transaction)
 	}
 
+	for i, category := range wallet.Categories {
+		categories[i] = NewHTTPCategory(category)
+	}
+
 	httpWallet := HTTPWallet{
 		Id:           wallet.Id,
 		Name:         wallet.Name,
@@ -37,6 +43,7 @@ func NewHTTPWallet(wallet models.Wallet) HTTPWallet {
 		Balance:      NewHTTPMonetary(wallet.Balance),
 		Transactions: transactions,
 		Members:      members,
+		Categories:   categories,
 		CreatedAt:    wallet.CreatedAt,
 		UpdatedAt:    wallet.UpdatedAt,
 	}
@@ -0,0 +1,92 @@
+package presenter
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+)
+
+type HTTPWalletSummary struct {
+	Id               string       `json:"id"`
+	Name             string       `json:"name"`
+	CreatorId        string       `json:"creator_id"`
+	Balance          HTTPMonetary `json:"balance"`
+	Role             string       `json:"role"`
+	TransactionCount int          `json:"transaction_count"`
+	LastActivityAt   *time.Time   `json:"last_activity_at"`
+	CreatedAt        time.Time    `json:"created_at"`
+	UpdatedAt        *time.Time   `json:"updated_at"`
+}
+
+func NewHTTPWalletSummary(wallet models.WalletSummary) HTTPWalletSummary {
+	return HTTPWalletSummary{
+		Id:               wallet.Id,
+		Name:             wallet.Name,
+		CreatorId:        wallet.CreatorId,
+		Balance:          NewHTTPMonetary(wallet.Balance),
+		Role:             string(wallet.Role),
+		TransactionCount: wallet.TransactionCount,
+		LastActivityAt:   wallet.LastActivityAt,
+		CreatedAt:        wallet.CreatedAt,
+		UpdatedAt:        wallet.UpdatedAt,
+	}
+}
+
+type HTTPWalletDetail struct {
+	Id                     string             `json:"id"`
+	Name                   string             `json:"name"`
+	CreatorId              string             `json:"creator_id"`
+	Balance                HTTPMonetary       `json:"balance"`
+	Members                []HTTPWalletMember `json:"members"`
+	Categories             []HTTPCategory     `json:"categories"`
+	RecentTransactions     []HTTPTransaction  `json:"recent_transactions"`
+	NextTransactionsCursor *string            `json:"next_transactions_cursor"`
+	CreatedAt              time.Time          `json:"created_at"`
+	UpdatedAt              *time.Time         `json:"updated_at"`
+}
+
+func NewHTTPWalletDetail(wallet models.WalletDetail) HTTPWalletDetail {
+	members := make([]HTTPWalletMember, len(wallet.Members))
+	transactions := make([]HTTPTransaction, len(wallet.RecentTransactions))
+	categories := make([]HTTPCategory, len(wallet.Categories))
+
+	for i, member := range wallet.Members {
+		members[i] = NewHTTPWalletMember(member)
+	}
+
+	for i, transaction := range wallet.RecentTransactions {
+		transactions[i] = NewHTTPTransaction(transaction)
+	}
+
+	for i, category := range wallet.Categories {
+		categories[i] = NewHTTPCategory(category)
+	}
+
+	httpWallet := HTTPWalletDetail{
+		Id:                 wallet.Id,
+		Name:               wallet.Name,
+		CreatorId:          wallet.CreatorId,
+		Balance:            NewHTTPMonetary(wallet.Balance),
+		Members:            members,
+		Categories:         categories,
+		RecentTransactions: transactions,
+		CreatedAt:          wallet.CreatedAt,
+		UpdatedAt:          wallet.UpdatedAt,
+	}
+
+	if wallet.NextTransactionsCursor != "" {
+		httpWallet.NextTransactionsCursor = &wallet.NextTransactionsCursor
+	}
+
+	return httpWallet
+}
+
+func (w HTTPWalletDetail) ToJSON() []byte {
+	data, err := json.Marshal(w)
+	if err != nil {
+		return []byte{}
+	}
+
+	return data
+}
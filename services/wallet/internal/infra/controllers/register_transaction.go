@@ -3,6 +3,7 @@ package controllers
 import (
 	"encoding/json"
 	"log/slog"
+	"math"
 	"net/http"
 
 	"github.com/gorilla/mux"
@@ -13,10 +14,46 @@ import (
 )
 
 type registerTransactionRequest struct {
-	Amount          int    `json:"amount"`
-	Offset          int    `json:"offset"`
-	TransactionType string `json:"transaction_type"`
-	Description     string `json:"description"`
+	Amount          int     `json:"amount"`
+	Offset          int     `json:"offset"`
+	TransactionType string  `json:"transaction_type"`
+	Description     string  `json:"description"`
+	CategoryId      *string `json:"category_id"`
+	Currency        string  `json:"currency"`
+	// Shares a withdraw among members of the wallet
+	Split *transactionSplitRequest `json:"split"`
+	// Member paid back by a settlement
+	SettledWithId string `json:"settled_with_id"`
+}
+
+type transactionSplitRequest struct {
+	Method string                         `json:"method"`
+	Shares []transactionSplitShareRequest `json:"shares"`
+}
+
+type transactionSplitShareRequest struct {
+	MemberId string `json:"member_id"`
+	// Percentage of the amount, e.g. 25.5
+	Percentage float64 `json:"percentage"`
+	// Exact amount, in the same offset as the transaction amount
+	Amount int `json:"amount"`
+}
+
+func (s *transactionSplitRequest) toInput() *usecases.TransactionSplitInput {
+	if s == nil {
+		return nil
+	}
+
+	input := &usecases.TransactionSplitInput{Method: s.Method}
+	for _, share := range s.Shares {
+		input.Shares = append(input.Shares, usecases.TransactionSplitShareInput{
+			MemberId:   share.MemberId,
+			Percentage: int(math.Round(share.Percentage * 100)),
+			Amount:     share.Amount,
+		})
+	}
+
+	return input
 }
 
 func (h *APIHandler) HandleRegisterTransaction(w http.ResponseWriter, r *http.Request) {
@@ -61,10 +98,14 @@ func (h *APIHandler) HandleRegisterTransaction(w http.ResponseWriter, r *http.Re
 		Offset:                        data.Offset,
 		TransactionType:               data.TransactionType,
 		Description:                   data.Description,
+		CategoryId:                    data.CategoryId,
+		Currency:                      data.Currency,
+		Split:                         data.Split.toInput(),
+		SettledWithId:                 data.SettledWithId,
 	})
 	if err != nil {
 		h.logger.Error(ctx, "Could not register transaction", slog.String("error", err.Error()))
-		WriteError(w, http.StatusBadRequest, map[string]any{
+		WriteError(w, errorStatusCode(err, http.StatusBadRequest), map[string]any{
 			"message": "Could not register the transaction",
 			"error":   err.Error(),
 		})
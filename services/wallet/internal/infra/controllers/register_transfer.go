@@ -0,0 +1,88 @@
+package controllers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/infra/controllers/presenter"
+	usecases "github.com/lopesgabriel/tellawl/services/wallet/internal/use-cases"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type registerTransferRequest struct {
+	ToWalletId  string `json:"to_wallet_id"`
+	Amount      int    `json:"amount"`
+	Offset      int    `json:"offset"`
+	Currency    string `json:"currency"`
+	Description string `json:"description"`
+}
+
+func (h *APIHandler) HandleRegisterTransfer(w http.ResponseWriter, r *http.Request) {
+	ctx, span := h.tracer.Start(r.Context(), "HandleRegisterTransfer")
+	defer span.End()
+
+	member := r.Context().Value(memberContextKey).(*models.Member)
+
+	var data registerTransferRequest
+	// Read the requst body
+	err := json.NewDecoder(r.Body).Decode(&data)
+	defer r.Body.Close()
+	if err != nil {
+		h.logger.Error(ctx, "Could not decode the request body", slog.String("error", err.Error()))
+		WriteError(w, http.StatusBadRequest, map[string]any{
+			"message": "Could not parse the request body, are you sending a JSON?",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	if data.ToWalletId == "" {
+		WriteError(w, http.StatusBadRequest, map[string]any{
+			"message": "to_wallet_id is required",
+		})
+		return
+	}
+
+	vars := mux.Vars(r)
+	walletId := vars["wallet_id"]
+	if walletId == "" {
+		h.logger.Error(ctx, "Could not get wallet id from path")
+		WriteError(w, http.StatusInternalServerError, map[string]any{
+			"message": "Could not get wallet id from path",
+		})
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("wallet_id", walletId),
+		attribute.String("to_wallet_id", data.ToWalletId),
+		attribute.String("user_id", member.Id),
+	)
+	transfer, err := h.usecases.RegisterTransfer(ctx, usecases.RegisterTransferUseCaseInput{
+		MemberId:     member.Id,
+		Member:       member,
+		FromWalletId: walletId,
+		ToWalletId:   data.ToWalletId,
+		Amount:       data.Amount,
+		Offset:       data.Offset,
+		Currency:     data.Currency,
+		Description:  data.Description,
+	})
+	if err != nil {
+		h.logger.Error(ctx, "Could not register the transfer", slog.String("error", err.Error()))
+		WriteError(w, errorStatusCode(err, http.StatusBadRequest), map[string]any{
+			"message": "Could not register the transfer",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	httpTransfer := presenter.NewHTTPTransfer(*transfer)
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	w.Write(httpTransfer.ToJSON())
+}
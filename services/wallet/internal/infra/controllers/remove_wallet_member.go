@@ -0,0 +1,63 @@
+package controllers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+	usecases "github.com/lopesgabriel/tellawl/services/wallet/internal/use-cases"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// HandleRemoveWalletMember unshares the wallet with the given member or, when
+// the member is the caller, makes the caller leave the wallet.
+func (h *APIHandler) HandleRemoveWalletMember(w http.ResponseWriter, r *http.Request) {
+	ctx, span := h.tracer.Start(r.Context(), "HandleRemoveWalletMember")
+	defer span.End()
+
+	member := r.Context().Value(memberContextKey).(*models.Member)
+
+	vars := mux.Vars(r)
+	walletId := vars["wallet_id"]
+	memberId := vars["member_id"]
+	if walletId == "" || memberId == "" {
+		h.logger.Error(ctx, "Could not get wallet id or member id from path")
+		WriteError(w, http.StatusInternalServerError, map[string]any{
+			"message": "Could not get wallet id or member id from path",
+		})
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("wallet_id", walletId),
+		attribute.String("member_id", memberId),
+		attribute.String("user_id", member.Id),
+	)
+
+	var err error
+	if memberId == member.Id {
+		err = h.usecases.LeaveWallet(ctx, usecases.LeaveWalletUseCaseInput{
+			MemberId: member.Id,
+			Member:   member,
+			WalletId: walletId,
+		})
+	} else {
+		_, err = h.usecases.UnshareWallet(ctx, usecases.UnshareWalletUseCaseInput{
+			WalletCreatorId: member.Id,
+			WalletCreator:   member,
+			WalletId:        walletId,
+			MemberId:        memberId,
+		})
+	}
+	if err != nil {
+		h.logger.Error(ctx, "Could not remove the wallet member", slog.String("error", err.Error()))
+		WriteError(w, errorStatusCode(err, http.StatusInternalServerError), map[string]any{
+			"message": "Could not remove the member from the wallet",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,50 @@
+package controllers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+	usecases "github.com/lopesgabriel/tellawl/services/wallet/internal/use-cases"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func (h *APIHandler) HandleRevokeInvitation(w http.ResponseWriter, r *http.Request) {
+	ctx, span := h.tracer.Start(r.Context(), "HandleRevokeInvitation")
+	defer span.End()
+
+	member := r.Context().Value(memberContextKey).(*models.Member)
+
+	vars := mux.Vars(r)
+	walletId := vars["wallet_id"]
+	invitationId := vars["invitation_id"]
+	if walletId == "" || invitationId == "" {
+		h.logger.Error(ctx, "Could not get wallet id or invitation id from path")
+		WriteError(w, http.StatusInternalServerError, map[string]any{
+			"message": "Could not get wallet id or invitation id from path",
+		})
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("wallet_id", walletId),
+		attribute.String("invitation_id", invitationId),
+		attribute.String("user_id", member.Id),
+	)
+	_, err := h.usecases.RevokeInvitation(ctx, usecases.RevokeInvitationUseCaseInput{
+		MemberId:     member.Id,
+		WalletId:     walletId,
+		InvitationId: invitationId,
+	})
+	if err != nil {
+		h.logger.Error(ctx, "Could not revoke the invitation", slog.String("error", err.Error()))
+		WriteError(w, errorStatusCode(err, http.StatusInternalServerError), map[string]any{
+			"message": "Could not revoke the invitation",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
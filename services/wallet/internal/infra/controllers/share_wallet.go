@@ -5,6 +5,7 @@ import (
 	"errors"
 	"log/slog"
 	"net/http"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
@@ -15,6 +16,7 @@ import (
 
 type shareWalletRequest struct {
 	UserEmail string `json:"user_email"`
+	Role      string `json:"role"`
 }
 
 func (h *APIHandler) HandleShareWallet(w http.ResponseWriter, r *http.Request) {
@@ -47,11 +49,12 @@ func (h *APIHandler) HandleShareWallet(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	wallet, err := h.usecases.ShareWallet(ctx, usecases.ShareWalletUseCaseInput{
+	invitation, err := h.usecases.ShareWallet(ctx, usecases.ShareWalletUseCaseInput{
 		WalletCreatorId: creatorId,
 		WalletCreator:   member,
 		WalletId:        walletId,
 		SharedUserEmail: data.UserEmail,
+		Role:            data.Role,
 	})
 	if err != nil {
 		if errors.Is(err, errx.ErrInsufficientPermissions) {
@@ -63,16 +66,16 @@ func (h *APIHandler) HandleShareWallet(w http.ResponseWriter, r *http.Request) {
 		}
 
 		h.logger.Error(ctx, "Could not share the wallet", slog.String("error", err.Error()))
-		WriteError(w, http.StatusInternalServerError, map[string]any{
+		WriteError(w, errorStatusCode(err, http.StatusInternalServerError), map[string]any{
 			"message": "Could not share the wallet",
 			"error":   err.Error(),
 		})
 		return
 	}
 
-	httpWallet := presenter.NewHTTPWallet(*wallet)
+	httpInvitation := presenter.NewHTTPInvitation(*invitation, time.Now())
 
 	w.Header().Add("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	w.Write(httpWallet.ToJSON())
+	w.WriteHeader(http.StatusCreated)
+	w.Write(httpInvitation.ToJSON())
 }
@@ -0,0 +1,71 @@
+package controllers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/infra/controllers/presenter"
+	usecases "github.com/lopesgabriel/tellawl/services/wallet/internal/use-cases"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func (h *APIHandler) HandleUpdateCategory(w http.ResponseWriter, r *http.Request) {
+	ctx, span := h.tracer.Start(r.Context(), "HandleUpdateCategory")
+	defer span.End()
+
+	member := r.Context().Value(memberContextKey).(*models.Member)
+
+	var data categoryRequest
+	// Read the requst body
+	err := json.NewDecoder(r.Body).Decode(&data)
+	defer r.Body.Close()
+	if err != nil {
+		h.logger.Error(ctx, "Could not decode the request body", slog.String("error", err.Error()))
+		WriteError(w, http.StatusBadRequest, map[string]any{
+			"message": "Could not parse the request body, are you sending a JSON?",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	vars := mux.Vars(r)
+	walletId := vars["wallet_id"]
+	categoryId := vars["category_id"]
+	if walletId == "" || categoryId == "" {
+		h.logger.Error(ctx, "Could not get wallet id or category id from path")
+		WriteError(w, http.StatusInternalServerError, map[string]any{
+			"message": "Could not get wallet id or category id from path",
+		})
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("wallet_id", walletId),
+		attribute.String("category_id", categoryId),
+		attribute.String("user_id", member.Id),
+	)
+	category, err := h.usecases.UpdateCategory(ctx, usecases.UpdateCategoryUseCaseInput{
+		MemberId:   member.Id,
+		Member:     member,
+		WalletId:   walletId,
+		CategoryId: categoryId,
+		Name:       data.Name,
+	})
+	if err != nil {
+		h.logger.Error(ctx, "Could not update the category", slog.String("error", err.Error()))
+		WriteError(w, errorStatusCode(err, http.StatusBadRequest), map[string]any{
+			"message": "Could not update the category",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	httpCategory := presenter.NewHTTPCategory(*category)
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(httpCategory.ToJSON())
+}
@@ -0,0 +1,119 @@
+package controllers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/infra/controllers/presenter"
+	usecases "github.com/lopesgabriel/tellawl/services/wallet/internal/use-cases"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type updateTransactionRequest struct {
+	Amount          *int    `json:"amount"`
+	Offset          *int    `json:"offset"`
+	TransactionType *string `json:"transaction_type"`
+	Description     *string `json:"description"`
+	// CategoryId is kept raw to tell an absent field from an explicit null,
+	// which removes the category from the transaction.
+	CategoryId json.RawMessage `json:"category_id"`
+	// Split is kept raw for the same reason, null stops sharing the expense.
+	Split json.RawMessage `json:"split"`
+}
+
+func (h *APIHandler) HandleUpdateTransaction(w http.ResponseWriter, r *http.Request) {
+	ctx, span := h.tracer.Start(r.Context(), "HandleUpdateTransaction")
+	defer span.End()
+
+	member := r.Context().Value(memberContextKey).(*models.Member)
+
+	var data updateTransactionRequest
+	// Read the requst body
+	err := json.NewDecoder(r.Body).Decode(&data)
+	defer r.Body.Close()
+	if err != nil {
+		h.logger.Error(ctx, "Could not decode the request body", slog.String("error", err.Error()))
+		WriteError(w, http.StatusBadRequest, map[string]any{
+			"message": "Could not parse the request body, are you sending a JSON?",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	var categoryId *string
+	clearCategory := false
+	if len(data.CategoryId) > 0 {
+		if err := json.Unmarshal(data.CategoryId, &categoryId); err != nil {
+			h.logger.Error(ctx, "Could not decode the category id", slog.String("error", err.Error()))
+			WriteError(w, http.StatusBadRequest, map[string]any{
+				"message": "category_id must be a string or null",
+				"error":   err.Error(),
+			})
+			return
+		}
+		clearCategory = categoryId == nil
+	}
+
+	var split *transactionSplitRequest
+	clearSplit := false
+	if len(data.Split) > 0 {
+		if err := json.Unmarshal(data.Split, &split); err != nil {
+			h.logger.Error(ctx, "Could not decode the split", slog.String("error", err.Error()))
+			WriteError(w, http.StatusBadRequest, map[string]any{
+				"message": "split must be an object or null",
+				"error":   err.Error(),
+			})
+			return
+		}
+		clearSplit = split == nil
+	}
+
+	vars := mux.Vars(r)
+	walletId := vars["wallet_id"]
+	transactionId := vars["transaction_id"]
+	if walletId == "" || transactionId == "" {
+		h.logger.Error(ctx, "Could not get wallet id or transaction id from path")
+		WriteError(w, http.StatusInternalServerError, map[string]any{
+			"message": "Could not get wallet id or transaction id from path",
+		})
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("wallet_id", walletId),
+		attribute.String("transaction_id", transactionId),
+		attribute.String("user_id", member.Id),
+	)
+	transaction, err := h.usecases.UpdateTransaction(ctx, usecases.UpdateTransactionUseCaseInput{
+		MemberId:        member.Id,
+		Member:          member,
+		WalletId:        walletId,
+		TransactionId:   transactionId,
+		Amount:          data.Amount,
+		Offset:          data.Offset,
+		TransactionType: data.TransactionType,
+		Description:     data.Description,
+		CategoryId:      categoryId,
+		ClearCategory:   clearCategory,
+		Split:           split.toInput(),
+		ClearSplit:      clearSplit,
+	})
+	if err != nil {
+		h.logger.Error(ctx, "Could not update the transaction", slog.String("error", err.Error()))
+		WriteError(w, errorStatusCode(err, http.StatusBadRequest), map[string]any{
+			"message": "Could not update the transaction",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	httpTransaction := presenter.NewHTTPTransaction(*transaction)
+
+	w.Header().Add("Content-Type", "application/json")
+	w.Header().Add("Location", "/wallets/"+walletId+"/transactions/"+transaction.Id)
+	w.WriteHeader(http.StatusOK)
+	w.Write(httpTransaction.ToJSON())
+}
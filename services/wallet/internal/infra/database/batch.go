@@ -0,0 +1,57 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// maxStatementParameters is how many parameters PostgreSQL accepts in a
+// single statement.
+const maxStatementParameters = 65535
+
+// execBatch inserts the rows with as few multi-row statements as the
+// parameter limit allows. query holds a single %s where the VALUES list goes,
+// e.g. "INSERT INTO t (a, b) VALUES %s ON CONFLICT DO NOTHING". Every row
+// must have the same number of values.
+func execBatch(ctx context.Context, tx *sql.Tx, query string, rows [][]any) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	columns := len(rows[0])
+	for batch := range slices.Chunk(rows, maxStatementParameters/columns) {
+		values := make([]string, len(batch))
+		args := make([]any, 0, len(batch)*columns)
+		for i, row := range batch {
+			placeholders := make([]string, columns)
+			for j := range row {
+				placeholders[j] = fmt.Sprintf("$%d", len(args)+j+1)
+			}
+			values[i] = "(" + strings.Join(placeholders, ", ") + ")"
+			args = append(args, row...)
+		}
+
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf(query, strings.Join(values, ", ")), args...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// changedEntities picks the entities with the given ids, in the order of the
+// ids. Ids of entities that no longer exist are skipped.
+func changedEntities[T any](entities []T, ids []string, id func(T) string) []T {
+	changed := make([]T, 0, len(ids))
+	for _, changedId := range ids {
+		index := slices.IndexFunc(entities, func(entity T) bool { return id(entity) == changedId })
+		if index >= 0 {
+			changed = append(changed, entities[index])
+		}
+	}
+
+	return changed
+}
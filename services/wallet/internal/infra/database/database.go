@@ -4,17 +4,18 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"log/slog"
 	"net/http"
 
 	"github.com/lopesgabriel/tellawl/packages/logger"
 	"github.com/lopesgabriel/tellawl/services/wallet/internal/config"
 	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/events"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
 	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/repository"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 )
 
 func InitDatabase(ctx context.Context, appConfig *config.AppConfiguration, publisher events.EventPublisher) (*repository.Repositories, error) {
-	var repo *repository.Repositories
 	var httMemberRepo *HTTPMemberRepository
 
 	appLogger, err := logger.GetLogger()
@@ -22,17 +23,21 @@ func InitDatabase(ctx context.Context, appConfig *config.AppConfiguration, publi
 		return nil, fmt.Errorf("failed to initialize logger: %w", err)
 	}
 
+	rates, err := ParseExchangeRates(appConfig.ExchangeRates)
+	if err != nil {
+		return nil, err
+	}
+
 	if appConfig.MemberServiceUrl != "" {
 		// Instrumented HTTP Client
 		client := &http.Client{
 			Transport: otelhttp.NewTransport(http.DefaultTransport),
 		}
 
-		repo, err := NewHTTPMemberRepository(appConfig.MemberServiceUrl, client)
+		httMemberRepo, err = NewHTTPMemberRepository(appConfig.MemberServiceUrl, client)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create HTTP member repository: %w", err)
 		}
-		httMemberRepo = repo
 	}
 
 	if appConfig.DatabaseUrl != "" && appConfig.MigrationUrl != "" {
@@ -53,25 +58,45 @@ func InitDatabase(ctx context.Context, appConfig *config.AppConfiguration, publi
 			return nil, fmt.Errorf("failed to apply database migration: %w", err)
 		}
 
-		repo = newPostgreSQL(db, publisher, httMemberRepo)
-		return repo, nil
+		memberRepo := NewPostgreSQLMemberRepository(db, httMemberRepo)
+		count, err := memberRepo.Backfill(ctx)
+		if err != nil {
+			// Members missing locally are still fetched from member-service
+			appLogger.Error(ctx, "failed to backfill members, it will be retried on the next start", slog.String("error", err.Error()))
+		} else if count > 0 {
+			appLogger.Info(ctx, "Backfilled members from member-service", slog.Int("count", count))
+		}
+
+		return newPostgreSQL(db, memberRepo, rates), nil
 	}
 
 	appLogger.Warn(ctx, "Using InMemory database. Data will not be persisted and will be lost on service restart.")
 
-	return NewInMemory(publisher), nil
+	return NewInMemory(publisher, rates...), nil
 }
 
-func NewInMemory(publisher events.EventPublisher) *repository.Repositories {
+func NewInMemory(publisher events.EventPublisher, rates ...models.ExchangeRate) *repository.Repositories {
+	// Invitations and imports are saved along with the wallets, so the wallet
+	// repository holds them
+	wallets := NewInMemoryWalletRepository(publisher)
 	return &repository.Repositories{
-		Member: NewInMemoryMemberRepository(publisher),
-		Wallet: NewInMemoryWalletRepository(publisher),
+		Member:          NewInMemoryMemberRepository(publisher),
+		Wallet:          wallets,
+		StatementImport: wallets.statementImports,
+		Invitation:      wallets.invitations,
+		IdempotencyKey:  NewInMemoryIdempotencyKeyRepository(),
+		ExchangeRate:    NewStaticExchangeRateRepository(rates...),
 	}
 }
 
-func newPostgreSQL(db *sql.DB, publisher events.EventPublisher, memberRepo *HTTPMemberRepository) *repository.Repositories {
+func newPostgreSQL(db *sql.DB, memberRepo *PostgreSQLMemberRepository, rates []models.ExchangeRate) *repository.Repositories {
 	return &repository.Repositories{
-		Member: memberRepo,
-		Wallet: NewPostgreSQLWalletRepository(db, publisher, memberRepo),
+		Member:          memberRepo,
+		Wallet:          NewPostgreSQLWalletRepository(db, memberRepo),
+		StatementImport: NewPostgreSQLStatementImportRepository(db),
+		Invitation:      NewPostgreSQLInvitationRepository(db),
+		Outbox:          NewPostgreSQLOutboxRepository(db),
+		IdempotencyKey:  NewPostgreSQLIdempotencyKeyRepository(db),
+		ExchangeRate:    NewStaticExchangeRateRepository(rates...),
 	}
 }
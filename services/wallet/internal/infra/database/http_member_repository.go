@@ -8,6 +8,8 @@ import (
 	"net/http"
 	"net/url"
 	"path"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -59,10 +61,20 @@ type memberAPIResponse struct {
 type membersListResponse struct {
 	Data []memberAPIResponse `json:"data"`
 	Meta struct {
-		Total int `json:"total"`
+		Total int     `json:"total"`
+		Next  *string `json:"next"`
 	} `json:"meta"`
 }
 
+// toModels converts a list of API responses to domain models.
+func toModels(list []memberAPIResponse) []models.Member {
+	members := make([]models.Member, len(list))
+	for i, m := range list {
+		members[i] = *toModel(m)
+	}
+	return members
+}
+
 // toModel converts API response to domain model.
 func toModel(a memberAPIResponse) *models.Member {
 	return &models.Member{
@@ -167,6 +179,90 @@ func (r *HTTPMemberRepository) FindByEmail(ctx context.Context, email string) (*
 	return toModel(list.Data[0]), nil
 }
 
+// memberLookupBatchSize is how many ids are sent to member-service per
+// request, keeping the URL short.
+const memberLookupBatchSize = 100
+
+// List returns a page of up to limit members ordered by id, starting after the
+// given id. A page shorter than limit is the last one.
+func (r *HTTPMemberRepository) List(ctx context.Context, after string, limit int) ([]models.Member, error) {
+	ctx, span := r.tracer.Start(ctx, "List")
+	defer span.End()
+
+	q := url.Values{}
+	q.Set("limit", strconv.Itoa(limit))
+	if after != "" {
+		q.Set("after", after)
+	}
+
+	list, err := r.listMembers(ctx, span, q)
+	if err != nil {
+		return nil, err
+	}
+
+	span.SetStatus(codes.Ok, "members listed")
+	return toModels(list.Data), nil
+}
+
+// FindByIDs looks the members up in batches, ids that do not exist are
+// skipped.
+func (r *HTTPMemberRepository) FindByIDs(ctx context.Context, ids []string) ([]models.Member, error) {
+	ctx, span := r.tracer.Start(ctx, "FindByIDs", trace.WithAttributes(
+		attribute.Int("members.ids", len(ids)),
+	))
+	defer span.End()
+
+	members := []models.Member{}
+	for batch := range slices.Chunk(ids, memberLookupBatchSize) {
+		q := url.Values{}
+		q.Set("id", strings.Join(batch, ","))
+		q.Set("limit", strconv.Itoa(len(batch)))
+
+		list, err := r.listMembers(ctx, span, q)
+		if err != nil {
+			return nil, err
+		}
+
+		members = append(members, toModels(list.Data)...)
+	}
+
+	span.SetStatus(codes.Ok, "members found")
+	return members, nil
+}
+
+func (r *HTTPMemberRepository) listMembers(ctx context.Context, span trace.Span, q url.Values) (*membersListResponse, error) {
+	urlStr := fmt.Sprintf("%s%s?%s", r.baseURL.String(), "/internal/members", q.Encode())
+	span.SetAttributes(attribute.String("http.url", urlStr))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, urlStr, nil)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		err := fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(body))
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	var list membersListResponse
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return &list, nil
+}
+
 func (r *HTTPMemberRepository) ValidateToken(ctx context.Context, token string) (*models.Member, error) {
 	ctx, span := r.tracer.Start(ctx, "ValidateToken")
 	defer span.End()
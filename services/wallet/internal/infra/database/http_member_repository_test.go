@@ -6,6 +6,8 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -128,3 +130,82 @@ func TestValidateToken(t *testing.T) {
 		t.Fatalf("expected ErrInvalidCredentials, got %v", err)
 	}
 }
+
+func TestList(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/internal/members" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.URL.Query().Get("after") != "abc" || r.URL.Query().Get("limit") != "2" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": []map[string]interface{}{
+				{"id": "abd", "first_name": "Jane", "last_name": "Roe", "email": "jane@example.com", "created_at": time.Now().Format(time.RFC3339)},
+			},
+			"meta": map[string]interface{}{"total": 1, "next": nil},
+		})
+	}))
+	defer s.Close()
+
+	r, err := NewHTTPMemberRepository(s.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	members, err := r.List(context.Background(), "abc", 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(members) != 1 || members[0].Id != "abd" {
+		t.Fatalf("unexpected members: %+v", members)
+	}
+}
+
+func TestFindByIDs(t *testing.T) {
+	requests := 0
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		ids := strings.Split(r.URL.Query().Get("id"), ",")
+		if r.URL.Path != "/internal/members" || r.URL.Query().Get("limit") != strconv.Itoa(len(ids)) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		data := []map[string]interface{}{}
+		for _, id := range ids {
+			if id == "missing" {
+				continue
+			}
+			data = append(data, map[string]interface{}{"id": id, "email": id + "@example.com", "created_at": time.Now().Format(time.RFC3339)})
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": data,
+			"meta": map[string]interface{}{"total": len(data), "next": nil},
+		})
+	}))
+	defer s.Close()
+
+	r, err := NewHTTPMemberRepository(s.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ids := []string{"missing"}
+	for i := range memberLookupBatchSize {
+		ids = append(ids, strconv.Itoa(i))
+	}
+
+	members, err := r.FindByIDs(context.Background(), ids)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(members) != memberLookupBatchSize {
+		t.Fatalf("expected %d members, got %d", memberLookupBatchSize, len(members))
+	}
+	if requests != 2 {
+		t.Fatalf("expected 2 requests, got %d", requests)
+	}
+}
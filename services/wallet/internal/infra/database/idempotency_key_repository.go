@@ -0,0 +1,225 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/lopesgabriel/tellawl/packages/tracing"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type PostgreSQLIdempotencyKeyRepository struct {
+	db     *sql.DB
+	tracer trace.Tracer
+}
+
+func NewPostgreSQLIdempotencyKeyRepository(db *sql.DB) *PostgreSQLIdempotencyKeyRepository {
+	return &PostgreSQLIdempotencyKeyRepository{
+		db:     db,
+		tracer: tracing.GetTracer("github.com/lopesgabriel/tellawl/services/wallet/internal/infra/database/postgresql/PostgreSQLIdempotencyKeyRepository"),
+	}
+}
+
+// maxReserveAttempts bounds how many times Reserve tries again when the key
+// it conflicted with is released before it could be read.
+const maxReserveAttempts = 3
+
+// Reserve relies on the primary key, when two requests race for the same key
+// only one of them inserts it. An expired or abandoned key is removed first so
+// the request takes it over.
+func (r *PostgreSQLIdempotencyKeyRepository) Reserve(ctx context.Context, request *models.IdempotentRequest) (*models.IdempotentRequest, error) {
+	ctx, span := r.tracer.Start(ctx, "Reserve", trace.WithAttributes(
+		attribute.String("member.id", request.MemberId),
+	))
+	defer span.End()
+
+	for range maxReserveAttempts {
+		reserved, existing, err := r.reserve(ctx, request)
+		if err != nil {
+			span.SetStatus(codes.Error, "failed to reserve the key")
+			span.RecordError(err)
+			return nil, err
+		}
+		if reserved {
+			span.SetStatus(codes.Ok, "Key reserved")
+			return nil, nil
+		}
+		if existing != nil {
+			span.SetStatus(codes.Ok, "Key already used")
+			return existing, nil
+		}
+	}
+
+	// The key keeps being reserved and released by other requests
+	span.SetStatus(codes.Error, "key kept changing hands")
+	return nil, errx.ErrIdempotencyKeyInProgress
+}
+
+// reserve inserts the request unless its key is held, returning the request
+// holding it. Neither is returned when that request was released before it
+// could be read.
+func (r *PostgreSQLIdempotencyKeyRepository) reserve(ctx context.Context, request *models.IdempotentRequest) (bool, *models.IdempotentRequest, error) {
+	now := time.Now()
+	_, err := r.db.ExecContext(ctx, `DELETE FROM idempotency_keys
+			  WHERE member_id = $1 AND key = $2 AND (created_at <= $3 OR (completed_at IS NULL AND created_at <= $4))`,
+		request.MemberId,
+		request.Key,
+		now.Add(-models.IdempotencyKeyTTL).UTC(),
+		now.Add(-models.IdempotencyLockTimeout).UTC(),
+	)
+	if err != nil {
+		return false, nil, err
+	}
+
+	result, err := r.db.ExecContext(ctx, `INSERT INTO idempotency_keys (member_id, key, method, path, fingerprint, created_at)
+			  VALUES ($1, $2, $3, $4, $5, $6)
+			  ON CONFLICT (member_id, key) DO NOTHING`,
+		request.MemberId,
+		request.Key,
+		request.Method,
+		request.Path,
+		request.Fingerprint,
+		request.CreatedAt.UTC(),
+	)
+	if err != nil {
+		return false, nil, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil || affected > 0 {
+		return affected > 0, nil, err
+	}
+
+	existing, err := r.find(ctx, request.MemberId, request.Key)
+	return false, existing, err
+}
+
+func (r *PostgreSQLIdempotencyKeyRepository) find(ctx context.Context, memberId, key string) (*models.IdempotentRequest, error) {
+	request := &models.IdempotentRequest{MemberId: memberId, Key: key}
+	var statusCode sql.NullInt64
+	var contentType sql.NullString
+	var completedAt sql.NullTime
+
+	err := r.db.QueryRowContext(ctx, `SELECT method, path, fingerprint, status_code, content_type, body, created_at, completed_at
+			  FROM idempotency_keys
+			  WHERE member_id = $1 AND key = $2`, memberId, key).Scan(
+		&request.Method,
+		&request.Path,
+		&request.Fingerprint,
+		&statusCode,
+		&contentType,
+		&request.Body,
+		&request.CreatedAt,
+		&completedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		// Released between the insert and the select
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	request.StatusCode = int(statusCode.Int64)
+	request.ContentType = contentType.String
+	if completedAt.Valid {
+		request.CompletedAt = &completedAt.Time
+	}
+
+	return request, nil
+}
+
+func (r *PostgreSQLIdempotencyKeyRepository) Complete(ctx context.Context, request *models.IdempotentRequest) error {
+	ctx, span := r.tracer.Start(ctx, "Complete", trace.WithAttributes(
+		attribute.String("member.id", request.MemberId),
+		attribute.Int("http.status_code", request.StatusCode),
+	))
+	defer span.End()
+
+	var completedAt *time.Time
+	if request.CompletedAt != nil {
+		utc := request.CompletedAt.UTC()
+		completedAt = &utc
+	}
+
+	result, err := r.db.ExecContext(ctx, `UPDATE idempotency_keys SET status_code = $3, content_type = $4, body = $5, completed_at = $6
+			  WHERE member_id = $1 AND key = $2 AND created_at = $7`,
+		request.MemberId,
+		request.Key,
+		request.StatusCode,
+		request.ContentType,
+		request.Body,
+		completedAt,
+		request.CreatedAt.UTC(),
+	)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return err
+	}
+	if affected == 0 {
+		span.SetStatus(codes.Error, "key taken over")
+		span.RecordError(errx.ErrIdempotencyKeyTakenOver)
+		return errx.ErrIdempotencyKeyTakenOver
+	}
+
+	span.SetStatus(codes.Ok, "Response stored")
+	return nil
+}
+
+func (r *PostgreSQLIdempotencyKeyRepository) Release(ctx context.Context, request *models.IdempotentRequest) error {
+	ctx, span := r.tracer.Start(ctx, "Release", trace.WithAttributes(
+		attribute.String("member.id", request.MemberId),
+	))
+	defer span.End()
+
+	_, err := r.db.ExecContext(ctx, `DELETE FROM idempotency_keys WHERE member_id = $1 AND key = $2 AND created_at = $3`,
+		request.MemberId,
+		request.Key,
+		request.CreatedAt.UTC(),
+	)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return err
+	}
+
+	span.SetStatus(codes.Ok, "Key released")
+	return nil
+}
+
+func (r *PostgreSQLIdempotencyKeyRepository) DeleteExpired(ctx context.Context, now time.Time) (int, error) {
+	ctx, span := r.tracer.Start(ctx, "DeleteExpired")
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, `DELETE FROM idempotency_keys WHERE created_at <= $1`, now.Add(-models.IdempotencyKeyTTL).UTC())
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return 0, err
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return 0, err
+	}
+
+	span.SetAttributes(attribute.Int64("idempotency_keys.deleted", deleted))
+	span.SetStatus(codes.Ok, "Expired keys deleted")
+	return int(deleted), nil
+}
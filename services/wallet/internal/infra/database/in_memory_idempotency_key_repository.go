@@ -0,0 +1,89 @@
+package database
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+)
+
+// InMemoryIdempotencyKeyRepository is safe for concurrent use, retries of the
+// same request often arrive at the same time.
+type InMemoryIdempotencyKeyRepository struct {
+	mu    sync.Mutex
+	items []models.IdempotentRequest
+}
+
+func NewInMemoryIdempotencyKeyRepository() *InMemoryIdempotencyKeyRepository {
+	return &InMemoryIdempotencyKeyRepository{
+		items: []models.IdempotentRequest{},
+	}
+}
+
+func (r *InMemoryIdempotencyKeyRepository) Reserve(ctx context.Context, request *models.IdempotentRequest) (*models.IdempotentRequest, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	index := r.indexOf(request.MemberId, request.Key)
+	if index >= 0 && !r.items[index].IsExpired(now) && !r.items[index].IsAbandoned(now) {
+		existing := r.items[index]
+		existing.Body = slices.Clone(existing.Body)
+		return &existing, nil
+	}
+
+	if index >= 0 {
+		r.items = slices.Delete(r.items, index, index+1)
+	}
+	r.items = append(r.items, *request)
+	return nil, nil
+}
+
+func (r *InMemoryIdempotencyKeyRepository) Complete(ctx context.Context, request *models.IdempotentRequest) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored := *request
+	stored.Body = slices.Clone(request.Body)
+
+	index := r.indexOf(request.MemberId, request.Key)
+	if index < 0 || !r.items[index].CreatedAt.Equal(request.CreatedAt) {
+		return errx.ErrIdempotencyKeyTakenOver
+	}
+
+	r.items[index] = stored
+	return nil
+}
+
+func (r *InMemoryIdempotencyKeyRepository) Release(ctx context.Context, request *models.IdempotentRequest) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	index := r.indexOf(request.MemberId, request.Key)
+	if index >= 0 && r.items[index].CreatedAt.Equal(request.CreatedAt) {
+		r.items = slices.Delete(r.items, index, index+1)
+	}
+
+	return nil
+}
+
+func (r *InMemoryIdempotencyKeyRepository) DeleteExpired(ctx context.Context, now time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	count := len(r.items)
+	r.items = slices.DeleteFunc(r.items, func(item models.IdempotentRequest) bool {
+		return item.IsExpired(now)
+	})
+
+	return count - len(r.items), nil
+}
+
+func (r *InMemoryIdempotencyKeyRepository) indexOf(memberId, key string) int {
+	return slices.IndexFunc(r.items, func(item models.IdempotentRequest) bool {
+		return item.MemberId == memberId && item.Key == key
+	})
+}
@@ -0,0 +1,87 @@
+package database
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/events"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+)
+
+type InMemoryInvitationRepository struct {
+	items     []models.Invitation
+	publisher events.EventPublisher
+}
+
+func NewInMemoryInvitationRepository(publisher events.EventPublisher) *InMemoryInvitationRepository {
+	return &InMemoryInvitationRepository{
+		items:     []models.Invitation{},
+		publisher: publisher,
+	}
+}
+
+func (r *InMemoryInvitationRepository) FindById(ctx context.Context, id string) (*models.Invitation, error) {
+	return r.find(func(invitation models.Invitation) bool { return invitation.Id == id })
+}
+
+func (r *InMemoryInvitationRepository) FindByToken(ctx context.Context, token string) (*models.Invitation, error) {
+	return r.find(func(invitation models.Invitation) bool { return invitation.Token == token })
+}
+
+func (r *InMemoryInvitationRepository) FindPending(ctx context.Context, walletId, email string) (*models.Invitation, error) {
+	email = models.NormalizeEmail(email)
+
+	// Newer invitations are appended last
+	for i := len(r.items) - 1; i >= 0; i-- {
+		item := r.items[i]
+		if item.WalletId == walletId && item.Email == email && item.IsPending() {
+			return &item, nil
+		}
+	}
+
+	return nil, errx.ErrInvitationNotFound
+}
+
+func (r *InMemoryInvitationRepository) FindPendingFor(ctx context.Context, member models.Member) ([]models.Invitation, error) {
+	invitations := []models.Invitation{}
+	for i := len(r.items) - 1; i >= 0; i-- {
+		item := r.items[i]
+		if item.IsPending() && item.IsFor(member) {
+			invitations = append(invitations, item)
+		}
+	}
+
+	return invitations, nil
+}
+
+func (r *InMemoryInvitationRepository) Save(ctx context.Context, invitation *models.Invitation) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+
+	if err := r.publisher.Publish(ctx, invitation.Events()); err != nil {
+		slog.Error("error publishing events", slog.String("error", err.Error()))
+	}
+	invitation.ClearEvents()
+
+	for i, item := range r.items {
+		if item.Id == invitation.Id {
+			r.items[i] = *invitation
+			return nil
+		}
+	}
+
+	r.items = append(r.items, *invitation)
+	return nil
+}
+
+func (r *InMemoryInvitationRepository) find(match func(models.Invitation) bool) (*models.Invitation, error) {
+	for _, item := range r.items {
+		if match(item) {
+			return &item, nil
+		}
+	}
+
+	return nil, errx.ErrInvitationNotFound
+}
@@ -3,7 +3,9 @@ package database
 import (
 	"context"
 	"fmt"
+	"slices"
 
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
 	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/events"
 	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
 )
@@ -31,12 +33,23 @@ func (r InMemoryMemberRepository) FindByID(ctx context.Context, id string) (*mod
 	}
 
 	if member.Id == "" {
-		return nil, fmt.Errorf("member not found")
+		return nil, errx.ErrNotFound
 	}
 
 	return &member, nil
 }
 
+func (r InMemoryMemberRepository) FindByIDs(ctx context.Context, ids []string) ([]models.Member, error) {
+	members := []models.Member{}
+	for _, u := range r.Items {
+		if slices.Contains(ids, u.Id) {
+			members = append(members, u)
+		}
+	}
+
+	return members, nil
+}
+
 func (r InMemoryMemberRepository) FindByEmail(ctx context.Context, email string) (*models.Member, error) {
 	var member models.Member
 
@@ -48,12 +61,28 @@ func (r InMemoryMemberRepository) FindByEmail(ctx context.Context, email string)
 	}
 
 	if member.Id == "" {
-		return nil, fmt.Errorf("member not found")
+		return nil, errx.ErrNotFound
 	}
 
 	return &member, nil
 }
 
+func (r *InMemoryMemberRepository) Upsert(ctx context.Context, member *models.Member) error {
+	for i, u := range r.Items {
+		if u.Id != member.Id {
+			continue
+		}
+
+		if !member.LastChangedAt().Before(u.LastChangedAt()) {
+			r.Items[i] = *member
+		}
+		return nil
+	}
+
+	r.Items = append(r.Items, *member)
+	return nil
+}
+
 func (r *InMemoryMemberRepository) ValidateToken(ctx context.Context, token string) (*models.Member, error) {
 	return nil, fmt.Errorf("token validation not implemented in in-memory repository")
 }
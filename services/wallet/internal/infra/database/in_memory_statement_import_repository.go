@@ -0,0 +1,83 @@
+package database
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+)
+
+type InMemoryStatementImportRepository struct {
+	items []models.StatementImport
+}
+
+func NewInMemoryStatementImportRepository() *InMemoryStatementImportRepository {
+	return &InMemoryStatementImportRepository{
+		items: []models.StatementImport{},
+	}
+}
+
+func (r *InMemoryStatementImportRepository) FindById(ctx context.Context, id string) (*models.StatementImport, error) {
+	for _, item := range r.items {
+		if item.Id == id {
+			statementImport := item
+			statementImport.Rows = slices.Clone(item.Rows)
+			return &statementImport, nil
+		}
+	}
+
+	return nil, errx.ErrStatementImportNotFound
+}
+
+func (r *InMemoryStatementImportRepository) Save(ctx context.Context, statementImport *models.StatementImport) error {
+	stored := *statementImport
+	stored.Rows = slices.Clone(statementImport.Rows)
+
+	for i, item := range r.items {
+		if item.Id == statementImport.Id {
+			r.items[i] = stored
+			return nil
+		}
+	}
+
+	r.items = append(r.items, stored)
+	return nil
+}
+
+func (r *InMemoryStatementImportRepository) FindCommittedRows(ctx context.Context, walletId string, from, to time.Time) ([]models.StatementRow, error) {
+	rows := []models.StatementRow{}
+	for _, item := range r.items {
+		if item.WalletId != walletId || item.Status != models.StatementImportStatusCommitted {
+			continue
+		}
+
+		for _, row := range item.Rows {
+			if row.TransactionId != nil && !row.Date.Before(from) && !row.Date.After(to) {
+				rows = append(rows, row)
+			}
+		}
+	}
+
+	return rows, nil
+}
+
+func (r *InMemoryStatementImportRepository) FindImportedExternalIds(ctx context.Context, walletId string, externalIds []string) (map[string]string, error) {
+	imported := map[string]string{}
+	for _, item := range r.items {
+		if item.WalletId != walletId || item.Status != models.StatementImportStatusCommitted {
+			continue
+		}
+
+		for _, row := range item.Rows {
+			if row.TransactionId != nil && row.ExternalId != "" && slices.Contains(externalIds, row.ExternalId) {
+				if _, ok := imported[row.ExternalId]; !ok {
+					imported[row.ExternalId] = *row.TransactionId
+				}
+			}
+		}
+	}
+
+	return imported, nil
+}
@@ -2,24 +2,34 @@ package database
 
 import (
 	"context"
-	"fmt"
 	"log/slog"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
 	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/events"
 	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/repository"
 )
 
 type InMemoryWalletRepository struct {
 	items     []models.Wallet
+	histories map[string]*models.BalanceHistory
 	publisher events.EventPublisher
+	// Saved along with the wallets
+	invitations      *InMemoryInvitationRepository
+	statementImports *InMemoryStatementImportRepository
 }
 
 func NewInMemoryWalletRepository(publisher events.EventPublisher) *InMemoryWalletRepository {
 	return &InMemoryWalletRepository{
-		items:     []models.Wallet{},
-		publisher: publisher,
+		items:            []models.Wallet{},
+		histories:        map[string]*models.BalanceHistory{},
+		publisher:        publisher,
+		invitations:      NewInMemoryInvitationRepository(publisher),
+		statementImports: NewInMemoryStatementImportRepository(),
 	}
 }
 
@@ -34,50 +44,370 @@ func (r *InMemoryWalletRepository) Save(ctx context.Context, wallet *models.Wall
 	// Handling update
 	for i, w := range r.items {
 		if w.Id == wallet.Id {
+			if w.Version != wallet.Version {
+				return errx.ErrWalletConflict
+			}
+			wallet.Version++
+			r.moveSnapshots(wallet)
+			wallet.ClearChanges()
+
 			if err := r.publisher.Publish(ctx, wallet.Events()); err != nil {
 				slog.Error("error publishing events", slog.String("error", err.Error()))
 			}
 			wallet.ClearEvents()
 
-			r.items[i] = *wallet
+			r.items[i] = cloneWallet(*wallet)
 			return nil
 		}
 	}
 
+	if wallet.Version != 0 {
+		return errx.ErrWalletConflict
+	}
+	wallet.Version = 1
+	r.moveSnapshots(wallet)
+	wallet.ClearChanges()
+
 	if err := r.publisher.Publish(ctx, wallet.Events()); err != nil {
 		slog.Error("error publishing events", slog.String("error", err.Error()))
 	}
 	wallet.ClearEvents()
 
-	r.items = append(r.items, *wallet)
+	r.items = append(r.items, cloneWallet(*wallet))
+	return nil
+}
+
+func (r *InMemoryWalletRepository) SaveAll(ctx context.Context, wallets ...*models.Wallet) error {
+	for _, wallet := range wallets {
+		if err := r.Save(ctx, wallet); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// SaveWithInvitation only saves the invitation once the wallet is saved.
+func (r *InMemoryWalletRepository) SaveWithInvitation(ctx context.Context, wallet *models.Wallet, invitation *models.Invitation) error {
+	if err := r.Save(ctx, wallet); err != nil {
+		return err
+	}
+
+	return r.invitations.Save(ctx, invitation)
+}
+
+// SaveWithStatementImport only saves the import once the wallet is saved.
+func (r *InMemoryWalletRepository) SaveWithStatementImport(ctx context.Context, wallet *models.Wallet, statementImport *models.StatementImport) error {
+	if err := r.Save(ctx, wallet); err != nil {
+		return err
+	}
+
+	return r.statementImports.Save(ctx, statementImport)
+}
+
 func (r InMemoryWalletRepository) FindById(ctx context.Context, id string) (*models.Wallet, error) {
 	var wallet models.Wallet
 
 	for _, w := range r.items {
 		if w.Id == id {
-			wallet = w
+			wallet = cloneWallet(w)
 			break
 		}
 	}
 
 	if wallet.Id == "" {
-		return nil, fmt.Errorf("wallet not found")
+		return nil, errx.ErrNotFound
 	}
 
 	return &wallet, nil
 }
 
-func (r InMemoryWalletRepository) FindByUserId(ctx context.Context, userId string) ([]models.Wallet, error) {
-	var wallets []models.Wallet
+func (r InMemoryWalletRepository) FindDetailById(ctx context.Context, id string) (*models.WalletDetail, error) {
+	wallet, err := r.FindById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.WalletDetail{
+		Id:         wallet.Id,
+		Name:       wallet.Name,
+		CreatorId:  wallet.CreatorId,
+		Balance:    wallet.Balance,
+		Members:    wallet.Members,
+		Categories: wallet.Categories,
+		CreatedAt:  wallet.CreatedAt,
+		UpdatedAt:  wallet.UpdatedAt,
+	}, nil
+}
+
+func (r InMemoryWalletRepository) FindSummariesByUserId(ctx context.Context, userId string) ([]models.WalletSummary, error) {
+	wallets := []models.WalletSummary{}
 
 	for _, wallet := range r.items {
-		if wallet.IsMemberAllowedToRegisterTransactions(userId) {
-			wallets = append(wallets, wallet)
+		role, ok := wallet.MemberRole(userId)
+		if !ok {
+			continue
+		}
+
+		summary := models.WalletSummary{
+			Id:        wallet.Id,
+			Name:      wallet.Name,
+			CreatorId: wallet.CreatorId,
+			Balance:   wallet.Balance,
+			Role:      role,
+			CreatedAt: wallet.CreatedAt,
+			UpdatedAt: wallet.UpdatedAt,
 		}
+
+		for _, transaction := range wallet.Transactions {
+			if transaction.IsActive() {
+				summary.TransactionCount++
+			}
+
+			activity := transaction.CreatedAt
+			if transaction.UpdatedAt != nil {
+				activity = *transaction.UpdatedAt
+			}
+			if summary.LastActivityAt == nil || activity.After(*summary.LastActivityAt) {
+				summary.LastActivityAt = &activity
+			}
+		}
+
+		wallets = append(wallets, summary)
 	}
 
 	return wallets, nil
 }
+
+func (r InMemoryWalletRepository) FindDueRecurringTransactions(ctx context.Context, until time.Time) ([]models.RecurringTransaction, error) {
+	var recurringTransactions []models.RecurringTransaction
+
+	for _, wallet := range r.items {
+		for _, recurring := range wallet.RecurringTransactions {
+			if recurring.IsDue(until) {
+				recurringTransactions = append(recurringTransactions, recurring)
+			}
+		}
+	}
+
+	return recurringTransactions, nil
+}
+
+func (r InMemoryWalletRepository) FindMemberRole(ctx context.Context, walletId, memberId string) (models.WalletRole, error) {
+	for _, wallet := range r.items {
+		if wallet.Id != walletId {
+			continue
+		}
+
+		role, ok := wallet.MemberRole(memberId)
+		if !ok {
+			return "", errx.ErrMemberNotInWallet
+		}
+		return role, nil
+	}
+
+	return "", errx.ErrNotFound
+}
+
+func (r InMemoryWalletRepository) ListTransactions(ctx context.Context, walletId string, filter repository.TransactionFilter) (*repository.TransactionPage, error) {
+	var cursor *repository.TransactionCursor
+	if filter.Cursor != "" {
+		decoded, err := repository.DecodeTransactionCursor(filter.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		cursor = decoded
+	}
+
+	page := &repository.TransactionPage{Transactions: []models.Transaction{}}
+	for _, wallet := range r.items {
+		if wallet.Id != walletId {
+			continue
+		}
+
+		for _, transaction := range wallet.Transactions {
+			if filter.Matches(transaction) && (cursor == nil || cursor.After(transaction)) {
+				page.Transactions = append(page.Transactions, transaction)
+			}
+		}
+	}
+
+	slices.SortFunc(page.Transactions, func(a, b models.Transaction) int {
+		if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(b.Id, a.Id)
+	})
+
+	limit := filter.PageSize()
+	if len(page.Transactions) > limit {
+		page.Transactions = page.Transactions[:limit]
+		last := page.Transactions[limit-1]
+		page.NextCursor = repository.TransactionCursor{CreatedAt: last.CreatedAt, Id: last.Id}.Encode()
+	}
+
+	return page, nil
+}
+
+func (r InMemoryWalletRepository) AggregateEvolution(ctx context.Context, walletId string, query repository.EvolutionQuery) (*repository.EvolutionAggregates, error) {
+	wallet, err := r.FindById(ctx, walletId)
+	if err != nil {
+		return nil, err
+	}
+
+	opening, err := r.BalanceAt(ctx, walletId, query.From)
+	if err != nil {
+		return nil, err
+	}
+
+	zero := models.Monetary{Offset: wallet.Balance.Offset, Currency: wallet.Balance.Currency}
+	aggregates := &repository.EvolutionAggregates{Opening: opening, Totals: []models.EvolutionTotals{}}
+	coverage := query.Granularity.SnapshotCoverage(query.Location, query.From, query.To)
+
+	for _, snapshot := range r.history(wallet).Snapshots {
+		if coverage.Covers(snapshot.Day) {
+			aggregates.Totals = append(aggregates.Totals, models.EvolutionTotals{
+				Start:     snapshot.Day,
+				Income:    snapshot.Income,
+				Outcome:   snapshot.Outcome,
+				Transfers: snapshot.Transfers,
+			})
+		}
+	}
+
+	totals := map[time.Time]*models.EvolutionTotals{}
+	for _, transaction := range wallet.Transactions {
+		if !transaction.IsActive() || transaction.CreatedAt.Before(query.From) || !transaction.CreatedAt.Before(query.To) || coverage.Covers(transaction.CreatedAt) {
+			continue
+		}
+
+		start := query.Granularity.BucketStart(transaction.CreatedAt, query.Location)
+		total, ok := totals[start]
+		if !ok {
+			total = &models.EvolutionTotals{Start: start, Income: zero, Outcome: zero, Transfers: zero}
+			totals[start] = total
+		}
+
+		switch {
+		case transaction.IsTransfer():
+			total.Transfers = total.Transfers.Sum(transaction.BalanceEffect())
+		case transaction.Type == models.TransactionTypeDeposit:
+			total.Income = total.Income.Sum(transaction.Amount)
+		case transaction.Type == models.TransactionTypeWithdraw:
+			total.Outcome = total.Outcome.Sum(transaction.Amount)
+		}
+	}
+
+	for _, total := range totals {
+		aggregates.Totals = append(aggregates.Totals, *total)
+	}
+
+	return aggregates, nil
+}
+
+func (r InMemoryWalletRepository) BalanceAt(ctx context.Context, walletId string, at time.Time) (models.Monetary, error) {
+	wallet, err := r.FindById(ctx, walletId)
+	if err != nil {
+		return models.Monetary{}, err
+	}
+
+	day := models.SnapshotDay(at)
+	balance := r.history(wallet).BalanceAt(day)
+	for _, transaction := range wallet.Transactions {
+		if !transaction.IsActive() || transaction.CreatedAt.Before(day) || !transaction.CreatedAt.Before(at) {
+			continue
+		}
+
+		balance = balance.Sum(transaction.BalanceEffect())
+	}
+
+	return balance, nil
+}
+
+func (r *InMemoryWalletRepository) RebuildBalanceSnapshots(ctx context.Context, walletId string) error {
+	wallet, err := r.FindById(ctx, walletId)
+	if err != nil {
+		return err
+	}
+
+	zero := models.Monetary{Offset: wallet.Balance.Offset, Currency: wallet.Balance.Currency}
+	r.histories[walletId] = models.NewBalanceHistory(walletId, zero, wallet.Transactions)
+	return nil
+}
+
+func (r InMemoryWalletRepository) ListIds(ctx context.Context) ([]string, error) {
+	ids := make([]string, len(r.items))
+	for i, item := range r.items {
+		ids[i] = item.Id
+	}
+
+	return ids, nil
+}
+
+// moveSnapshots applies the balance movements of the wallet to its snapshots
+// before the changes are cleared.
+func (r *InMemoryWalletRepository) moveSnapshots(wallet *models.Wallet) {
+	history := r.history(wallet)
+	for _, movement := range wallet.Changes().BalanceMovements {
+		if transaction, err := wallet.FindTransaction(movement.TransactionId); err == nil {
+			history.Apply(*transaction, movement.Reverted)
+		}
+	}
+	r.histories[wallet.Id] = history
+}
+
+func (r InMemoryWalletRepository) history(wallet *models.Wallet) *models.BalanceHistory {
+	if history, ok := r.histories[wallet.Id]; ok {
+		return history
+	}
+
+	return &models.BalanceHistory{
+		WalletId:  wallet.Id,
+		Zero:      models.Monetary{Offset: wallet.Balance.Offset, Currency: wallet.Balance.Currency},
+		Snapshots: []models.BalanceSnapshot{},
+	}
+}
+
+func (r InMemoryWalletRepository) StreamTransactions(ctx context.Context, walletId string, from, to *time.Time, fn func(models.Transaction) error) error {
+	wallet, err := r.FindById(ctx, walletId)
+	if err != nil {
+		return err
+	}
+
+	transactions := slices.DeleteFunc(wallet.Transactions, func(transaction models.Transaction) bool {
+		return !transaction.IsActive() ||
+			(from != nil && transaction.CreatedAt.Before(*from)) ||
+			(to != nil && !transaction.CreatedAt.Before(*to))
+	})
+	slices.SortFunc(transactions, func(a, b models.Transaction) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Id, b.Id)
+	})
+
+	for _, transaction := range transactions {
+		if err := fn(transaction); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// cloneWallet copies the wallet collections so callers can mutate a loaded
+// aggregate without changing the stored one before it is saved.
+func cloneWallet(wallet models.Wallet) models.Wallet {
+	wallet.Members = append([]models.WalletMember{}, wallet.Members...)
+	wallet.Transactions = append([]models.Transaction{}, wallet.Transactions...)
+	wallet.Categories = append([]models.Category{}, wallet.Categories...)
+	wallet.RecurringTransactions = append([]models.RecurringTransaction{}, wallet.RecurringTransactions...)
+	wallet.Budgets = append([]models.Budget{}, wallet.Budgets...)
+	for i, budget := range wallet.Budgets {
+		wallet.Budgets[i].Periods = append([]models.BudgetPeriod{}, budget.Periods...)
+		for j, period := range wallet.Budgets[i].Periods {
+			wallet.Budgets[i].Periods[j].NotifiedThresholds = append([]int{}, period.NotifiedThresholds...)
+		}
+	}
+	return wallet
+}
@@ -0,0 +1,232 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/lopesgabriel/tellawl/packages/tracing"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type PostgreSQLInvitationRepository struct {
+	db     *sql.DB
+	tracer trace.Tracer
+}
+
+func NewPostgreSQLInvitationRepository(db *sql.DB) *PostgreSQLInvitationRepository {
+	return &PostgreSQLInvitationRepository{
+		db:     db,
+		tracer: tracing.GetTracer("github.com/lopesgabriel/tellawl/services/wallet/internal/infra/database/postgresql/PostgreSQLInvitationRepository"),
+	}
+}
+
+const invitationColumns = `id, wallet_id, wallet_name, email, member_id, role, token, status, invited_by, created_at, expires_at, responded_at`
+
+func (r *PostgreSQLInvitationRepository) FindById(ctx context.Context, id string) (*models.Invitation, error) {
+	ctx, span := r.tracer.Start(ctx, "FindById", trace.WithAttributes(
+		attribute.String("invitation.id", id),
+	))
+	defer span.End()
+
+	invitation, err := r.findInvitation(ctx, `WHERE id = $1`, id)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetStatus(codes.Ok, "Invitation found")
+	return invitation, nil
+}
+
+func (r *PostgreSQLInvitationRepository) FindByToken(ctx context.Context, token string) (*models.Invitation, error) {
+	ctx, span := r.tracer.Start(ctx, "FindByToken")
+	defer span.End()
+
+	invitation, err := r.findInvitation(ctx, `WHERE token = $1`, token)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.String("invitation.id", invitation.Id))
+	span.SetStatus(codes.Ok, "Invitation found")
+	return invitation, nil
+}
+
+func (r *PostgreSQLInvitationRepository) FindPending(ctx context.Context, walletId, email string) (*models.Invitation, error) {
+	ctx, span := r.tracer.Start(ctx, "FindPending", trace.WithAttributes(
+		attribute.String("wallet.id", walletId),
+	))
+	defer span.End()
+
+	invitation, err := r.findInvitation(ctx, `WHERE wallet_id = $1 AND email = $2 AND status = 'pending'
+			  ORDER BY created_at DESC
+			  LIMIT 1`, walletId, models.NormalizeEmail(email))
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetStatus(codes.Ok, "Invitation found")
+	return invitation, nil
+}
+
+func (r *PostgreSQLInvitationRepository) FindPendingFor(ctx context.Context, member models.Member) ([]models.Invitation, error) {
+	ctx, span := r.tracer.Start(ctx, "FindPendingFor", trace.WithAttributes(
+		attribute.String("member.id", member.Id),
+	))
+	defer span.End()
+
+	rows, err := r.db.QueryContext(ctx, `SELECT `+invitationColumns+`
+			  FROM wallet_invitations
+			  WHERE status = 'pending' AND (member_id = $1 OR (member_id IS NULL AND email = $2))
+			  ORDER BY created_at DESC`,
+		member.Id,
+		models.NormalizeEmail(member.Email),
+	)
+	if err != nil {
+		span.SetStatus(codes.Error, "query failed")
+		span.RecordError(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	invitations := []models.Invitation{}
+	for rows.Next() {
+		invitation, err := scanInvitation(rows)
+		if err != nil {
+			span.SetStatus(codes.Error, "scan failed")
+			span.RecordError(err)
+			return nil, err
+		}
+		invitations = append(invitations, *invitation)
+	}
+	if err := rows.Err(); err != nil {
+		span.SetStatus(codes.Error, "scan failed")
+		span.RecordError(err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("invitations.count", len(invitations)))
+	span.SetStatus(codes.Ok, "Invitations found")
+	return invitations, nil
+}
+
+// Save upserts the invitation and writes its events to the outbox in the
+// same transaction.
+func (r *PostgreSQLInvitationRepository) Save(ctx context.Context, invitation *models.Invitation) error {
+	ctx, span := r.tracer.Start(ctx, "Save", trace.WithAttributes(
+		attribute.String("invitation.id", invitation.Id),
+		attribute.String("invitation.status", string(invitation.Status)),
+	))
+	defer span.End()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		span.SetStatus(codes.Error, "failed to begin transaction")
+		span.RecordError(err)
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := saveInvitation(ctx, tx, invitation); err != nil {
+		span.SetStatus(codes.Error, "failed to save invitation")
+		span.RecordError(err)
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		span.SetStatus(codes.Error, "failed to commit transaction")
+		span.RecordError(err)
+		return err
+	}
+	invitation.ClearEvents()
+
+	span.SetStatus(codes.Ok, "Invitation saved")
+	return nil
+}
+
+// saveInvitation upserts the invitation and writes its events to the outbox
+// within tx.
+func saveInvitation(ctx context.Context, tx *sql.Tx, invitation *models.Invitation) error {
+	_, err := tx.ExecContext(ctx, `INSERT INTO wallet_invitations (`+invitationColumns+`)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+			  ON CONFLICT (id) DO UPDATE SET member_id = EXCLUDED.member_id, status = EXCLUDED.status,
+			  responded_at = EXCLUDED.responded_at`,
+		invitation.Id,
+		invitation.WalletId,
+		invitation.WalletName,
+		invitation.Email,
+		invitation.MemberId,
+		string(invitation.Role),
+		invitation.Token,
+		string(invitation.Status),
+		invitation.InvitedBy,
+		invitation.CreatedAt,
+		invitation.ExpiresAt,
+		invitation.RespondedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	return writeOutbox(ctx, tx, invitation.Events())
+}
+
+func (r *PostgreSQLInvitationRepository) findInvitation(ctx context.Context, where string, args ...any) (*models.Invitation, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT `+invitationColumns+` FROM wallet_invitations `+where, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+		return nil, errx.ErrInvitationNotFound
+	}
+
+	return scanInvitation(rows)
+}
+
+func scanInvitation(rows *sql.Rows) (*models.Invitation, error) {
+	var invitation models.Invitation
+	var memberId sql.NullString
+	var respondedAt sql.NullTime
+
+	err := rows.Scan(
+		&invitation.Id,
+		&invitation.WalletId,
+		&invitation.WalletName,
+		&invitation.Email,
+		&memberId,
+		&invitation.Role,
+		&invitation.Token,
+		&invitation.Status,
+		&invitation.InvitedBy,
+		&invitation.CreatedAt,
+		&invitation.ExpiresAt,
+		&respondedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errx.ErrInvitationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if memberId.Valid {
+		invitation.MemberId = &memberId.String
+	}
+	if respondedAt.Valid {
+		invitation.RespondedAt = &respondedAt.Time
+	}
+
+	return &invitation, nil
+}
@@ -0,0 +1,284 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/lopesgabriel/tellawl/packages/tracing"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const memberBackfillPageSize = 500
+
+// PostgreSQLMemberRepository serves members from the local copy kept up to
+// date by member-service events. Members missing from it, e.g. created moments
+// ago whose event did not arrive yet, are fetched from member-service and
+// stored. remote may be nil, in which case only the local copy is used.
+type PostgreSQLMemberRepository struct {
+	db     *sql.DB
+	remote *HTTPMemberRepository
+	tracer trace.Tracer
+}
+
+func NewPostgreSQLMemberRepository(db *sql.DB, remote *HTTPMemberRepository) *PostgreSQLMemberRepository {
+	return &PostgreSQLMemberRepository{
+		db:     db,
+		remote: remote,
+		tracer: tracing.GetTracer("github.com/lopesgabriel/tellawl/services/wallet/internal/infra/database/postgresql/PostgreSQLMemberRepository"),
+	}
+}
+
+func (r *PostgreSQLMemberRepository) FindByID(ctx context.Context, id string) (*models.Member, error) {
+	ctx, span := r.tracer.Start(ctx, "FindByID", trace.WithAttributes(
+		attribute.String("member.id", id),
+	))
+	defer span.End()
+
+	member, err := r.findMember(ctx, `WHERE id = $1`, id)
+	if errors.Is(err, errx.ErrNotFound) && r.remote != nil {
+		span.AddEvent("Member not found locally, fetching from member-service")
+		member, err = r.remote.FindByID(ctx, id)
+		if err == nil {
+			r.storeFetched(ctx, span, member)
+		}
+	}
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetStatus(codes.Ok, "member found")
+	return member, nil
+}
+
+func (r *PostgreSQLMemberRepository) FindByEmail(ctx context.Context, email string) (*models.Member, error) {
+	ctx, span := r.tracer.Start(ctx, "FindByEmail", trace.WithAttributes(
+		attribute.String("member.email", email),
+	))
+	defer span.End()
+
+	member, err := r.findMember(ctx, `WHERE email = $1`, email)
+	if errors.Is(err, errx.ErrNotFound) && r.remote != nil {
+		span.AddEvent("Member not found locally, fetching from member-service")
+		member, err = r.remote.FindByEmail(ctx, email)
+		if err == nil {
+			r.storeFetched(ctx, span, member)
+		}
+	}
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetStatus(codes.Ok, "member found")
+	return member, nil
+}
+
+// FindByIDs returns the members with the given ids, ids that do not exist are
+// skipped. Members missing locally are fetched together from member-service.
+func (r *PostgreSQLMemberRepository) FindByIDs(ctx context.Context, ids []string) ([]models.Member, error) {
+	ctx, span := r.tracer.Start(ctx, "FindByIDs", trace.WithAttributes(
+		attribute.Int("members.ids", len(ids)),
+	))
+	defer span.End()
+
+	rows, err := r.db.QueryContext(ctx, `SELECT id, first_name, last_name, email, created_at, updated_at
+			  FROM members
+			  WHERE id = ANY($1)`, ids)
+	if err != nil {
+		span.SetStatus(codes.Error, "query failed")
+		span.RecordError(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	members := []models.Member{}
+	for rows.Next() {
+		member, err := scanMember(rows)
+		if err != nil {
+			span.SetStatus(codes.Error, "scan failed")
+			span.RecordError(err)
+			return nil, err
+		}
+		members = append(members, *member)
+	}
+	if err := rows.Err(); err != nil {
+		span.SetStatus(codes.Error, "scan failed")
+		span.RecordError(err)
+		return nil, err
+	}
+
+	missing := slices.DeleteFunc(slices.Clone(ids), func(id string) bool {
+		return slices.ContainsFunc(members, func(m models.Member) bool { return m.Id == id })
+	})
+	if len(missing) > 0 && r.remote != nil {
+		span.AddEvent("Members not found locally, fetching from member-service", trace.WithAttributes(
+			attribute.Int("members.missing", len(missing)),
+		))
+
+		fetched, err := r.remote.FindByIDs(ctx, missing)
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+			return nil, err
+		}
+
+		for i := range fetched {
+			r.storeFetched(ctx, span, &fetched[i])
+		}
+		members = append(members, fetched...)
+	}
+
+	span.SetStatus(codes.Ok, "members found")
+	return members, nil
+}
+
+// ValidateToken always asks member-service, tokens are not projected.
+func (r *PostgreSQLMemberRepository) ValidateToken(ctx context.Context, token string) (*models.Member, error) {
+	if r.remote == nil {
+		return nil, fmt.Errorf("token validation requires the member service")
+	}
+
+	return r.remote.ValidateToken(ctx, token)
+}
+
+// Upsert stores the member unless the stored copy changed more recently, so
+// events delivered out of order never overwrite newer data.
+func (r *PostgreSQLMemberRepository) Upsert(ctx context.Context, member *models.Member) error {
+	ctx, span := r.tracer.Start(ctx, "Upsert", trace.WithAttributes(
+		attribute.String("member.id", member.Id),
+	))
+	defer span.End()
+
+	_, err := r.db.ExecContext(ctx, `INSERT INTO members (id, first_name, last_name, email, created_at, updated_at, synced_at)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7)
+			  ON CONFLICT (id) DO UPDATE SET
+				first_name = EXCLUDED.first_name,
+				last_name = EXCLUDED.last_name,
+				email = EXCLUDED.email,
+				updated_at = EXCLUDED.updated_at,
+				synced_at = EXCLUDED.synced_at
+			  WHERE COALESCE(EXCLUDED.updated_at, EXCLUDED.created_at) >= COALESCE(members.updated_at, members.created_at)`,
+		member.Id, member.FirstName, member.LastName, member.Email, member.CreatedAt, member.UpdatedAt, time.Now(),
+	)
+	if err != nil {
+		span.SetStatus(codes.Error, "failed to upsert member")
+		span.RecordError(err)
+		return err
+	}
+
+	span.SetStatus(codes.Ok, "member stored")
+	return nil
+}
+
+// Backfill copies every member of member-service, page by page, the first
+// time the service starts. It is safe to run while events are consumed since
+// the most recent copy of each member wins. Returns how many members were
+// copied, zero when the backfill already ran.
+func (r *PostgreSQLMemberRepository) Backfill(ctx context.Context) (int, error) {
+	ctx, span := r.tracer.Start(ctx, "Backfill")
+	defer span.End()
+
+	if r.remote == nil {
+		span.SetStatus(codes.Ok, "no member service configured")
+		return 0, nil
+	}
+
+	var completed bool
+	err := r.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM member_backfills)`).Scan(&completed)
+	if err != nil {
+		span.SetStatus(codes.Error, "failed to check the backfill")
+		span.RecordError(err)
+		return 0, err
+	}
+
+	if completed {
+		span.SetStatus(codes.Ok, "already backfilled")
+		return 0, nil
+	}
+
+	count := 0
+	after := ""
+	for {
+		page, err := r.remote.List(ctx, after, memberBackfillPageSize)
+		if err != nil {
+			span.SetStatus(codes.Error, "failed to list members")
+			span.RecordError(err)
+			return count, err
+		}
+
+		for i := range page {
+			if err := r.Upsert(ctx, &page[i]); err != nil {
+				span.SetStatus(codes.Error, "failed to store member")
+				span.RecordError(err)
+				return count, err
+			}
+		}
+
+		count += len(page)
+		if len(page) < memberBackfillPageSize {
+			break
+		}
+		after = page[len(page)-1].Id
+	}
+
+	_, err = r.db.ExecContext(ctx, `INSERT INTO member_backfills (completed_at) VALUES ($1) ON CONFLICT DO NOTHING`, time.Now())
+	if err != nil {
+		span.SetStatus(codes.Error, "failed to record the backfill")
+		span.RecordError(err)
+		return count, err
+	}
+
+	span.SetAttributes(attribute.Int("members.count", count))
+	span.SetStatus(codes.Ok, "members backfilled")
+	return count, nil
+}
+
+func (r *PostgreSQLMemberRepository) findMember(ctx context.Context, where string, arg any) (*models.Member, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT id, first_name, last_name, email, created_at, updated_at
+			  FROM members `+where, arg)
+
+	member, err := scanMember(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errx.ErrNotFound
+	}
+
+	return member, err
+}
+
+func scanMember(row interface{ Scan(dest ...any) error }) (*models.Member, error) {
+	var member models.Member
+	var updatedAt sql.NullTime
+
+	err := row.Scan(
+		&member.Id,
+		&member.FirstName,
+		&member.LastName,
+		&member.Email,
+		&member.CreatedAt,
+		&updatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if updatedAt.Valid {
+		member.UpdatedAt = &updatedAt.Time
+	}
+
+	return &member, nil
+}
+
+// storeFetched keeps a member fetched from member-service for the next
+// lookups. Failing to store it does not fail the lookup.
+func (r *PostgreSQLMemberRepository) storeFetched(ctx context.Context, span trace.Span, member *models.Member) {
+	if err := r.Upsert(ctx, member); err != nil {
+		span.RecordError(err)
+	}
+}
@@ -0,0 +1,210 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lopesgabriel/tellawl/packages/tracing"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/events"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/repository"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// outboxRelayLock is the advisory lock held by the relay publishing the
+// outbox, so replicas do not publish the same messages or reorder them.
+const outboxRelayLock = 7_263_541_001
+
+type PostgreSQLOutboxRepository struct {
+	db     *sql.DB
+	tracer trace.Tracer
+}
+
+func NewPostgreSQLOutboxRepository(db *sql.DB) *PostgreSQLOutboxRepository {
+	return &PostgreSQLOutboxRepository{
+		db:     db,
+		tracer: tracing.GetTracer("github.com/lopesgabriel/tellawl/services/wallet/internal/infra/database/postgresql/PostgreSQLOutboxRepository"),
+	}
+}
+
+// writeOutbox stores the events in the outbox as part of tx, they are only
+// published once it commits.
+func writeOutbox(ctx context.Context, tx *sql.Tx, domainEvents []events.DomainEvent) error {
+	for _, event := range domainEvents {
+		message, err := models.NewOutboxMessage(event)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `INSERT INTO outbox_events (event_id, event_type, aggregate_id, payload, occurred_at, created_at)
+				  VALUES ($1, $2, $3, $4, $5, $6)`,
+			message.EventId,
+			message.EventType,
+			message.AggregateId,
+			message.Payload,
+			message.OccurredAt.UTC(),
+			message.CreatedAt.UTC(),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *PostgreSQLOutboxRepository) Relay(ctx context.Context, limit, maxAttempts int, publish func(ctx context.Context, message models.OutboxMessage) error) (int, error) {
+	ctx, span := r.tracer.Start(ctx, "Relay", trace.WithAttributes(
+		attribute.Int("outbox.limit", limit),
+		attribute.Int("outbox.max_attempts", maxAttempts),
+	))
+	defer span.End()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		span.SetStatus(codes.Error, "failed to begin transaction")
+		span.RecordError(err)
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var locked bool
+	if err := tx.QueryRowContext(ctx, `SELECT pg_try_advisory_xact_lock($1)`, outboxRelayLock).Scan(&locked); err != nil {
+		span.SetStatus(codes.Error, "failed to acquire the relay lock")
+		span.RecordError(err)
+		return 0, err
+	}
+	if !locked {
+		span.SetStatus(codes.Ok, "Another relay is running")
+		return 0, nil
+	}
+
+	messages, err := r.findPending(ctx, tx, limit)
+	if err != nil {
+		span.SetStatus(codes.Error, "failed to load pending messages")
+		span.RecordError(err)
+		return 0, err
+	}
+
+	published := 0
+	var publishErr error
+	for _, message := range messages {
+		if publishErr = publish(ctx, message); publishErr != nil {
+			message.RecordFailure(publishErr, maxAttempts)
+
+			var failedAt *time.Time
+			if message.FailedAt != nil {
+				utc := message.FailedAt.UTC()
+				failedAt = &utc
+			}
+
+			_, err := tx.ExecContext(ctx, `UPDATE outbox_events SET attempts = $2, last_error = $3, failed_at = $4 WHERE id = $1`,
+				message.Id,
+				message.Attempts,
+				message.LastError,
+				failedAt,
+			)
+			if err != nil {
+				span.SetStatus(codes.Error, "failed to record the failure")
+				span.RecordError(err)
+				return 0, err
+			}
+			break
+		}
+
+		_, err := tx.ExecContext(ctx, `UPDATE outbox_events SET sent_at = $2 WHERE id = $1`, message.Id, time.Now().UTC())
+		if err != nil {
+			span.SetStatus(codes.Error, "failed to mark the message as sent")
+			span.RecordError(err)
+			return 0, err
+		}
+		published++
+	}
+
+	// Messages already produced are delivered again if this commit fails,
+	// consumers tell them apart by the event id
+	if err := tx.Commit(); err != nil {
+		span.SetStatus(codes.Error, "failed to commit")
+		span.RecordError(err)
+		return 0, err
+	}
+
+	span.SetAttributes(attribute.Int("outbox.published", published))
+	if publishErr != nil {
+		span.SetStatus(codes.Error, "failed to publish")
+		span.RecordError(publishErr)
+		return published, publishErr
+	}
+
+	span.SetStatus(codes.Ok, "Outbox relayed")
+	return published, nil
+}
+
+func (r *PostgreSQLOutboxRepository) findPending(ctx context.Context, tx *sql.Tx, limit int) ([]models.OutboxMessage, error) {
+	rows, err := tx.QueryContext(ctx, `SELECT id, event_id, event_type, aggregate_id, payload, occurred_at, created_at, attempts, last_error
+			  FROM outbox_events
+			  WHERE sent_at IS NULL AND failed_at IS NULL
+			  ORDER BY id
+			  LIMIT $1`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	messages := []models.OutboxMessage{}
+	for rows.Next() {
+		var message models.OutboxMessage
+		var lastError sql.NullString
+
+		err := rows.Scan(
+			&message.Id,
+			&message.EventId,
+			&message.EventType,
+			&message.AggregateId,
+			&message.Payload,
+			&message.OccurredAt,
+			&message.CreatedAt,
+			&message.Attempts,
+			&lastError,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if lastError.Valid {
+			message.LastError = &lastError.String
+		}
+		messages = append(messages, message)
+	}
+
+	return messages, rows.Err()
+}
+
+func (r *PostgreSQLOutboxRepository) Stats(ctx context.Context) (repository.OutboxStats, error) {
+	ctx, span := r.tracer.Start(ctx, "Stats")
+	defer span.End()
+
+	var stats repository.OutboxStats
+	var oldest sql.NullTime
+
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FILTER (WHERE failed_at IS NULL),
+			         MIN(created_at) FILTER (WHERE failed_at IS NULL),
+			         COUNT(*) FILTER (WHERE failed_at IS NOT NULL)
+			  FROM outbox_events
+			  WHERE sent_at IS NULL`).Scan(&stats.Pending, &oldest, &stats.Failed)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return stats, err
+	}
+
+	if oldest.Valid {
+		stats.OldestPending = &oldest.Time
+	}
+
+	span.SetStatus(codes.Ok, "Outbox stats computed")
+	return stats, nil
+}
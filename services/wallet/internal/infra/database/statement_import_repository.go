@@ -0,0 +1,335 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/lopesgabriel/tellawl/packages/tracing"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type PostgreSQLStatementImportRepository struct {
+	db     *sql.DB
+	tracer trace.Tracer
+}
+
+func NewPostgreSQLStatementImportRepository(db *sql.DB) *PostgreSQLStatementImportRepository {
+	return &PostgreSQLStatementImportRepository{
+		db:     db,
+		tracer: tracing.GetTracer("github.com/lopesgabriel/tellawl/services/wallet/internal/infra/database/postgresql/PostgreSQLStatementImportRepository"),
+	}
+}
+
+const statementRowColumns = `r.line, r.entry_date, r.description, r.amount_value, r.amount_offset, r.amount_currency,
+			  r.type, r.error, r.external_id, r.duplicate_of, r.already_imported, r.transaction_id`
+
+func (r *PostgreSQLStatementImportRepository) FindById(ctx context.Context, id string) (*models.StatementImport, error) {
+	ctx, span := r.tracer.Start(ctx, "FindById", trace.WithAttributes(
+		attribute.String("statement_import.id", id),
+	))
+	defer span.End()
+
+	statementImport := &models.StatementImport{Rows: []models.StatementRow{}}
+	var committedAt sql.NullTime
+
+	err := r.db.QueryRowContext(ctx, `SELECT id, wallet_id, format, status, created_by, created_at, committed_at
+			  FROM statement_imports
+			  WHERE id = $1`, id).Scan(
+		&statementImport.Id,
+		&statementImport.WalletId,
+		&statementImport.Format,
+		&statementImport.Status,
+		&statementImport.CreatedBy,
+		&statementImport.CreatedAt,
+		&committedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			span.SetStatus(codes.Error, "Statement import not found")
+			return nil, errx.ErrStatementImportNotFound
+		}
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return nil, err
+	}
+	if committedAt.Valid {
+		statementImport.CommittedAt = &committedAt.Time
+	}
+
+	rows, err := r.db.QueryContext(ctx, `SELECT `+statementRowColumns+`
+			  FROM statement_import_rows r
+			  WHERE r.import_id = $1
+			  ORDER BY r.line`, id)
+	if err != nil {
+		span.SetStatus(codes.Error, "query failed")
+		span.RecordError(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		row, err := scanStatementRow(rows)
+		if err != nil {
+			span.SetStatus(codes.Error, "scan failed")
+			span.RecordError(err)
+			return nil, err
+		}
+		statementImport.Rows = append(statementImport.Rows, row)
+	}
+	if err := rows.Err(); err != nil {
+		span.SetStatus(codes.Error, "scan failed")
+		span.RecordError(err)
+		return nil, err
+	}
+
+	span.SetStatus(codes.Ok, "Statement import found")
+	return statementImport, nil
+}
+
+// Save upserts the import and its rows. Only the duplicate and transaction
+// references of a row change after it is parsed. Once committed, the bank
+// ids of the registered rows are remembered for the wallet.
+func (r *PostgreSQLStatementImportRepository) Save(ctx context.Context, statementImport *models.StatementImport) error {
+	ctx, span := r.tracer.Start(ctx, "Save", trace.WithAttributes(
+		attribute.String("statement_import.id", statementImport.Id),
+		attribute.Int("statement_import.rows", len(statementImport.Rows)),
+	))
+	defer span.End()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		span.SetStatus(codes.Error, "failed to begin transaction")
+		span.RecordError(err)
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := saveStatementImport(ctx, tx, statementImport); err != nil {
+		span.SetStatus(codes.Error, "failed to save statement import")
+		span.RecordError(err)
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		span.SetStatus(codes.Error, "failed to commit transaction")
+		span.RecordError(err)
+		return err
+	}
+
+	span.SetStatus(codes.Ok, "Statement import saved")
+	return nil
+}
+
+// saveStatementImport upserts the import and its rows within tx.
+func saveStatementImport(ctx context.Context, tx *sql.Tx, statementImport *models.StatementImport) error {
+	_, err := tx.ExecContext(ctx, `INSERT INTO statement_imports (id, wallet_id, format, status, created_by, created_at, committed_at)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7)
+			  ON CONFLICT (id) DO UPDATE SET status = EXCLUDED.status, committed_at = EXCLUDED.committed_at`,
+		statementImport.Id,
+		statementImport.WalletId,
+		string(statementImport.Format),
+		string(statementImport.Status),
+		statementImport.CreatedBy,
+		statementImport.CreatedAt,
+		statementImport.CommittedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	statement, err := tx.PrepareContext(ctx, `INSERT INTO statement_import_rows
+			  (import_id, line, entry_date, description, amount_value, amount_offset, amount_currency, type, error, external_id, duplicate_of, already_imported, transaction_id)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+			  ON CONFLICT (import_id, line) DO UPDATE SET duplicate_of = EXCLUDED.duplicate_of,
+			  already_imported = EXCLUDED.already_imported, transaction_id = EXCLUDED.transaction_id`)
+	if err != nil {
+		return err
+	}
+	defer statement.Close()
+
+	for _, row := range statementImport.Rows {
+		var date, amountValue, amountOffset, amountCurrency, transactionType, rowError, externalId any
+		if row.IsValid() {
+			date = row.Date
+			amountValue = row.Amount.Value
+			amountOffset = row.Amount.Offset
+			amountCurrency = row.Amount.Currency
+			transactionType = string(row.Type)
+		} else {
+			rowError = row.Error
+		}
+		if row.ExternalId != "" {
+			externalId = row.ExternalId
+		}
+
+		_, err := statement.ExecContext(ctx,
+			statementImport.Id,
+			row.Line,
+			date,
+			row.Description,
+			amountValue,
+			amountOffset,
+			amountCurrency,
+			transactionType,
+			rowError,
+			externalId,
+			row.DuplicateOf,
+			row.AlreadyImported,
+			row.TransactionId,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	if statementImport.Status == models.StatementImportStatusCommitted {
+		_, err = tx.ExecContext(ctx, `INSERT INTO statement_external_ids (wallet_id, external_id, transaction_id, import_id)
+				  SELECT $1, r.external_id, r.transaction_id, r.import_id
+				  FROM statement_import_rows r
+				  WHERE r.import_id = $2 AND r.external_id IS NOT NULL AND r.transaction_id IS NOT NULL
+				  ON CONFLICT (wallet_id, external_id) DO NOTHING`,
+			statementImport.WalletId,
+			statementImport.Id,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *PostgreSQLStatementImportRepository) FindCommittedRows(ctx context.Context, walletId string, from, to time.Time) ([]models.StatementRow, error) {
+	ctx, span := r.tracer.Start(ctx, "FindCommittedRows", trace.WithAttributes(
+		attribute.String("wallet.id", walletId),
+	))
+	defer span.End()
+
+	rows, err := r.db.QueryContext(ctx, `SELECT `+statementRowColumns+`
+			  FROM statement_import_rows r
+			  JOIN statement_imports i ON i.id = r.import_id
+			  WHERE i.wallet_id = $1 AND i.status = 'committed' AND r.transaction_id IS NOT NULL
+			  AND r.entry_date BETWEEN $2 AND $3`,
+		walletId,
+		from.UTC(),
+		to.UTC(),
+	)
+	if err != nil {
+		span.SetStatus(codes.Error, "query failed")
+		span.RecordError(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	committed := []models.StatementRow{}
+	for rows.Next() {
+		row, err := scanStatementRow(rows)
+		if err != nil {
+			span.SetStatus(codes.Error, "scan failed")
+			span.RecordError(err)
+			return nil, err
+		}
+		committed = append(committed, row)
+	}
+	if err := rows.Err(); err != nil {
+		span.SetStatus(codes.Error, "scan failed")
+		span.RecordError(err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("rows.count", len(committed)))
+	span.SetStatus(codes.Ok, "Committed rows found")
+	return committed, nil
+}
+
+func (r *PostgreSQLStatementImportRepository) FindImportedExternalIds(ctx context.Context, walletId string, externalIds []string) (map[string]string, error) {
+	ctx, span := r.tracer.Start(ctx, "FindImportedExternalIds", trace.WithAttributes(
+		attribute.String("wallet.id", walletId),
+		attribute.Int("external_ids.count", len(externalIds)),
+	))
+	defer span.End()
+
+	imported := map[string]string{}
+	if len(externalIds) == 0 {
+		return imported, nil
+	}
+
+	rows, err := r.db.QueryContext(ctx, `SELECT external_id, transaction_id
+			  FROM statement_external_ids
+			  WHERE wallet_id = $1 AND external_id = ANY($2)`,
+		walletId,
+		externalIds,
+	)
+	if err != nil {
+		span.SetStatus(codes.Error, "query failed")
+		span.RecordError(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var externalId, transactionId string
+		if err := rows.Scan(&externalId, &transactionId); err != nil {
+			span.SetStatus(codes.Error, "scan failed")
+			span.RecordError(err)
+			return nil, err
+		}
+		imported[externalId] = transactionId
+	}
+	if err := rows.Err(); err != nil {
+		span.SetStatus(codes.Error, "scan failed")
+		span.RecordError(err)
+		return nil, err
+	}
+
+	span.SetStatus(codes.Ok, "External ids found")
+	return imported, nil
+}
+
+func scanStatementRow(rows *sql.Rows) (models.StatementRow, error) {
+	var row models.StatementRow
+	var date sql.NullTime
+	var amountValue, amountOffset sql.NullInt64
+	var amountCurrency, transactionType, rowError, externalId, duplicateOf, transactionId sql.NullString
+
+	err := rows.Scan(
+		&row.Line,
+		&date,
+		&row.Description,
+		&amountValue,
+		&amountOffset,
+		&amountCurrency,
+		&transactionType,
+		&rowError,
+		&externalId,
+		&duplicateOf,
+		&row.AlreadyImported,
+		&transactionId,
+	)
+	if err != nil {
+		return row, err
+	}
+
+	if date.Valid {
+		year, month, day := date.Time.Date()
+		row.Date = time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	row.Amount = models.Monetary{Value: int(amountValue.Int64), Offset: int(amountOffset.Int64), Currency: amountCurrency.String}
+	row.Type = models.TransactionType(transactionType.String)
+	row.Error = rowError.String
+	row.ExternalId = externalId.String
+	if duplicateOf.Valid {
+		row.DuplicateOf = &duplicateOf.String
+	}
+	if transactionId.Valid {
+		row.TransactionId = &transactionId.String
+	}
+
+	return row, nil
+}
@@ -0,0 +1,102 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+)
+
+// StaticExchangeRateRepository serves a fixed set of rates, usually loaded
+// from the configuration. Inverse rates and rates crossing a single common
+// currency are derived from the configured ones.
+type StaticExchangeRateRepository struct {
+	Items []models.ExchangeRate
+}
+
+func NewStaticExchangeRateRepository(rates ...models.ExchangeRate) *StaticExchangeRateRepository {
+	return &StaticExchangeRateRepository{
+		Items: rates,
+	}
+}
+
+// ParseExchangeRates parses rates in the "USD/BRL=5.10,EUR/BRL=5.90" format,
+// each one being the price of one unit of the first currency in the second.
+func ParseExchangeRates(text string) ([]models.ExchangeRate, error) {
+	rates := []models.ExchangeRate{}
+
+	for entry := range strings.SplitSeq(text, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		pair, value, found := strings.Cut(entry, "=")
+		base, quote, ok := strings.Cut(pair, "/")
+		if !found || !ok {
+			return nil, fmt.Errorf("invalid exchange rate %q, expected BASE/QUOTE=RATE", entry)
+		}
+
+		rate, err := models.ParseExchangeRate(base, quote, value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exchange rate %q: %w", entry, err)
+		}
+
+		rates = append(rates, rate)
+	}
+
+	return rates, nil
+}
+
+func (r *StaticExchangeRateRepository) FindRate(ctx context.Context, base, quote string) (*models.ExchangeRate, error) {
+	if base == quote {
+		rate := models.IdentityRate(base)
+		return &rate, nil
+	}
+
+	if rate, ok := r.direct(base, quote); ok {
+		return &rate, nil
+	}
+
+	// Cross the currencies through a common one, e.g. USD/BRL and BRL/EUR
+	for _, item := range r.Items {
+		for _, pivot := range []string{item.Base, item.Quote} {
+			if pivot == base || pivot == quote {
+				continue
+			}
+
+			first, ok := r.direct(base, pivot)
+			if !ok {
+				continue
+			}
+
+			second, ok := r.direct(pivot, quote)
+			if !ok {
+				continue
+			}
+
+			rate, err := first.Then(second)
+			if err != nil {
+				return nil, err
+			}
+			return &rate, nil
+		}
+	}
+
+	return nil, errx.ErrExchangeRateNotFound
+}
+
+func (r *StaticExchangeRateRepository) direct(base, quote string) (models.ExchangeRate, bool) {
+	for _, item := range r.Items {
+		if item.Base == base && item.Quote == quote {
+			return item, true
+		}
+		if item.Base == quote && item.Quote == base {
+			return item.Inverse(), true
+		}
+	}
+
+	return models.ExchangeRate{}, false
+}
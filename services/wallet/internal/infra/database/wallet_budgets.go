@@ -0,0 +1,203 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func (r *PostgreSQLWalletRepository) loadWalletBudgets(ctx context.Context, walletId string) ([]models.Budget, error) {
+	ctx, span := r.tracer.Start(ctx, "loadWalletBudgets", trace.WithAttributes(
+		attribute.String("wallet.id", walletId),
+	))
+	defer span.End()
+
+	query := `SELECT b.id, b.wallet_id, b.category_id, b.limit_value, b.limit_offset, b.limit_currency,
+			  b.thresholds, b.created_at, b.updated_at
+			  FROM budgets b
+			  WHERE b.wallet_id = $1
+			  ORDER BY b.created_at`
+	rows, err := r.db.QueryContext(ctx, query, walletId)
+	if err != nil {
+		span.SetStatus(codes.Error, "query failed")
+		span.RecordError(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	budgets := []models.Budget{}
+	budgetIndexes := map[string]int{}
+
+	for rows.Next() {
+		var budget models.Budget
+		var categoryId sql.NullString
+		var thresholds []byte
+		var updatedAt sql.NullTime
+
+		err := rows.Scan(
+			&budget.Id,
+			&budget.WalletId,
+			&categoryId,
+			&budget.Limit.Value,
+			&budget.Limit.Offset,
+			&budget.Limit.Currency,
+			&thresholds,
+			&budget.CreatedAt,
+			&updatedAt,
+		)
+		if err != nil {
+			span.SetStatus(codes.Error, "scan failed")
+			span.RecordError(err)
+			return nil, err
+		}
+
+		if err := json.Unmarshal(thresholds, &budget.Thresholds); err != nil {
+			span.SetStatus(codes.Error, "invalid thresholds")
+			span.RecordError(err)
+			return nil, err
+		}
+		if categoryId.Valid {
+			budget.CategoryId = &categoryId.String
+		}
+		if updatedAt.Valid {
+			budget.UpdatedAt = &updatedAt.Time
+		}
+		budget.Periods = []models.BudgetPeriod{}
+
+		budgetIndexes[budget.Id] = len(budgets)
+		budgets = append(budgets, budget)
+	}
+	rows.Close()
+
+	query = `SELECT p.budget_id, p.period_start, p.spent_value, p.spent_offset, p.spent_currency, p.notified_thresholds
+			 FROM budget_periods p
+			 JOIN budgets b ON b.id = p.budget_id
+			 WHERE b.wallet_id = $1
+			 ORDER BY p.period_start`
+	periodRows, err := r.db.QueryContext(ctx, query, walletId)
+	if err != nil {
+		span.SetStatus(codes.Error, "query failed")
+		span.RecordError(err)
+		return nil, err
+	}
+	defer periodRows.Close()
+
+	for periodRows.Next() {
+		var budgetId string
+		var period models.BudgetPeriod
+		var notifiedThresholds []byte
+
+		err := periodRows.Scan(
+			&budgetId,
+			&period.Start,
+			&period.Spent.Value,
+			&period.Spent.Offset,
+			&period.Spent.Currency,
+			&notifiedThresholds,
+		)
+		if err != nil {
+			span.SetStatus(codes.Error, "scan failed")
+			span.RecordError(err)
+			return nil, err
+		}
+
+		if err := json.Unmarshal(notifiedThresholds, &period.NotifiedThresholds); err != nil {
+			span.SetStatus(codes.Error, "invalid notified thresholds")
+			span.RecordError(err)
+			return nil, err
+		}
+		period.Start = models.BudgetPeriodStart(period.Start)
+
+		if i, ok := budgetIndexes[budgetId]; ok {
+			budgets[i].Periods = append(budgets[i].Periods, period)
+		}
+	}
+
+	return budgets, nil
+}
+
+func (r *PostgreSQLWalletRepository) saveWalletBudgets(ctx context.Context, tx *sql.Tx, wallet *models.Wallet, changes models.WalletChanges) error {
+	ctx, span := r.tracer.Start(ctx, "saveWalletBudgets", trace.WithAttributes(
+		attribute.String("wallet.id", wallet.Id),
+	))
+	defer span.End()
+
+	// Periods are removed along with their budget
+	if len(changes.RemovedBudgets) > 0 {
+		_, err := tx.ExecContext(ctx, "DELETE FROM budgets WHERE wallet_id = $1 AND id = ANY($2)", wallet.Id, changes.RemovedBudgets)
+		if err != nil {
+			span.SetStatus(codes.Error, "failed to remove budgets")
+			span.RecordError(err)
+			return err
+		}
+	}
+
+	budgetRows := [][]any{}
+	periodRows := [][]any{}
+	for _, budget := range changedEntities(wallet.Budgets, changes.Budgets, func(b models.Budget) string { return b.Id }) {
+		thresholds, err := json.Marshal(budget.Thresholds)
+		if err != nil {
+			return err
+		}
+
+		budgetRows = append(budgetRows, []any{
+			budget.Id,
+			wallet.Id,
+			budget.CategoryId,
+			budget.Limit.Value,
+			budget.Limit.Offset,
+			budget.Limit.Currency,
+			thresholds,
+			budget.CreatedAt,
+			budget.UpdatedAt,
+		})
+
+		for _, period := range budget.Periods {
+			notifiedThresholds, err := json.Marshal(period.NotifiedThresholds)
+			if err != nil {
+				return err
+			}
+
+			periodRows = append(periodRows, []any{
+				budget.Id,
+				period.Start,
+				period.Spent.Value,
+				period.Spent.Offset,
+				period.Spent.Currency,
+				notifiedThresholds,
+			})
+		}
+	}
+
+	err := execBatch(ctx, tx, `INSERT INTO budgets (id, wallet_id, category_id, limit_value, limit_offset, limit_currency, thresholds, created_at, updated_at)
+			  VALUES %s
+			  ON CONFLICT (id) DO UPDATE SET
+			  limit_value = EXCLUDED.limit_value,
+			  limit_offset = EXCLUDED.limit_offset,
+			  thresholds = EXCLUDED.thresholds,
+			  updated_at = EXCLUDED.updated_at`, budgetRows)
+	if err != nil {
+		span.SetStatus(codes.Error, "failed to save budget")
+		span.RecordError(err)
+		return err
+	}
+
+	err = execBatch(ctx, tx, `INSERT INTO budget_periods (budget_id, period_start, spent_value, spent_offset, spent_currency, notified_thresholds)
+			  VALUES %s
+			  ON CONFLICT (budget_id, period_start) DO UPDATE SET
+			  spent_value = EXCLUDED.spent_value,
+			  spent_offset = EXCLUDED.spent_offset,
+			  notified_thresholds = EXCLUDED.notified_thresholds`, periodRows)
+	if err != nil {
+		span.SetStatus(codes.Error, "failed to save budget period")
+		span.RecordError(err)
+		return err
+	}
+
+	return nil
+}
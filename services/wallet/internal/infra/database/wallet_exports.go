@@ -0,0 +1,105 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// StreamTransactions reads the rows as they arrive from the database, only
+// their authors, looked up together beforehand, are kept in memory.
+func (r *PostgreSQLWalletRepository) StreamTransactions(ctx context.Context, walletId string, from, to *time.Time, fn func(models.Transaction) error) error {
+	ctx, span := r.tracer.Start(ctx, "StreamTransactions", trace.WithAttributes(
+		attribute.String("wallet.id", walletId),
+	))
+	defer span.End()
+
+	conditions := []string{"t.wallet_id = $1", "t.status = 'active'"}
+	args := []any{walletId}
+	if from != nil {
+		args = append(args, from.UTC())
+		conditions = append(conditions, fmt.Sprintf("t.created_at >= $%d", len(args)))
+	}
+	if to != nil {
+		args = append(args, to.UTC())
+		conditions = append(conditions, fmt.Sprintf("t.created_at < $%d", len(args)))
+	}
+
+	authorRows, err := r.db.QueryContext(ctx, `SELECT DISTINCT t.created_by
+			  FROM transactions t
+			  WHERE `+strings.Join(conditions, " AND "), args...)
+	if err != nil {
+		span.SetStatus(codes.Error, "query failed")
+		span.RecordError(err)
+		return err
+	}
+	defer authorRows.Close()
+
+	ids := []string{}
+	for authorRows.Next() {
+		var id string
+		if err := authorRows.Scan(&id); err != nil {
+			span.SetStatus(codes.Error, "scan failed")
+			span.RecordError(err)
+			return err
+		}
+		ids = append(ids, id)
+	}
+	if err := authorRows.Err(); err != nil {
+		span.SetStatus(codes.Error, "scan failed")
+		span.RecordError(err)
+		return err
+	}
+
+	authors, err := r.findMembers(ctx, ids)
+	if err != nil {
+		span.SetStatus(codes.Error, "failed to retrieve user data")
+		span.RecordError(err)
+		return err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `SELECT `+transactionColumns+`
+			  FROM transactions t
+			  WHERE `+strings.Join(conditions, " AND ")+`
+			  ORDER BY t.created_at, t.id`, args...)
+	if err != nil {
+		span.SetStatus(codes.Error, "query failed")
+		span.RecordError(err)
+		return err
+	}
+	defer rows.Close()
+
+	count := 0
+	for rows.Next() {
+		transaction, err := scanTransaction(rows)
+		if err != nil {
+			span.SetStatus(codes.Error, "scan failed")
+			span.RecordError(err)
+			return err
+		}
+
+		transaction.CreatedBy = authors[transaction.CreatedBy.Id]
+
+		if err := fn(transaction); err != nil {
+			span.SetStatus(codes.Error, "stream interrupted")
+			span.RecordError(err)
+			return err
+		}
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		span.SetStatus(codes.Error, "scan failed")
+		span.RecordError(err)
+		return err
+	}
+
+	span.SetAttributes(attribute.Int("transactions.count", count))
+	span.SetStatus(codes.Ok, "Transactions streamed")
+	return nil
+}
@@ -0,0 +1,197 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const recurringTransactionColumns = `r.id, r.wallet_id, r.amount_value, r.amount_offset, r.amount_currency, r.created_by,
+			  r.type, r.description, r.category_id, r.frequency, r.day_of_month, r.start_date, r.end_date,
+			  r.status, r.next_occurrence, r.last_occurrence, r.created_at, r.updated_at`
+
+func (r *PostgreSQLWalletRepository) FindDueRecurringTransactions(ctx context.Context, until time.Time) ([]models.RecurringTransaction, error) {
+	ctx, span := r.tracer.Start(ctx, "FindDueRecurringTransactions")
+	defer span.End()
+
+	query := `SELECT ` + recurringTransactionColumns + `
+			  FROM recurring_transactions r
+			  WHERE r.status = $1 AND r.next_occurrence <= $2
+			  ORDER BY r.next_occurrence`
+
+	rows, err := r.db.QueryContext(ctx, query, string(models.RecurringTransactionStatusActive), models.RecurrenceDate(until))
+	if err != nil {
+		span.SetStatus(codes.Error, "query failed")
+		span.RecordError(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	recurringTransactions, err := scanRecurringTransactions(rows)
+	if err != nil {
+		span.SetStatus(codes.Error, "scan failed")
+		span.RecordError(err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("recurring_transactions.due", len(recurringTransactions)))
+	span.SetStatus(codes.Ok, "Due recurring transactions found")
+	return recurringTransactions, nil
+}
+
+func (r *PostgreSQLWalletRepository) loadWalletRecurringTransactions(ctx context.Context, wallet *models.Wallet) ([]models.RecurringTransaction, error) {
+	ctx, span := r.tracer.Start(ctx, "loadWalletRecurringTransactions", trace.WithAttributes(
+		attribute.String("wallet.id", wallet.Id),
+	))
+	defer span.End()
+
+	query := `SELECT ` + recurringTransactionColumns + `
+			  FROM recurring_transactions r
+			  WHERE r.wallet_id = $1
+			  ORDER BY r.created_at`
+
+	rows, err := r.db.QueryContext(ctx, query, wallet.Id)
+	if err != nil {
+		span.SetStatus(codes.Error, "query failed")
+		span.RecordError(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	recurringTransactions, err := scanRecurringTransactions(rows)
+	if err != nil {
+		span.SetStatus(codes.Error, "scan failed")
+		span.RecordError(err)
+		return nil, err
+	}
+
+	// The authors are wallet members, which are already loaded
+	for i := range recurringTransactions {
+		for _, member := range wallet.Members {
+			if member.Id == recurringTransactions[i].CreatedBy.Id {
+				recurringTransactions[i].CreatedBy = member.Member
+				break
+			}
+		}
+	}
+
+	return recurringTransactions, nil
+}
+
+func (r *PostgreSQLWalletRepository) saveWalletRecurringTransactions(ctx context.Context, tx *sql.Tx, wallet *models.Wallet, changes models.WalletChanges) error {
+	ctx, span := r.tracer.Start(ctx, "saveWalletRecurringTransactions", trace.WithAttributes(
+		attribute.String("wallet.id", wallet.Id),
+	))
+	defer span.End()
+
+	rows := [][]any{}
+	for _, recurring := range changedEntities(wallet.RecurringTransactions, changes.RecurringTransactions, func(r models.RecurringTransaction) string { return r.Id }) {
+		rows = append(rows, []any{
+			recurring.Id,
+			wallet.Id,
+			recurring.Amount.Value,
+			recurring.Amount.Offset,
+			recurring.Amount.Currency,
+			recurring.CreatedBy.Id,
+			string(recurring.Type),
+			recurring.Description,
+			recurring.CategoryId,
+			string(recurring.Frequency),
+			recurring.DayOfMonth,
+			recurring.StartDate,
+			recurring.EndDate,
+			recurringTransactionStatus(recurring),
+			recurring.NextOccurrence,
+			recurring.LastOccurrence,
+			recurring.CreatedAt,
+			recurring.UpdatedAt,
+		})
+	}
+
+	// Definitions are never deleted, only their schedule state changes
+	err := execBatch(ctx, tx, `INSERT INTO recurring_transactions (id, wallet_id, amount_value, amount_offset, amount_currency, created_by,
+			  type, description, category_id, frequency, day_of_month, start_date, end_date,
+			  status, next_occurrence, last_occurrence, created_at, updated_at)
+			  VALUES %s
+			  ON CONFLICT (id) DO UPDATE SET
+			  category_id = EXCLUDED.category_id,
+			  status = EXCLUDED.status,
+			  next_occurrence = EXCLUDED.next_occurrence,
+			  last_occurrence = EXCLUDED.last_occurrence,
+			  updated_at = EXCLUDED.updated_at`, rows)
+	if err != nil {
+		span.SetStatus(codes.Error, "failed to save recurring transaction")
+		span.RecordError(err)
+		return err
+	}
+
+	return nil
+}
+
+func scanRecurringTransactions(rows *sql.Rows) ([]models.RecurringTransaction, error) {
+	recurringTransactions := []models.RecurringTransaction{}
+
+	for rows.Next() {
+		var recurring models.RecurringTransaction
+		var categoryId sql.NullString
+		var endDate, lastOccurrence, updatedAt sql.NullTime
+
+		err := rows.Scan(
+			&recurring.Id,
+			&recurring.WalletId,
+			&recurring.Amount.Value,
+			&recurring.Amount.Offset,
+			&recurring.Amount.Currency,
+			&recurring.CreatedBy.Id,
+			&recurring.Type,
+			&recurring.Description,
+			&categoryId,
+			&recurring.Frequency,
+			&recurring.DayOfMonth,
+			&recurring.StartDate,
+			&endDate,
+			&recurring.Status,
+			&recurring.NextOccurrence,
+			&lastOccurrence,
+			&recurring.CreatedAt,
+			&updatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if categoryId.Valid {
+			recurring.CategoryId = &categoryId.String
+		}
+		if endDate.Valid {
+			date := models.RecurrenceDate(endDate.Time)
+			recurring.EndDate = &date
+		}
+		if lastOccurrence.Valid {
+			date := models.RecurrenceDate(lastOccurrence.Time)
+			recurring.LastOccurrence = &date
+		}
+		if updatedAt.Valid {
+			recurring.UpdatedAt = &updatedAt.Time
+		}
+		recurring.StartDate = models.RecurrenceDate(recurring.StartDate)
+		recurring.NextOccurrence = models.RecurrenceDate(recurring.NextOccurrence)
+
+		recurringTransactions = append(recurringTransactions, recurring)
+	}
+
+	return recurringTransactions, rows.Err()
+}
+
+func recurringTransactionStatus(recurring models.RecurringTransaction) string {
+	if recurring.Status == "" {
+		return string(models.RecurringTransactionStatusActive)
+	}
+
+	return string(recurring.Status)
+}
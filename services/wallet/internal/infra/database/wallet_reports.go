@@ -0,0 +1,219 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/repository"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// AggregateEvolution reads the totals of the whole days falling into a
+// single bucket from the daily snapshots, and sums the active transactions
+// of the remaining days at the edges of the range and of the buckets.
+// Amounts are grouped by offset as well, values of different offsets are
+// only added up once they are normalised by models.Monetary.
+func (r *PostgreSQLWalletRepository) AggregateEvolution(ctx context.Context, walletId string, query repository.EvolutionQuery) (*repository.EvolutionAggregates, error) {
+	ctx, span := r.tracer.Start(ctx, "AggregateEvolution", trace.WithAttributes(
+		attribute.String("wallet.id", walletId),
+		attribute.String("report.granularity", string(query.Granularity)),
+		attribute.String("report.timezone", query.Location.String()),
+	))
+	defer span.End()
+
+	opening, err := r.BalanceAt(ctx, walletId, query.From)
+	if err != nil {
+		span.SetStatus(codes.Error, "failed to compute the opening balance")
+		span.RecordError(err)
+		return nil, err
+	}
+
+	aggregates := &repository.EvolutionAggregates{Opening: opening, Totals: []models.EvolutionTotals{}}
+	coverage := query.Granularity.SnapshotCoverage(query.Location, query.From, query.To)
+
+	snapshotTotals, err := r.aggregateSnapshots(ctx, walletId, coverage)
+	if err != nil {
+		span.SetStatus(codes.Error, "failed to read the snapshots")
+		span.RecordError(err)
+		return nil, err
+	}
+
+	transactionTotals, err := r.aggregateTransactions(ctx, walletId, query, coverage, opening.Currency)
+	if err != nil {
+		span.SetStatus(codes.Error, "failed to sum the transactions")
+		span.RecordError(err)
+		return nil, err
+	}
+
+	aggregates.Totals = append(snapshotTotals, transactionTotals...)
+
+	span.SetAttributes(attribute.Int("snapshots.count", len(snapshotTotals)))
+	span.SetStatus(codes.Ok, "Evolution aggregated")
+	return aggregates, nil
+}
+
+// aggregateSnapshots returns the totals of the days covered by the
+// snapshots, starting at midnight UTC of each day.
+func (r *PostgreSQLWalletRepository) aggregateSnapshots(ctx context.Context, walletId string, coverage models.SnapshotCoverage) ([]models.EvolutionTotals, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT `+balanceSnapshotColumns+`
+			  FROM wallet_balance_snapshots
+			  WHERE wallet_id = $1 AND day >= $2 AND day < $3 AND NOT (day = ANY($4))
+			  ORDER BY day`,
+		walletId,
+		coverage.First,
+		coverage.End,
+		coverage.Straddling,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	totals := []models.EvolutionTotals{}
+	for rows.Next() {
+		snapshot, err := scanBalanceSnapshot(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		totals = append(totals, models.EvolutionTotals{
+			Start:     snapshot.Day,
+			Income:    snapshot.Income,
+			Outcome:   snapshot.Outcome,
+			Transfers: snapshot.Transfers,
+		})
+	}
+
+	return totals, rows.Err()
+}
+
+// aggregateTransactions sums the active transactions of the range left out
+// of the coverage, by bucket.
+func (r *PostgreSQLWalletRepository) aggregateTransactions(ctx context.Context, walletId string, query repository.EvolutionQuery, coverage models.SnapshotCoverage, currency string) ([]models.EvolutionTotals, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT date_trunc($2, t.created_at AT TIME ZONE 'UTC' AT TIME ZONE $3) AS bucket, t.amount_offset,
+			  COALESCE(SUM(t.amount_value) FILTER (WHERE t.transfer_id IS NULL AND t.type = 'deposit'), 0)::bigint,
+			  COALESCE(SUM(t.amount_value) FILTER (WHERE t.transfer_id IS NULL AND t.type = 'withdraw'), 0)::bigint,
+			  COALESCE(SUM(CASE WHEN t.type = 'deposit' THEN t.amount_value ELSE -t.amount_value END) FILTER (WHERE t.transfer_id IS NOT NULL), 0)::bigint
+			  FROM transactions t
+			  WHERE t.wallet_id = $1 AND t.status = 'active' AND t.created_at >= $4 AND t.created_at < $5
+			  AND (t.created_at < $6 OR t.created_at >= $7 OR t.created_at::date = ANY($8))
+			  GROUP BY bucket, t.amount_offset`,
+		walletId,
+		string(query.Granularity),
+		query.Location.String(),
+		query.From.UTC(),
+		query.To.UTC(),
+		coverage.First,
+		coverage.End,
+		coverage.Straddling,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	totals := []models.EvolutionTotals{}
+	for rows.Next() {
+		var bucket time.Time
+		var offset, income, outcome, transfers int
+
+		if err := rows.Scan(&bucket, &offset, &income, &outcome, &transfers); err != nil {
+			return nil, err
+		}
+
+		// The bucket is the wall clock time in the report location
+		year, month, day := bucket.Date()
+		totals = append(totals, models.EvolutionTotals{
+			Start:     time.Date(year, month, day, 0, 0, 0, 0, query.Location),
+			Income:    models.Monetary{Value: income, Offset: offset, Currency: currency},
+			Outcome:   models.Monetary{Value: outcome, Offset: offset, Currency: currency},
+			Transfers: models.Monetary{Value: transfers, Offset: offset, Currency: currency},
+		})
+	}
+
+	return totals, rows.Err()
+}
+
+// BalanceAt starts from the closing balance of the last snapshot before the
+// day of the moment, and sums the active transactions of that day up to it
+// by offset, like AggregateEvolution.
+func (r *PostgreSQLWalletRepository) BalanceAt(ctx context.Context, walletId string, at time.Time) (models.Monetary, error) {
+	ctx, span := r.tracer.Start(ctx, "BalanceAt", trace.WithAttributes(
+		attribute.String("wallet.id", walletId),
+	))
+	defer span.End()
+
+	day := models.SnapshotDay(at)
+
+	var currency string
+	var offset, closing sql.NullInt64
+	err := r.db.QueryRowContext(ctx, `SELECT w.currency, s.amount_offset, s.closing_value
+			  FROM wallets w
+			  LEFT JOIN LATERAL (
+			      SELECT amount_offset, closing_value FROM wallet_balance_snapshots
+			      WHERE wallet_id = w.id AND day < $2
+			      ORDER BY day DESC
+			      LIMIT 1
+			  ) s ON TRUE
+			  WHERE w.id = $1`,
+		walletId,
+		day,
+	).Scan(&currency, &offset, &closing)
+	if errors.Is(err, sql.ErrNoRows) {
+		span.SetStatus(codes.Error, "Wallet not found")
+		return models.Monetary{}, errx.ErrNotFound
+	}
+	if err != nil {
+		span.SetStatus(codes.Error, "query failed")
+		span.RecordError(err)
+		return models.Monetary{}, err
+	}
+
+	balance := models.Monetary{Offset: models.CurrencyOffset(currency), Currency: currency}
+	if offset.Valid {
+		balance = balance.Sum(models.Monetary{Value: int(closing.Int64), Offset: int(offset.Int64), Currency: currency})
+	}
+
+	// Timestamps are stored in UTC
+	rows, err := r.db.QueryContext(ctx, `SELECT t.amount_offset,
+			  COALESCE(SUM(CASE t.type WHEN 'deposit' THEN t.amount_value WHEN 'withdraw' THEN -t.amount_value ELSE 0 END), 0)::bigint
+			  FROM transactions t
+			  WHERE t.wallet_id = $1 AND t.status = 'active' AND t.created_at >= $2 AND t.created_at < $3
+			  GROUP BY t.amount_offset`,
+		walletId,
+		day,
+		at.UTC(),
+	)
+	if err != nil {
+		span.SetStatus(codes.Error, "query failed")
+		span.RecordError(err)
+		return models.Monetary{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var offset, value int
+
+		if err := rows.Scan(&offset, &value); err != nil {
+			span.SetStatus(codes.Error, "scan failed")
+			span.RecordError(err)
+			return models.Monetary{}, err
+		}
+
+		balance = balance.Sum(models.Monetary{Value: value, Offset: offset, Currency: currency})
+	}
+	if err := rows.Err(); err != nil {
+		span.SetStatus(codes.Error, "scan failed")
+		span.RecordError(err)
+		return models.Monetary{}, err
+	}
+
+	span.SetStatus(codes.Ok, "Balance computed")
+	return balance, nil
+}
@@ -3,12 +3,14 @@ package database
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
-	"log/slog"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/lopesgabriel/tellawl/packages/tracing"
-	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/events"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
 	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
@@ -17,15 +19,13 @@ import (
 
 type PostgreSQLWalletRepository struct {
 	db         *sql.DB
-	publisher  events.EventPublisher
-	memberRepo *HTTPMemberRepository
+	memberRepo *PostgreSQLMemberRepository
 	tracer     trace.Tracer
 }
 
-func NewPostgreSQLWalletRepository(db *sql.DB, publisher events.EventPublisher, memberRepo *HTTPMemberRepository) *PostgreSQLWalletRepository {
+func NewPostgreSQLWalletRepository(db *sql.DB, memberRepo *PostgreSQLMemberRepository) *PostgreSQLWalletRepository {
 	return &PostgreSQLWalletRepository{
 		db:         db,
-		publisher:  publisher,
 		memberRepo: memberRepo,
 		tracer:     tracing.GetTracer("github.com/lopesgabriel/tellawl/services/wallet/internal/infra/database/postgresql/PostgreSQLWalletRepository"),
 	}
@@ -35,7 +35,96 @@ func (r *PostgreSQLWalletRepository) FindById(ctx context.Context, id string) (*
 	ctx, span := r.tracer.Start(ctx, "FindByID")
 	defer span.End()
 
-	query := `SELECT id, creator_id, name, balance_value, balance_offset, created_at, updated_at 
+	wallet, err := r.findWalletHeader(ctx, id)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	// Load users
+	members, err := r.loadWalletMembers(ctx, id)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	wallet.Members = members
+
+	// Load transactions
+	transactions, err := r.loadWalletTransactions(ctx, id)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	wallet.Transactions = transactions
+
+	// Load categories
+	categories, err := r.loadWalletCategories(ctx, id)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	wallet.Categories = categories
+
+	// Load recurring transactions
+	recurringTransactions, err := r.loadWalletRecurringTransactions(ctx, wallet)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	wallet.RecurringTransactions = recurringTransactions
+
+	// Load budgets
+	budgets, err := r.loadWalletBudgets(ctx, id)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	wallet.Budgets = budgets
+
+	span.SetStatus(codes.Ok, "Wallet found")
+	return wallet, nil
+}
+
+// FindDetailById loads the wallet with its members and categories, leaving
+// the transactions to the caller.
+func (r *PostgreSQLWalletRepository) FindDetailById(ctx context.Context, id string) (*models.WalletDetail, error) {
+	ctx, span := r.tracer.Start(ctx, "FindDetailById")
+	defer span.End()
+
+	wallet, err := r.findWalletHeader(ctx, id)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	members, err := r.loadWalletMembers(ctx, id)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	categories, err := r.loadWalletCategories(ctx, id)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetStatus(codes.Ok, "Wallet found")
+	return &models.WalletDetail{
+		Id:         wallet.Id,
+		Name:       wallet.Name,
+		CreatorId:  wallet.CreatorId,
+		Balance:    wallet.Balance,
+		Members:    members,
+		Categories: categories,
+		CreatedAt:  wallet.CreatedAt,
+		UpdatedAt:  wallet.UpdatedAt,
+	}, nil
+}
+
+// findWalletHeader loads the wallet row alone, without any collection.
+func (r *PostgreSQLWalletRepository) findWalletHeader(ctx context.Context, id string) (*models.Wallet, error) {
+	query := `SELECT id, creator_id, name, balance_value, balance_offset, currency, created_at, updated_at, version
 			  FROM wallets WHERE id = $1`
 
 	row := r.db.QueryRowContext(ctx, query, id)
@@ -49,12 +138,16 @@ func (r *PostgreSQLWalletRepository) FindById(ctx context.Context, id string) (*
 		&wallet.Name,
 		&wallet.Balance.Value,
 		&wallet.Balance.Offset,
+		&wallet.Balance.Currency,
 		&wallet.CreatedAt,
 		&updatedAt,
+		&wallet.Version,
 	)
 
 	if err != nil {
-		span.SetStatus(codes.Error, err.Error())
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errx.ErrNotFound
+		}
 		return nil, err
 	}
 
@@ -62,34 +155,27 @@ func (r *PostgreSQLWalletRepository) FindById(ctx context.Context, id string) (*
 		wallet.UpdatedAt = &updatedAt.Time
 	}
 
-	// Load users
-	members, err := r.loadWalletMembers(ctx, id)
-	if err != nil {
-		span.SetStatus(codes.Error, err.Error())
-		return nil, err
-	}
-	wallet.Members = members
-
-	// Load transactions
-	transactions, err := r.loadWalletTransactions(ctx, id)
-	if err != nil {
-		span.SetStatus(codes.Error, err.Error())
-		return nil, err
-	}
-	wallet.Transactions = transactions
-
-	span.SetStatus(codes.Ok, "Wallet found")
 	return &wallet, nil
 }
 
-func (r *PostgreSQLWalletRepository) FindByUserId(ctx context.Context, userId string) ([]models.Wallet, error) {
-	ctx, span := r.tracer.Start(ctx, "FindByUserId")
+// FindSummariesByUserId lists the wallets the member has access to with
+// their activity aggregated in the same query.
+func (r *PostgreSQLWalletRepository) FindSummariesByUserId(ctx context.Context, userId string) ([]models.WalletSummary, error) {
+	ctx, span := r.tracer.Start(ctx, "FindSummariesByUserId")
 	defer span.End()
 
-	query := `SELECT DISTINCT w.id, w.creator_id, w.name, w.balance_value, w.balance_offset, w.created_at, w.updated_at 
-			  FROM wallets w 
-			  JOIN wallet_users wu ON w.id = wu.wallet_id 
-			  WHERE wu.member_id = $1`
+	query := `SELECT w.id, w.creator_id, w.name, w.balance_value, w.balance_offset, w.currency, w.created_at, w.updated_at,
+			  CASE WHEN w.creator_id = $1 THEN 'owner' ELSE wu.role END,
+			  COALESCE(t.transaction_count, 0), t.last_activity_at
+			  FROM wallets w
+			  JOIN wallet_users wu ON w.id = wu.wallet_id AND wu.member_id = $1
+			  LEFT JOIN LATERAL (
+			      SELECT COUNT(*) FILTER (WHERE status = 'active') AS transaction_count,
+			             MAX(COALESCE(updated_at, created_at)) AS last_activity_at
+			      FROM transactions
+			      WHERE wallet_id = w.id
+			  ) t ON true
+			  ORDER BY w.created_at`
 
 	rows, err := r.db.QueryContext(ctx, query, userId)
 	if err != nil {
@@ -99,11 +185,11 @@ func (r *PostgreSQLWalletRepository) FindByUserId(ctx context.Context, userId st
 	}
 	defer rows.Close()
 
-	var wallets []models.Wallet
+	wallets := []models.WalletSummary{}
 
 	for rows.Next() {
-		var wallet models.Wallet
-		var updatedAt sql.NullTime
+		var wallet models.WalletSummary
+		var updatedAt, lastActivityAt sql.NullTime
 
 		err := rows.Scan(
 			&wallet.Id,
@@ -111,8 +197,12 @@ func (r *PostgreSQLWalletRepository) FindByUserId(ctx context.Context, userId st
 			&wallet.Name,
 			&wallet.Balance.Value,
 			&wallet.Balance.Offset,
+			&wallet.Balance.Currency,
 			&wallet.CreatedAt,
 			&updatedAt,
+			&wallet.Role,
+			&wallet.TransactionCount,
+			&lastActivityAt,
 		)
 
 		if err != nil {
@@ -124,6 +214,9 @@ func (r *PostgreSQLWalletRepository) FindByUserId(ctx context.Context, userId st
 		if updatedAt.Valid {
 			wallet.UpdatedAt = &updatedAt.Time
 		}
+		if lastActivityAt.Valid {
+			wallet.LastActivityAt = &lastActivityAt.Time
+		}
 
 		wallets = append(wallets, wallet)
 	}
@@ -136,93 +229,363 @@ func (r *PostgreSQLWalletRepository) Save(ctx context.Context, wallet *models.Wa
 	ctx, span := r.tracer.Start(ctx, "Save")
 	defer span.End()
 
-	tx, err := r.db.Begin()
+	err := r.saveWallets(ctx, []*models.Wallet{wallet}, nil)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return err
+	}
+
+	span.SetStatus(codes.Ok, "Wallet saved")
+	return nil
+}
+
+func (r *PostgreSQLWalletRepository) SaveAll(ctx context.Context, wallets ...*models.Wallet) error {
+	ctx, span := r.tracer.Start(ctx, "SaveAll", trace.WithAttributes(
+		attribute.Int("wallets.count", len(wallets)),
+	))
+	defer span.End()
+
+	err := r.saveWallets(ctx, wallets, nil)
 	if err != nil {
 		span.SetStatus(codes.Error, err.Error())
 		span.RecordError(err)
 		return err
 	}
+
+	span.SetStatus(codes.Ok, "Wallets saved")
+	return nil
+}
+
+// SaveWithInvitation saves the wallet and the invitation in the same database
+// transaction.
+func (r *PostgreSQLWalletRepository) SaveWithInvitation(ctx context.Context, wallet *models.Wallet, invitation *models.Invitation) error {
+	ctx, span := r.tracer.Start(ctx, "SaveWithInvitation", trace.WithAttributes(
+		attribute.String("wallet.id", wallet.Id),
+		attribute.String("invitation.id", invitation.Id),
+	))
+	defer span.End()
+
+	err := r.saveWallets(ctx, []*models.Wallet{wallet}, func(tx *sql.Tx) error {
+		return saveInvitation(ctx, tx, invitation)
+	})
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return err
+	}
+	invitation.ClearEvents()
+
+	span.SetStatus(codes.Ok, "Wallet and invitation saved")
+	return nil
+}
+
+// SaveWithStatementImport saves the wallet and the import in the same
+// database transaction.
+func (r *PostgreSQLWalletRepository) SaveWithStatementImport(ctx context.Context, wallet *models.Wallet, statementImport *models.StatementImport) error {
+	ctx, span := r.tracer.Start(ctx, "SaveWithStatementImport", trace.WithAttributes(
+		attribute.String("wallet.id", wallet.Id),
+		attribute.String("statement_import.id", statementImport.Id),
+	))
+	defer span.End()
+
+	err := r.saveWallets(ctx, []*models.Wallet{wallet}, func(tx *sql.Tx) error {
+		return saveStatementImport(ctx, tx, statementImport)
+	})
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return err
+	}
+
+	span.SetStatus(codes.Ok, "Wallet and statement import saved")
+	return nil
+}
+
+// saveWallets persists the wallets and writes their events to the outbox in a
+// single database transaction, so no event is lost if the broker is down.
+// saveWith, when given, writes whatever else must be saved along with them.
+func (r *PostgreSQLWalletRepository) saveWallets(ctx context.Context, wallets []*models.Wallet, saveWith func(tx *sql.Tx) error) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
 	defer tx.Rollback()
 
-	// Save wallet
-	query := `INSERT INTO wallets (id, creator_id, name, balance_value, balance_offset, created_at, updated_at)
-			  VALUES ($1, $2, $3, $4, $5, $6, $7)
-			  ON CONFLICT (id) DO UPDATE SET
-			  name = EXCLUDED.name,
-			  balance_value = EXCLUDED.balance_value,
-			  balance_offset = EXCLUDED.balance_offset,
-			  updated_at = $8`
-
-	now := time.Now()
-	_, err = tx.ExecContext(ctx, query,
-		wallet.Id,
-		wallet.CreatorId,
-		wallet.Name,
-		wallet.Balance.Value,
-		wallet.Balance.Offset,
-		wallet.CreatedAt,
-		wallet.UpdatedAt,
-		now,
-	)
+	// Rows are always locked in the same order so concurrent transfers
+	// between the same wallets cannot deadlock
+	ordered := slices.Clone(wallets)
+	slices.SortFunc(ordered, func(a, b *models.Wallet) int {
+		return strings.Compare(a.Id, b.Id)
+	})
+
+	for _, wallet := range ordered {
+		if err := r.saveWallet(ctx, tx, wallet); err != nil {
+			return err
+		}
+	}
+
+	// Events keep the order the wallets were given in
+	for _, wallet := range wallets {
+		if err := writeOutbox(ctx, tx, wallet.Events()); err != nil {
+			return err
+		}
+	}
+
+	if saveWith != nil {
+		if err := saveWith(tx); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	for _, wallet := range wallets {
+		wallet.Version++
+		wallet.ClearEvents()
+		wallet.ClearChanges()
+	}
+
+	return nil
+}
+
+func (r *PostgreSQLWalletRepository) saveWallet(ctx context.Context, tx *sql.Tx, wallet *models.Wallet) error {
+	ctx, span := r.tracer.Start(ctx, "saveWallet", trace.WithAttributes(
+		attribute.String("wallet.id", wallet.Id),
+	))
+	defer span.End()
+
+	// Save wallet. New wallets are inserted, the others only updated while
+	// the stored version is the one they were loaded from
+	var result sql.Result
+	var err error
+	if wallet.Version == 0 {
+		result, err = tx.ExecContext(ctx, `INSERT INTO wallets (id, creator_id, name, balance_value, balance_offset, currency, created_at, updated_at, version)
+				  VALUES ($1, $2, $3, $4, $5, $6, $7, $8, 1)
+				  ON CONFLICT (id) DO NOTHING`,
+			wallet.Id,
+			wallet.CreatorId,
+			wallet.Name,
+			wallet.Balance.Value,
+			wallet.Balance.Offset,
+			wallet.Balance.Currency,
+			wallet.CreatedAt,
+			wallet.UpdatedAt,
+		)
+	} else {
+		result, err = tx.ExecContext(ctx, `UPDATE wallets SET
+				  name = $2,
+				  balance_value = $3,
+				  balance_offset = $4,
+				  updated_at = $5,
+				  version = version + 1
+				  WHERE id = $1 AND version = $6`,
+			wallet.Id,
+			wallet.Name,
+			wallet.Balance.Value,
+			wallet.Balance.Offset,
+			time.Now(),
+			wallet.Version,
+		)
+	}
+
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return err
+	}
+	if affected == 0 {
+		span.SetStatus(codes.Error, "Wallet version conflict")
+		return errx.ErrWalletConflict
+	}
+
+	// Only the entities that changed since the wallet was loaded are written
+	changes := wallet.Changes()
+	span.SetAttributes(attribute.Int("wallet.changed_transactions", len(changes.Transactions)))
+
+	err = r.saveWalletMembers(ctx, tx, wallet, changes)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return err
+	}
+
+	// Save categories before transactions, which may reference them
+	err = r.saveWalletCategories(ctx, tx, wallet, changes)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return err
+	}
+
+	// Budgets reference categories, removed ones cascade to their budgets
+	err = r.saveWalletBudgets(ctx, tx, wallet, changes)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return err
+	}
+
+	// Save recurring transactions before transactions, which may reference them
+	err = r.saveWalletRecurringTransactions(ctx, tx, wallet, changes)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return err
+	}
 
+	err = r.saveWalletTransactions(ctx, tx, wallet, changes)
 	if err != nil {
 		span.SetStatus(codes.Error, err.Error())
 		span.RecordError(err)
 		return err
 	}
 
-	// Sync wallet users
-	err = r.syncWalletMembers(ctx, tx, wallet.Id, wallet.Members)
+	err = r.saveBalanceSnapshots(ctx, tx, wallet, changes)
 	if err != nil {
 		span.SetStatus(codes.Error, err.Error())
 		span.RecordError(err)
 		return err
 	}
 
-	// Save transactions
-	for _, transaction := range wallet.Transactions {
-		_, err = tx.ExecContext(ctx, `INSERT INTO transactions (id, wallet_id, amount_value, amount_offset, created_by, type, description, created_at)
-						  VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-						  ON CONFLICT (id) DO NOTHING`,
+	return nil
+}
+
+func (r *PostgreSQLWalletRepository) saveWalletTransactions(ctx context.Context, tx *sql.Tx, wallet *models.Wallet, changes models.WalletChanges) error {
+	ctx, span := r.tracer.Start(ctx, "saveWalletTransactions", trace.WithAttributes(
+		attribute.String("wallet.id", wallet.Id),
+		attribute.Int("transactions.count", len(changes.Transactions)),
+	))
+	defer span.End()
+
+	rows := [][]any{}
+	splitRows := [][]any{}
+	for _, transaction := range changedEntities(wallet.Transactions, changes.Transactions, func(t models.Transaction) string { return t.Id }) {
+		rows = append(rows, []any{
 			transaction.Id,
 			wallet.Id,
 			transaction.Amount.Value,
 			transaction.Amount.Offset,
+			transactionCurrency(*wallet, transaction),
 			transaction.CreatedBy.Id,
 			string(transaction.Type),
 			transaction.Description,
+			transaction.CategoryId,
+			transactionStatus(transaction),
+			transaction.ReplacesId,
+			transaction.ReplacedById,
+			transaction.RecurringTransactionId,
+			transaction.OccurrenceDate,
+			transaction.TransferId,
 			transaction.CreatedAt,
-		)
-		if err != nil {
-			span.SetStatus(codes.Error, err.Error())
-			span.RecordError(err)
-			return err
+			transaction.UpdatedAt,
+			splitMethod(transaction),
+			transaction.SettledWithId,
+		})
+
+		if transaction.Split != nil {
+			for position, share := range transaction.Split.Shares {
+				splitRows = append(splitRows, []any{
+					transaction.Id,
+					share.MemberId,
+					position,
+					share.Percentage,
+					share.Amount.Value,
+					share.Amount.Offset,
+					transactionCurrency(*wallet, transaction),
+				})
+			}
 		}
 	}
 
-	err = tx.Commit()
+	// Amounts are immutable, edits are stored as a new replacing entry.
+	// Only the lifecycle columns of existing rows may change.
+	err := execBatch(ctx, tx, `INSERT INTO transactions (id, wallet_id, amount_value, amount_offset, amount_currency, created_by, type, description, category_id, status, replaces_id, replaced_by_id, recurring_transaction_id, occurrence_date, transfer_id, created_at, updated_at, split_method, settled_with_id)
+			  VALUES %s
+			  ON CONFLICT (id) DO UPDATE SET
+			  category_id = EXCLUDED.category_id,
+			  status = EXCLUDED.status,
+			  replaced_by_id = EXCLUDED.replaced_by_id,
+			  updated_at = EXCLUDED.updated_at`, rows)
 	if err != nil {
-		span.SetStatus(codes.Error, err.Error())
+		span.SetStatus(codes.Error, "failed to save transactions")
 		span.RecordError(err)
 		return err
 	}
 
-	if err := r.publisher.Publish(ctx, wallet.Events()); err != nil {
-		slog.Error("error publishing events", slog.String("error", err.Error()))
+	// Splits are as immutable as the amounts they divide
+	err = execBatch(ctx, tx, `INSERT INTO transaction_splits (transaction_id, member_id, position, percentage, amount_value, amount_offset, amount_currency)
+			  VALUES %s
+			  ON CONFLICT (transaction_id, member_id) DO NOTHING`, splitRows)
+	if err != nil {
+		span.SetStatus(codes.Error, "failed to save transaction splits")
+		span.RecordError(err)
+		return err
 	}
-	wallet.ClearEvents()
 
-	span.SetStatus(codes.Ok, "Wallet saved")
-	return err
+	return nil
+}
+
+func splitMethod(transaction models.Transaction) *string {
+	if transaction.Split == nil {
+		return nil
+	}
+
+	method := string(transaction.Split.Method)
+	return &method
+}
+
+// findMembers looks the members up in a single call, keyed by id. Ids may
+// repeat; a member that does not exist fails the lookup.
+func (r *PostgreSQLWalletRepository) findMembers(ctx context.Context, ids []string) (map[string]models.Member, error) {
+	unique := []string{}
+	seen := map[string]bool{}
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	if len(unique) == 0 {
+		return map[string]models.Member{}, nil
+	}
+
+	found, err := r.memberRepo.FindByIDs(ctx, unique)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make(map[string]models.Member, len(found))
+	for _, member := range found {
+		members[member.Id] = member
+	}
+
+	for _, id := range unique {
+		if _, ok := members[id]; !ok {
+			return nil, fmt.Errorf("member %s: %w", id, errx.ErrNotFound)
+		}
+	}
+
+	return members, nil
 }
 
-func (r *PostgreSQLWalletRepository) loadWalletMembers(ctx context.Context, walletId string) ([]models.Member, error) {
+func (r *PostgreSQLWalletRepository) loadWalletMembers(ctx context.Context, walletId string) ([]models.WalletMember, error) {
 	ctx, span := r.tracer.Start(ctx, "loadWalletMembers", trace.WithAttributes(
 		attribute.String("wallet.id", walletId),
 	))
 	defer span.End()
 
-	query := `SELECT wu.member_id
+	query := `SELECT wu.member_id, wu.role, wu.assigned_at
 			  FROM wallet_users wu
 			  WHERE wu.wallet_id = $1`
 
@@ -234,13 +597,15 @@ func (r *PostgreSQLWalletRepository) loadWalletMembers(ctx context.Context, wall
 	}
 	defer rows.Close()
 
-	var users []models.Member
+	var users []models.WalletMember
 
 	for rows.Next() {
-		var user models.Member
+		var user models.WalletMember
 
 		err := rows.Scan(
 			&user.Id,
+			&user.Role,
+			&user.AssignedAt,
 		)
 		if err != nil {
 			span.SetStatus(codes.Error, "query failed")
@@ -248,31 +613,28 @@ func (r *PostgreSQLWalletRepository) loadWalletMembers(ctx context.Context, wall
 			return nil, err
 		}
 
-		span.AddEvent(fmt.Sprintf("Retrieving data for user %s", user.Id), trace.WithAttributes(
-			attribute.String("user.id", user.Id),
-		))
-
-		userData, err := r.memberRepo.FindByID(ctx, user.Id)
-		if err != nil {
-			span.SetStatus(codes.Error, "failed to get member data")
-			span.RecordError(err)
-			return nil, err
-		}
+		users = append(users, user)
+	}
+	if err := rows.Err(); err != nil {
+		span.SetStatus(codes.Error, "query failed")
+		span.RecordError(err)
+		return nil, err
+	}
 
-		user.FirstName = userData.FirstName
-		user.LastName = userData.LastName
-		user.Email = userData.Email
-		user.CreatedAt = userData.CreatedAt
-		if userData.UpdatedAt != nil {
-			user.UpdatedAt = userData.UpdatedAt
-		}
+	ids := make([]string, len(users))
+	for i, user := range users {
+		ids[i] = user.Id
+	}
 
-		span.AddEvent(fmt.Sprintf("User data retrieved for user %s", user.Id), trace.WithAttributes(
-			attribute.String("user.id", user.Id),
-			attribute.String("user.email", user.Email),
-		))
+	members, err := r.findMembers(ctx, ids)
+	if err != nil {
+		span.SetStatus(codes.Error, "failed to get member data")
+		span.RecordError(err)
+		return nil, err
+	}
 
-		users = append(users, user)
+	for i := range users {
+		users[i].Member = members[users[i].Id]
 	}
 
 	return users, nil
@@ -284,7 +646,7 @@ func (r *PostgreSQLWalletRepository) loadWalletTransactions(ctx context.Context,
 	))
 	defer span.End()
 
-	query := `SELECT t.id, t.amount_value, t.amount_offset, t.type, t.description, t.created_at, t.created_by
+	query := `SELECT ` + transactionColumns + `
 			  FROM transactions t
 			  WHERE t.wallet_id = $1
 			  ORDER BY t.created_at DESC`
@@ -299,109 +661,295 @@ func (r *PostgreSQLWalletRepository) loadWalletTransactions(ctx context.Context,
 	var transactions []models.Transaction
 
 	for rows.Next() {
-		var transaction models.Transaction
-		var user models.Member
-
-		err := rows.Scan(
-			&transaction.Id,
-			&transaction.Amount.Value,
-			&transaction.Amount.Offset,
-			&transaction.Type,
-			&transaction.Description,
-			&transaction.CreatedAt,
-			&user.Id,
-		)
+		transaction, err := scanTransaction(rows)
 		if err != nil {
 			span.SetStatus(codes.Error, "scan failed")
 			span.RecordError(err)
 			return nil, err
 		}
+		transactions = append(transactions, transaction)
+	}
+	if err := rows.Err(); err != nil {
+		span.SetStatus(codes.Error, "scan failed")
+		span.RecordError(err)
+		return nil, err
+	}
+
+	ids := make([]string, len(transactions))
+	for i, transaction := range transactions {
+		ids[i] = transaction.CreatedBy.Id
+	}
+
+	authors, err := r.findMembers(ctx, ids)
+	if err != nil {
+		span.SetStatus(codes.Error, "failed to retrieve user data")
+		span.RecordError(err)
+		return nil, err
+	}
+
+	for i := range transactions {
+		transactions[i].CreatedBy = authors[transactions[i].CreatedBy.Id]
+	}
 
-		span.AddEvent(fmt.Sprintf("Retrieving data for user %s", user.Id), trace.WithAttributes(
-			attribute.String("user.id", user.Id),
-		))
+	if err := r.loadTransactionSplits(ctx, transactions); err != nil {
+		span.SetStatus(codes.Error, "failed to load transaction splits")
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return transactions, nil
+}
+
+// loadTransactionSplits fills the shares of the split transactions, which
+// scanTransaction only knows the method of.
+func (r *PostgreSQLWalletRepository) loadTransactionSplits(ctx context.Context, transactions []models.Transaction) error {
+	ids := []string{}
+	index := map[string]int{}
+	for i, transaction := range transactions {
+		if transaction.Split != nil {
+			ids = append(ids, transaction.Id)
+			index[transaction.Id] = i
+		}
+	}
+
+	if len(ids) == 0 {
+		return nil
+	}
 
-		userData, err := r.memberRepo.FindByID(ctx, user.Id)
+	rows, err := r.db.QueryContext(ctx, `SELECT transaction_id, member_id, percentage, amount_value, amount_offset, amount_currency
+			  FROM transaction_splits
+			  WHERE transaction_id = ANY($1)
+			  ORDER BY transaction_id, position`, ids)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var transactionId string
+		var share models.SplitShare
+		err := rows.Scan(
+			&transactionId,
+			&share.MemberId,
+			&share.Percentage,
+			&share.Amount.Value,
+			&share.Amount.Offset,
+			&share.Amount.Currency,
+		)
 		if err != nil {
-			span.SetStatus(codes.Error, "failed to retrieve user data")
-			span.RecordError(err)
-			return nil, err
+			return err
 		}
 
-		user.FirstName = userData.FirstName
-		user.LastName = userData.LastName
-		user.Email = userData.Email
-		user.CreatedAt = userData.CreatedAt
-		if userData.UpdatedAt != nil {
-			user.UpdatedAt = userData.UpdatedAt
+		split := transactions[index[transactionId]].Split
+		split.Shares = append(split.Shares, share)
+	}
+
+	return rows.Err()
+}
+
+// transactionColumns are the columns read by scanTransaction.
+const transactionColumns = `t.id, t.amount_value, t.amount_offset, t.amount_currency, t.type, t.description, t.category_id,
+			  t.status, t.replaces_id, t.replaced_by_id, t.recurring_transaction_id, t.occurrence_date,
+			  t.transfer_id, t.created_at, t.updated_at, t.created_by, t.split_method, t.settled_with_id`
+
+// scanTransaction reads a row selected with transactionColumns. Only the id
+// of the author is known, the member details are up to the caller.
+func scanTransaction(rows *sql.Rows) (models.Transaction, error) {
+	var transaction models.Transaction
+	var categoryId, replacesId, replacedById, recurringTransactionId, transferId, splitMethod, settledWithId sql.NullString
+	var occurrenceDate, updatedAt sql.NullTime
+
+	err := rows.Scan(
+		&transaction.Id,
+		&transaction.Amount.Value,
+		&transaction.Amount.Offset,
+		&transaction.Amount.Currency,
+		&transaction.Type,
+		&transaction.Description,
+		&categoryId,
+		&transaction.Status,
+		&replacesId,
+		&replacedById,
+		&recurringTransactionId,
+		&occurrenceDate,
+		&transferId,
+		&transaction.CreatedAt,
+		&updatedAt,
+		&transaction.CreatedBy.Id,
+		&splitMethod,
+		&settledWithId,
+	)
+	if err != nil {
+		return transaction, err
+	}
+
+	if categoryId.Valid {
+		transaction.CategoryId = &categoryId.String
+	}
+	if replacesId.Valid {
+		transaction.ReplacesId = &replacesId.String
+	}
+	if replacedById.Valid {
+		transaction.ReplacedById = &replacedById.String
+	}
+	if recurringTransactionId.Valid {
+		transaction.RecurringTransactionId = &recurringTransactionId.String
+	}
+	if occurrenceDate.Valid {
+		date := models.RecurrenceDate(occurrenceDate.Time)
+		transaction.OccurrenceDate = &date
+	}
+	if transferId.Valid {
+		transaction.TransferId = &transferId.String
+	}
+	if updatedAt.Valid {
+		transaction.UpdatedAt = &updatedAt.Time
+	}
+	if splitMethod.Valid {
+		transaction.Split = &models.TransactionSplit{Method: models.SplitMethod(splitMethod.String)}
+	}
+	if settledWithId.Valid {
+		transaction.SettledWithId = &settledWithId.String
+	}
+
+	return transaction, nil
+}
+
+func (r *PostgreSQLWalletRepository) saveWalletMembers(ctx context.Context, tx *sql.Tx, wallet *models.Wallet, changes models.WalletChanges) error {
+	ctx, span := r.tracer.Start(ctx, "saveWalletMembers", trace.WithAttributes(
+		attribute.String("wallet.id", wallet.Id),
+	))
+	defer span.End()
+
+	if len(changes.RemovedMembers) > 0 {
+		_, err := tx.ExecContext(ctx, "DELETE FROM wallet_users WHERE wallet_id = $1 AND member_id = ANY($2)", wallet.Id, changes.RemovedMembers)
+		if err != nil {
+			span.SetStatus(codes.Error, "failed to remove old users")
+			span.RecordError(err)
+			return err
 		}
+	}
 
-		span.AddEvent(fmt.Sprintf("User data retrieved for user %s", user.Id), trace.WithAttributes(
-			attribute.String("user.id", user.Id),
-			attribute.String("user.email", user.Email),
-		))
+	rows := [][]any{}
+	for _, user := range changedEntities(wallet.Members, changes.Members, func(m models.WalletMember) string { return m.Id }) {
+		assignedAt := user.AssignedAt
+		if assignedAt.IsZero() {
+			assignedAt = time.Now()
+		}
+		rows = append(rows, []any{wallet.Id, user.Id, user.Role, assignedAt})
+	}
 
-		transaction.CreatedBy = user
-		transactions = append(transactions, transaction)
+	// Members added back keep their original assignment date
+	err := execBatch(ctx, tx, `INSERT INTO wallet_users (wallet_id, member_id, role, assigned_at)
+			  VALUES %s
+			  ON CONFLICT (wallet_id, member_id) DO UPDATE SET
+			  role = EXCLUDED.role`, rows)
+	if err != nil {
+		span.SetStatus(codes.Error, "failed to save users")
+		span.RecordError(err)
+		return err
 	}
 
-	return transactions, nil
+	return nil
 }
 
-func (r *PostgreSQLWalletRepository) syncWalletMembers(ctx context.Context, tx *sql.Tx, walletId string, users []models.Member) error {
-	ctx, span := r.tracer.Start(ctx, "syncWalletMembers", trace.WithAttributes(
+func (r *PostgreSQLWalletRepository) loadWalletCategories(ctx context.Context, walletId string) ([]models.Category, error) {
+	ctx, span := r.tracer.Start(ctx, "loadWalletCategories", trace.WithAttributes(
 		attribute.String("wallet.id", walletId),
 	))
 	defer span.End()
-	// Get current user IDs
-	rows, err := tx.QueryContext(ctx, "SELECT member_id FROM wallet_users WHERE wallet_id = $1", walletId)
+
+	query := `SELECT c.id, c.wallet_id, c.name, c.created_at, c.updated_at
+			  FROM categories c
+			  WHERE c.wallet_id = $1
+			  ORDER BY c.name`
+	rows, err := r.db.QueryContext(ctx, query, walletId)
 	if err != nil {
-		span.SetStatus(codes.Error, "failed to query current users")
+		span.SetStatus(codes.Error, "query failed")
 		span.RecordError(err)
-		return err
+		return nil, err
 	}
 	defer rows.Close()
 
-	currentUsers := make(map[string]bool)
+	categories := []models.Category{}
+
 	for rows.Next() {
-		var userId string
-		if err := rows.Scan(&userId); err != nil {
-			span.SetStatus(codes.Error, "failed to scan current users")
+		var category models.Category
+		var updatedAt sql.NullTime
+
+		err := rows.Scan(
+			&category.Id,
+			&category.WalletId,
+			&category.Name,
+			&category.CreatedAt,
+			&updatedAt,
+		)
+		if err != nil {
+			span.SetStatus(codes.Error, "scan failed")
 			span.RecordError(err)
-			return err
+			return nil, err
 		}
-		currentUsers[userId] = true
-	}
 
-	// Build new user set
-	newUsers := make(map[string]bool)
-	for _, user := range users {
-		newUsers[user.Id] = true
+		if updatedAt.Valid {
+			category.UpdatedAt = &updatedAt.Time
+		}
+
+		categories = append(categories, category)
 	}
 
-	// Remove users not in new set
-	for userId := range currentUsers {
-		if !newUsers[userId] {
-			_, err = tx.ExecContext(ctx, "DELETE FROM wallet_users WHERE wallet_id = $1 AND member_id = $2", walletId, userId)
-			if err != nil {
-				span.SetStatus(codes.Error, "failed to remove old users")
-				span.RecordError(err)
-				return err
-			}
+	return categories, nil
+}
+
+func (r *PostgreSQLWalletRepository) saveWalletCategories(ctx context.Context, tx *sql.Tx, wallet *models.Wallet, changes models.WalletChanges) error {
+	ctx, span := r.tracer.Start(ctx, "saveWalletCategories", trace.WithAttributes(
+		attribute.String("wallet.id", wallet.Id),
+	))
+	defer span.End()
+
+	// Removed first so a new category may take the name of a removed one.
+	// Transactions referencing them are detached by the foreign key.
+	if len(changes.RemovedCategories) > 0 {
+		_, err := tx.ExecContext(ctx, "DELETE FROM categories WHERE wallet_id = $1 AND id = ANY($2)", wallet.Id, changes.RemovedCategories)
+		if err != nil {
+			span.SetStatus(codes.Error, "failed to remove old categories")
+			span.RecordError(err)
+			return err
 		}
 	}
 
-	// Add new users
-	for userId := range newUsers {
-		if !currentUsers[userId] {
-			_, err = tx.ExecContext(ctx, "INSERT INTO wallet_users (wallet_id, member_id, assigned_at) VALUES ($1, $2, $3)", walletId, userId, time.Now())
-			if err != nil {
-				span.SetStatus(codes.Error, "failed to add new users")
-				span.RecordError(err)
-				return err
-			}
-		}
+	rows := [][]any{}
+	for _, category := range changedEntities(wallet.Categories, changes.Categories, func(c models.Category) string { return c.Id }) {
+		rows = append(rows, []any{category.Id, wallet.Id, category.Name, category.CreatedAt, category.UpdatedAt})
+	}
+
+	err := execBatch(ctx, tx, `INSERT INTO categories (id, wallet_id, name, created_at, updated_at)
+			  VALUES %s
+			  ON CONFLICT (id) DO UPDATE SET
+			  name = EXCLUDED.name,
+			  updated_at = EXCLUDED.updated_at`, rows)
+	if err != nil {
+		span.SetStatus(codes.Error, "failed to upsert categories")
+		span.RecordError(err)
+		return err
 	}
 
 	return nil
 }
+
+func transactionStatus(transaction models.Transaction) string {
+	if transaction.Status == "" {
+		return string(models.TransactionStatusActive)
+	}
+
+	return string(transaction.Status)
+}
+
+// transactionCurrency falls back to the wallet currency for amounts created
+// without one.
+func transactionCurrency(wallet models.Wallet, transaction models.Transaction) string {
+	if transaction.Amount.Currency != "" {
+		return transaction.Amount.Currency
+	}
+
+	return wallet.Balance.Currency
+}
@@ -0,0 +1,288 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// saveBalanceSnapshots moves the daily snapshots along with the balance.
+// Only the snapshots from the earliest day moved are loaded and written,
+// which is usually just today's.
+func (r *PostgreSQLWalletRepository) saveBalanceSnapshots(ctx context.Context, tx *sql.Tx, wallet *models.Wallet, changes models.WalletChanges) error {
+	if len(changes.BalanceMovements) == 0 {
+		return nil
+	}
+
+	ctx, span := r.tracer.Start(ctx, "saveBalanceSnapshots", trace.WithAttributes(
+		attribute.String("wallet.id", wallet.Id),
+		attribute.Int("movements.count", len(changes.BalanceMovements)),
+	))
+	defer span.End()
+
+	transactions := make([]*models.Transaction, len(changes.BalanceMovements))
+	var from time.Time
+	for i, movement := range changes.BalanceMovements {
+		transaction, err := wallet.FindTransaction(movement.TransactionId)
+		if err != nil {
+			span.SetStatus(codes.Error, "moved transaction not found")
+			span.RecordError(err)
+			return err
+		}
+		transactions[i] = transaction
+
+		day := models.SnapshotDay(transaction.CreatedAt)
+		if from.IsZero() || day.Before(from) {
+			from = day
+		}
+	}
+
+	history, err := r.loadBalanceHistory(ctx, tx, wallet, from)
+	if err != nil {
+		span.SetStatus(codes.Error, "failed to load snapshots")
+		span.RecordError(err)
+		return err
+	}
+
+	for i, movement := range changes.BalanceMovements {
+		history.Apply(*transactions[i], movement.Reverted)
+	}
+
+	// The snapshot before the earliest day is only there for its closing
+	// balance
+	snapshots := []models.BalanceSnapshot{}
+	for _, snapshot := range history.Snapshots {
+		if !snapshot.Day.Before(from) {
+			snapshots = append(snapshots, snapshot)
+		}
+	}
+
+	if err := writeBalanceSnapshots(ctx, tx, snapshots); err != nil {
+		span.SetStatus(codes.Error, "failed to save snapshots")
+		span.RecordError(err)
+		return err
+	}
+
+	span.SetAttributes(attribute.Int("snapshots.count", len(snapshots)))
+	return nil
+}
+
+// loadBalanceHistory loads the snapshots of the wallet from the day on,
+// preceded by the last one before it.
+func (r *PostgreSQLWalletRepository) loadBalanceHistory(ctx context.Context, tx *sql.Tx, wallet *models.Wallet, from time.Time) (*models.BalanceHistory, error) {
+	rows, err := tx.QueryContext(ctx, `SELECT `+balanceSnapshotColumns+`
+			  FROM wallet_balance_snapshots
+			  WHERE wallet_id = $1 AND day >= COALESCE(
+			      (SELECT MAX(day) FROM wallet_balance_snapshots WHERE wallet_id = $1 AND day < $2), $2)
+			  ORDER BY day`,
+		wallet.Id,
+		from,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	history := &models.BalanceHistory{
+		WalletId:  wallet.Id,
+		Zero:      models.Monetary{Offset: wallet.Balance.Offset, Currency: wallet.Balance.Currency},
+		Snapshots: []models.BalanceSnapshot{},
+	}
+	for rows.Next() {
+		snapshot, err := scanBalanceSnapshot(rows)
+		if err != nil {
+			return nil, err
+		}
+		snapshot.WalletId = wallet.Id
+		history.Snapshots = append(history.Snapshots, snapshot)
+	}
+
+	return history, rows.Err()
+}
+
+// RebuildBalanceSnapshots replaces the snapshots of the wallet with the ones
+// computed from its active transactions. The wallet row is locked meanwhile
+// so no transaction is committed halfway.
+func (r *PostgreSQLWalletRepository) RebuildBalanceSnapshots(ctx context.Context, walletId string) error {
+	ctx, span := r.tracer.Start(ctx, "RebuildBalanceSnapshots", trace.WithAttributes(
+		attribute.String("wallet.id", walletId),
+	))
+	defer span.End()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		span.SetStatus(codes.Error, "failed to begin transaction")
+		span.RecordError(err)
+		return err
+	}
+	defer tx.Rollback()
+
+	var zero models.Monetary
+	err = tx.QueryRowContext(ctx, `SELECT balance_offset, currency FROM wallets WHERE id = $1 FOR UPDATE`, walletId).
+		Scan(&zero.Offset, &zero.Currency)
+	if errors.Is(err, sql.ErrNoRows) {
+		span.SetStatus(codes.Error, "Wallet not found")
+		return errx.ErrNotFound
+	}
+	if err != nil {
+		span.SetStatus(codes.Error, "failed to lock the wallet")
+		span.RecordError(err)
+		return err
+	}
+
+	rows, err := tx.QueryContext(ctx, `SELECT `+transactionColumns+`
+			  FROM transactions t
+			  WHERE t.wallet_id = $1 AND t.status = 'active'
+			  ORDER BY t.created_at, t.id`, walletId)
+	if err != nil {
+		span.SetStatus(codes.Error, "query failed")
+		span.RecordError(err)
+		return err
+	}
+
+	transactions := []models.Transaction{}
+	for rows.Next() {
+		transaction, err := scanTransaction(rows)
+		if err != nil {
+			rows.Close()
+			span.SetStatus(codes.Error, "scan failed")
+			span.RecordError(err)
+			return err
+		}
+		transactions = append(transactions, transaction)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		span.SetStatus(codes.Error, "scan failed")
+		span.RecordError(err)
+		return err
+	}
+
+	history := models.NewBalanceHistory(walletId, zero, transactions)
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM wallet_balance_snapshots WHERE wallet_id = $1`, walletId); err != nil {
+		span.SetStatus(codes.Error, "failed to delete snapshots")
+		span.RecordError(err)
+		return err
+	}
+
+	if err := writeBalanceSnapshots(ctx, tx, history.Snapshots); err != nil {
+		span.SetStatus(codes.Error, "failed to save snapshots")
+		span.RecordError(err)
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		span.SetStatus(codes.Error, "failed to commit transaction")
+		span.RecordError(err)
+		return err
+	}
+
+	span.SetAttributes(
+		attribute.Int("transactions.count", len(transactions)),
+		attribute.Int("snapshots.count", len(history.Snapshots)),
+	)
+	span.SetStatus(codes.Ok, "Snapshots rebuilt")
+	return nil
+}
+
+// ListIds returns the id of every wallet, oldest first.
+func (r *PostgreSQLWalletRepository) ListIds(ctx context.Context) ([]string, error) {
+	ctx, span := r.tracer.Start(ctx, "ListIds")
+	defer span.End()
+
+	rows, err := r.db.QueryContext(ctx, `SELECT id FROM wallets ORDER BY created_at, id`)
+	if err != nil {
+		span.SetStatus(codes.Error, "query failed")
+		span.RecordError(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			span.SetStatus(codes.Error, "scan failed")
+			span.RecordError(err)
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		span.SetStatus(codes.Error, "scan failed")
+		span.RecordError(err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("wallets.count", len(ids)))
+	span.SetStatus(codes.Ok, "Wallets listed")
+	return ids, nil
+}
+
+// writeBalanceSnapshots upserts the snapshots, each one in the least common
+// offset of its amounts.
+func writeBalanceSnapshots(ctx context.Context, tx *sql.Tx, snapshots []models.BalanceSnapshot) error {
+	now := time.Now()
+	rows := make([][]any, len(snapshots))
+	for i, snapshot := range snapshots {
+		offset := snapshot.Offset()
+		rows[i] = []any{
+			snapshot.WalletId,
+			snapshot.Day,
+			offset,
+			snapshot.Closing.Currency,
+			snapshot.Opening.Rescale(offset, models.RoundHalfEven).Value,
+			snapshot.Closing.Rescale(offset, models.RoundHalfEven).Value,
+			snapshot.Income.Rescale(offset, models.RoundHalfEven).Value,
+			snapshot.Outcome.Rescale(offset, models.RoundHalfEven).Value,
+			snapshot.Transfers.Rescale(offset, models.RoundHalfEven).Value,
+			now,
+		}
+	}
+
+	return execBatch(ctx, tx, `INSERT INTO wallet_balance_snapshots (wallet_id, day, amount_offset, amount_currency, opening_value, closing_value, income_value, outcome_value, transfers_value, updated_at)
+			  VALUES %s
+			  ON CONFLICT (wallet_id, day) DO UPDATE SET
+			  amount_offset = EXCLUDED.amount_offset,
+			  opening_value = EXCLUDED.opening_value,
+			  closing_value = EXCLUDED.closing_value,
+			  income_value = EXCLUDED.income_value,
+			  outcome_value = EXCLUDED.outcome_value,
+			  transfers_value = EXCLUDED.transfers_value,
+			  updated_at = EXCLUDED.updated_at`, rows)
+}
+
+// balanceSnapshotColumns are the columns read by scanBalanceSnapshot.
+const balanceSnapshotColumns = `day, amount_offset, amount_currency, opening_value, closing_value, income_value, outcome_value, transfers_value`
+
+func scanBalanceSnapshot(rows *sql.Rows) (models.BalanceSnapshot, error) {
+	var snapshot models.BalanceSnapshot
+	var offset int
+	var currency string
+	var opening, closing, income, outcome, transfers int
+
+	err := rows.Scan(&snapshot.Day, &offset, &currency, &opening, &closing, &income, &outcome, &transfers)
+	if err != nil {
+		return snapshot, err
+	}
+
+	amount := func(value int) models.Monetary {
+		return models.Monetary{Value: value, Offset: offset, Currency: currency}
+	}
+	snapshot.Day = models.SnapshotDay(snapshot.Day)
+	snapshot.Opening = amount(opening)
+	snapshot.Closing = amount(closing)
+	snapshot.Income = amount(income)
+	snapshot.Outcome = amount(outcome)
+	snapshot.Transfers = amount(transfers)
+
+	return snapshot, nil
+}
@@ -0,0 +1,167 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/repository"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func (r *PostgreSQLWalletRepository) FindMemberRole(ctx context.Context, walletId, memberId string) (models.WalletRole, error) {
+	ctx, span := r.tracer.Start(ctx, "FindMemberRole", trace.WithAttributes(
+		attribute.String("wallet.id", walletId),
+		attribute.String("member.id", memberId),
+	))
+	defer span.End()
+
+	// The creator is always an owner, whatever is stored in wallet_users
+	query := `SELECT CASE WHEN w.creator_id = $2 THEN 'owner' ELSE wu.role END
+			  FROM wallets w
+			  LEFT JOIN wallet_users wu ON wu.wallet_id = w.id AND wu.member_id = $2
+			  WHERE w.id = $1`
+
+	var role sql.NullString
+	err := r.db.QueryRowContext(ctx, query, walletId, memberId).Scan(&role)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			span.SetStatus(codes.Error, "Wallet not found")
+			return "", errx.ErrNotFound
+		}
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return "", err
+	}
+
+	if !role.Valid {
+		return "", errx.ErrMemberNotInWallet
+	}
+
+	return models.WalletRole(role.String), nil
+}
+
+func (r *PostgreSQLWalletRepository) ListTransactions(ctx context.Context, walletId string, filter repository.TransactionFilter) (*repository.TransactionPage, error) {
+	ctx, span := r.tracer.Start(ctx, "ListTransactions", trace.WithAttributes(
+		attribute.String("wallet.id", walletId),
+	))
+	defer span.End()
+
+	conditions := []string{"t.wallet_id = $1"}
+	args := []any{walletId}
+	arg := func(value any) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if filter.Cursor != "" {
+		cursor, err := repository.DecodeTransactionCursor(filter.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, fmt.Sprintf("(t.created_at, t.id) < (%s, %s)", arg(cursor.CreatedAt), arg(cursor.Id)))
+	}
+	if filter.From != nil {
+		conditions = append(conditions, "t.created_at >= "+arg(*filter.From))
+	}
+	if filter.To != nil {
+		conditions = append(conditions, "t.created_at < "+arg(*filter.To))
+	}
+	if filter.Type != "" {
+		conditions = append(conditions, "t.type = "+arg(string(filter.Type)))
+	}
+	if filter.Status != "" {
+		conditions = append(conditions, "t.status = "+arg(string(filter.Status)))
+	}
+	if filter.CreatedBy != "" {
+		conditions = append(conditions, "t.created_by = "+arg(filter.CreatedBy))
+	}
+	// Amounts are compared as fractions so different offsets compare exactly
+	if filter.MinAmount != nil {
+		conditions = append(conditions, fmt.Sprintf("t.amount_value::numeric * %s >= %s::numeric * t.amount_offset",
+			arg(filter.MinAmount.Offset), arg(filter.MinAmount.Value)))
+	}
+	if filter.MaxAmount != nil {
+		conditions = append(conditions, fmt.Sprintf("t.amount_value::numeric * %s <= %s::numeric * t.amount_offset",
+			arg(filter.MaxAmount.Offset), arg(filter.MaxAmount.Value)))
+	}
+	if filter.Search != "" {
+		conditions = append(conditions, "t.description ILIKE '%' || "+arg(escapeLike(filter.Search))+" || '%'")
+	}
+
+	limit := filter.PageSize()
+	// One extra row tells whether there is a next page
+	query := `SELECT ` + transactionColumns + `
+			  FROM transactions t
+			  WHERE ` + strings.Join(conditions, " AND ") + `
+			  ORDER BY t.created_at DESC, t.id DESC
+			  LIMIT ` + arg(limit+1)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		span.SetStatus(codes.Error, "query failed")
+		span.RecordError(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	page := &repository.TransactionPage{Transactions: []models.Transaction{}}
+	for rows.Next() {
+		transaction, err := scanTransaction(rows)
+		if err != nil {
+			span.SetStatus(codes.Error, "scan failed")
+			span.RecordError(err)
+			return nil, err
+		}
+		page.Transactions = append(page.Transactions, transaction)
+	}
+	if err := rows.Err(); err != nil {
+		span.SetStatus(codes.Error, "scan failed")
+		span.RecordError(err)
+		return nil, err
+	}
+
+	if len(page.Transactions) > limit {
+		page.Transactions = page.Transactions[:limit]
+		last := page.Transactions[limit-1]
+		page.NextCursor = repository.TransactionCursor{CreatedAt: last.CreatedAt, Id: last.Id}.Encode()
+	}
+
+	// Authors usually repeat within a page, they are looked up together
+	ids := make([]string, len(page.Transactions))
+	for i, transaction := range page.Transactions {
+		ids[i] = transaction.CreatedBy.Id
+	}
+
+	authors, err := r.findMembers(ctx, ids)
+	if err != nil {
+		span.SetStatus(codes.Error, "failed to retrieve user data")
+		span.RecordError(err)
+		return nil, err
+	}
+
+	for i := range page.Transactions {
+		page.Transactions[i].CreatedBy = authors[page.Transactions[i].CreatedBy.Id]
+	}
+
+	if err := r.loadTransactionSplits(ctx, page.Transactions); err != nil {
+		span.SetStatus(codes.Error, "failed to load transaction splits")
+		span.RecordError(err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("transactions.count", len(page.Transactions)))
+	span.SetStatus(codes.Ok, "Transactions listed")
+	return page, nil
+}
+
+// escapeLike escapes the LIKE wildcards so the search is matched literally.
+func escapeLike(text string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(text)
+}
@@ -0,0 +1,125 @@
+package listener
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/lopesgabriel/tellawl/packages/broker"
+	"github.com/lopesgabriel/tellawl/packages/logger"
+	"github.com/lopesgabriel/tellawl/packages/tracing"
+	usecases "github.com/lopesgabriel/tellawl/services/wallet/internal/use-cases"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	MemberCreatedEventType = "dev.lopesgabriel.member-service.member.created"
+	MemberUpdatedEventType = "dev.lopesgabriel.member-service.member.updated"
+)
+
+// memberEvent is the payload of the member-service member events. Timestamp
+// is when the member was created or, for updates, last changed.
+type memberEvent struct {
+	MemberId  string    `json:"member_id"`
+	FirstName string    `json:"first_name"`
+	LastName  string    `json:"last_name"`
+	Email     string    `json:"email"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// MemberEventsListener keeps the local copy of the members up to date with
+// the events published by member-service. Other events on the topic are
+// ignored.
+type MemberEventsListener struct {
+	broker   broker.Broker
+	topic    string
+	useCases *usecases.UseCase
+	logger   *logger.AppLogger
+	tracer   trace.Tracer
+}
+
+type NewMemberEventsListenerArgs struct {
+	Broker   broker.Broker
+	Topic    string
+	UseCases *usecases.UseCase
+	Logger   *logger.AppLogger
+}
+
+func NewMemberEventsListener(args NewMemberEventsListenerArgs) *MemberEventsListener {
+	return &MemberEventsListener{
+		broker:   args.Broker,
+		topic:    args.Topic,
+		useCases: args.UseCases,
+		logger:   args.Logger,
+		tracer:   tracing.GetTracer("github.com/lopesgabriel/tellawl/services/wallet/internal/infra/listener/MemberEventsListener"),
+	}
+}
+
+func (l *MemberEventsListener) Start(ctx context.Context) error {
+	l.logger.Info(ctx, "Listening to member events", slog.String("topic", l.topic))
+	return l.broker.StartConsumer(l.topic, l.HandleMessage)
+}
+
+// HandleMessage projects a member event. Returning an error leaves the
+// message uncommitted.
+func (l *MemberEventsListener) HandleMessage(message *broker.KafkaMessage) error {
+	ctx := otel.GetTextMapPropagator().Extract(context.Background(), propagation.MapCarrier{
+		"traceparent": getHeaderValue(message.Headers, "ce-traceparent"),
+	})
+
+	ceId := getHeaderValue(message.Headers, "ce-id")
+	ceType := getHeaderValue(message.Headers, "ce-type")
+	if ceType != MemberCreatedEventType && ceType != MemberUpdatedEventType {
+		return nil
+	}
+
+	ctx, span := l.tracer.Start(ctx, "HandleMessage", trace.WithAttributes(
+		attribute.String("ce-id", ceId),
+		attribute.String("ce-type", ceType),
+	))
+	defer span.End()
+
+	var event memberEvent
+	if err := json.Unmarshal(message.Value, &event); err != nil {
+		l.logger.Error(ctx, "Failed to unmarshal member event", slog.String("ce-id", ceId), slog.Any("error", err))
+		span.SetStatus(codes.Error, "failed to unmarshal event")
+		span.RecordError(err)
+		return err
+	}
+
+	input := usecases.SyncMemberUseCaseInput{
+		MemberId:  event.MemberId,
+		FirstName: event.FirstName,
+		LastName:  event.LastName,
+		Email:     event.Email,
+		CreatedAt: event.Timestamp,
+	}
+	if ceType == MemberUpdatedEventType {
+		input.UpdatedAt = &event.Timestamp
+	}
+
+	if err := l.useCases.SyncMember(ctx, input); err != nil {
+		l.logger.Error(ctx, "Failed to sync member", slog.String("member.id", event.MemberId), slog.Any("error", err))
+		span.SetStatus(codes.Error, "failed to sync member")
+		span.RecordError(err)
+		return err
+	}
+
+	span.SetStatus(codes.Ok, "member synced")
+	return nil
+}
+
+func getHeaderValue(headers []kafka.Header, key string) string {
+	for _, h := range headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
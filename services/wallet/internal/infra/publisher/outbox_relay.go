@@ -0,0 +1,215 @@
+package publisher
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/lopesgabriel/tellawl/packages/broker"
+	"github.com/lopesgabriel/tellawl/packages/logger"
+	"github.com/lopesgabriel/tellawl/packages/tracing"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/repository"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// maxOutboxBackoff caps the wait between attempts while the broker is failing.
+const maxOutboxBackoff = time.Minute
+
+type outboxStore interface {
+	Relay(ctx context.Context, limit, maxAttempts int, publish func(ctx context.Context, message models.OutboxMessage) error) (int, error)
+	Stats(ctx context.Context) (repository.OutboxStats, error)
+}
+
+// OutboxRelay publishes the events stored in the outbox through the broker,
+// retrying with an exponential backoff while it fails. Events failing too
+// many times are given up on so they do not block the ones after them.
+type OutboxRelay struct {
+	outbox      outboxStore
+	client      broker.Broker
+	batchSize   int
+	maxAttempts int
+	interval    time.Duration
+	logger      *logger.AppLogger
+	tracer      trace.Tracer
+
+	published metric.Int64Counter
+	failures  metric.Int64Counter
+	delay     metric.Float64Histogram
+}
+
+type NewOutboxRelayArgs struct {
+	Outbox outboxStore
+	Broker broker.Broker
+	Logger *logger.AppLogger
+	// Messages loaded at a time. Defaults to 100
+	BatchSize int
+	// Wait between polls of the outbox. Defaults to a second
+	Interval time.Duration
+	// Failed attempts after which a message is given up on. Defaults to 10
+	MaxAttempts int
+}
+
+// NewOutboxRelay creates the relay and registers its metrics: the pending
+// messages and the age of the oldest one (the outbox lag) are observed from
+// the database on every collection.
+func NewOutboxRelay(args NewOutboxRelayArgs) (*OutboxRelay, error) {
+	relay := &OutboxRelay{
+		outbox:      args.Outbox,
+		client:      args.Broker,
+		batchSize:   args.BatchSize,
+		maxAttempts: args.MaxAttempts,
+		interval:    args.Interval,
+		logger:      args.Logger,
+		tracer:      tracing.GetTracer("github.com/lopesgabriel/tellawl/services/wallet/internal/infra/publisher/OutboxRelay"),
+	}
+	if relay.batchSize <= 0 {
+		relay.batchSize = 100
+	}
+	if relay.maxAttempts <= 0 {
+		relay.maxAttempts = 10
+	}
+	if relay.interval <= 0 {
+		relay.interval = time.Second
+	}
+
+	meter := tracing.GetMeter("github.com/lopesgabriel/tellawl/services/wallet/internal/infra/publisher/OutboxRelay")
+
+	var err error
+	relay.published, err = meter.Int64Counter("wallet.outbox.published",
+		metric.WithDescription("Outbox messages published to the broker"),
+		metric.WithUnit("{message}"))
+	if err != nil {
+		return nil, err
+	}
+
+	relay.failures, err = meter.Int64Counter("wallet.outbox.failures",
+		metric.WithDescription("Failed attempts to publish an outbox message"),
+		metric.WithUnit("{attempt}"))
+	if err != nil {
+		return nil, err
+	}
+
+	relay.delay, err = meter.Float64Histogram("wallet.outbox.delivery_delay",
+		metric.WithDescription("Time between storing an outbox message and publishing it"),
+		metric.WithUnit("s"))
+	if err != nil {
+		return nil, err
+	}
+
+	pending, err := meter.Int64ObservableGauge("wallet.outbox.pending",
+		metric.WithDescription("Outbox messages not published yet"),
+		metric.WithUnit("{message}"))
+	if err != nil {
+		return nil, err
+	}
+
+	lag, err := meter.Float64ObservableGauge("wallet.outbox.lag",
+		metric.WithDescription("Age of the oldest outbox message not published yet"),
+		metric.WithUnit("s"))
+	if err != nil {
+		return nil, err
+	}
+
+	failed, err := meter.Int64ObservableGauge("wallet.outbox.failed",
+		metric.WithDescription("Outbox messages given up on after too many failed attempts"),
+		metric.WithUnit("{message}"))
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = meter.RegisterCallback(func(ctx context.Context, observer metric.Observer) error {
+		stats, err := relay.outbox.Stats(ctx)
+		if err != nil {
+			return err
+		}
+
+		observer.ObserveInt64(pending, int64(stats.Pending))
+		observer.ObserveFloat64(lag, stats.Lag(time.Now()).Seconds())
+		observer.ObserveInt64(failed, int64(stats.Failed))
+		return nil
+	}, pending, lag, failed)
+	if err != nil {
+		return nil, err
+	}
+
+	return relay, nil
+}
+
+// RelayPending publishes batches until the outbox is drained or a message
+// fails, returning how many were published.
+func (r *OutboxRelay) RelayPending(ctx context.Context) (int, error) {
+	ctx, span := r.tracer.Start(ctx, "RelayPending")
+	defer span.End()
+
+	total := 0
+	for {
+		published, err := r.outbox.Relay(ctx, r.batchSize, r.maxAttempts, r.publish)
+		total += published
+		if err != nil || published < r.batchSize {
+			return total, err
+		}
+	}
+}
+
+func (r *OutboxRelay) publish(ctx context.Context, message models.OutboxMessage) error {
+	err := r.client.Produce(ctx, &broker.Message{
+		EventId:   message.EventId,
+		EventType: message.EventType,
+		Key:       message.AggregateId,
+		Payload:   message.Payload,
+	})
+	if err != nil {
+		r.failures.Add(ctx, 1)
+		return err
+	}
+
+	r.published.Add(ctx, 1)
+	r.delay.Record(ctx, time.Since(message.CreatedAt).Seconds())
+	return nil
+}
+
+// Run relays the outbox on every interval until ctx is done. After a failure
+// the wait doubles, up to a minute, and goes back to the interval once a
+// message is published again.
+func (r *OutboxRelay) Run(ctx context.Context) {
+	r.logger.Info(ctx, "Starting the outbox relay",
+		slog.String("interval", r.interval.String()),
+		slog.Int("batch_size", r.batchSize),
+	)
+
+	wait := r.interval
+	for {
+		published, err := r.RelayPending(ctx)
+		wait = r.nextWait(wait, err != nil)
+		switch {
+		case err != nil:
+			r.logger.Error(ctx, "failed to relay the outbox",
+				slog.String("error", err.Error()),
+				slog.Int("published", published),
+				slog.String("retry_in", wait.String()),
+			)
+		default:
+			if published > 0 {
+				r.logger.Debug(ctx, "outbox relayed", slog.Int("published", published))
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+// nextWait doubles the wait after a failure, up to maxOutboxBackoff, and
+// goes back to the interval otherwise.
+func (r *OutboxRelay) nextWait(wait time.Duration, failed bool) time.Duration {
+	if !failed {
+		return r.interval
+	}
+
+	return min(wait*2, maxOutboxBackoff)
+}
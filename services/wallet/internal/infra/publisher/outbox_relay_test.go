@@ -0,0 +1,298 @@
+package publisher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lopesgabriel/tellawl/packages/broker"
+	"github.com/lopesgabriel/tellawl/packages/logger"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/repository"
+	lognoop "go.opentelemetry.io/otel/log/noop"
+)
+
+// fakeOutboxStore relays its messages the way the PostgreSQL outbox does.
+type fakeOutboxStore struct {
+	mu       sync.Mutex
+	messages []models.OutboxMessage
+	batches  int
+}
+
+func newFakeOutboxStore(count int) *fakeOutboxStore {
+	store := &fakeOutboxStore{}
+	for i := 1; i <= count; i++ {
+		store.messages = append(store.messages, models.OutboxMessage{
+			Id:          int64(i),
+			EventId:     fmt.Sprintf("event-%d", i),
+			EventType:   "com.tellawl.wallet.test",
+			AggregateId: "wallet-1",
+			Payload:     []byte(`{}`),
+			CreatedAt:   time.Now(),
+		})
+	}
+
+	return store
+}
+
+func (s *fakeOutboxStore) Relay(ctx context.Context, limit, maxAttempts int, publish func(ctx context.Context, message models.OutboxMessage) error) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.batches++
+
+	published := 0
+	for i := range s.messages {
+		message := &s.messages[i]
+		if !message.IsPending() {
+			continue
+		}
+		if published == limit {
+			break
+		}
+
+		if err := publish(ctx, *message); err != nil {
+			message.RecordFailure(err, maxAttempts)
+			return published, err
+		}
+
+		sentAt := time.Now()
+		message.SentAt = &sentAt
+		published++
+	}
+
+	return published, nil
+}
+
+func (s *fakeOutboxStore) Stats(ctx context.Context) (repository.OutboxStats, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var stats repository.OutboxStats
+	for _, message := range s.messages {
+		switch {
+		case message.IsPending():
+			stats.Pending++
+		case message.FailedAt != nil:
+			stats.Failed++
+		}
+	}
+
+	return stats, nil
+}
+
+func (s *fakeOutboxStore) message(id int64) models.OutboxMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	index := slices.IndexFunc(s.messages, func(message models.OutboxMessage) bool { return message.Id == id })
+	return s.messages[index]
+}
+
+// fakeBroker records the produced events and fails the ones in failing.
+type fakeBroker struct {
+	mu       sync.Mutex
+	produced []string
+	failing  map[string]bool
+}
+
+func (b *fakeBroker) Close() error { return nil }
+
+func (b *fakeBroker) Produce(ctx context.Context, message *broker.Message) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failing[message.EventId] {
+		return errors.New("broker unavailable")
+	}
+
+	b.produced = append(b.produced, message.EventId)
+	return nil
+}
+
+func (b *fakeBroker) StartConsumer(topic string, callback broker.CallbackFunction) error {
+	return nil
+}
+
+func (b *fakeBroker) producedEvents() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return slices.Clone(b.produced)
+}
+
+func (b *fakeBroker) setFailing(eventIds ...string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failing = map[string]bool{}
+	for _, eventId := range eventIds {
+		b.failing[eventId] = true
+	}
+}
+
+func newTestRelay(t *testing.T, store *fakeOutboxStore, client *fakeBroker, args NewOutboxRelayArgs) *OutboxRelay {
+	appLogger, err := logger.Init(t.Context(), logger.InitLoggerArgs{
+		LoggerProvider: lognoop.NewLoggerProvider(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to initialize logger: %v", err)
+	}
+	t.Cleanup(func() { appLogger.Shutdown(context.Background()) })
+
+	args.Outbox = store
+	args.Broker = client
+	args.Logger = appLogger
+	relay, err := NewOutboxRelay(args)
+	if err != nil {
+		t.Fatalf("Failed to create the relay: %v", err)
+	}
+
+	return relay
+}
+
+func TestOutboxRelay(t *testing.T) {
+	t.Run("should publish every pending message in order", func(t *testing.T) {
+		store := newFakeOutboxStore(5)
+		client := &fakeBroker{}
+		relay := newTestRelay(t, store, client, NewOutboxRelayArgs{BatchSize: 2})
+
+		published, err := relay.RelayPending(t.Context())
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if published != 5 {
+			t.Errorf("Expected 5 messages published, got %v", published)
+		}
+		// Two full batches and a partial one ending the drain
+		if store.batches != 3 {
+			t.Errorf("Expected 3 batches, got %v", store.batches)
+		}
+		expected := []string{"event-1", "event-2", "event-3", "event-4", "event-5"}
+		if !slices.Equal(client.producedEvents(), expected) {
+			t.Errorf("Expected %v to be produced, got %v", expected, client.producedEvents())
+		}
+	})
+
+	t.Run("should stop at a failure in the middle of a batch and resume from it", func(t *testing.T) {
+		store := newFakeOutboxStore(5)
+		client := &fakeBroker{}
+		client.setFailing("event-3")
+		relay := newTestRelay(t, store, client, NewOutboxRelayArgs{BatchSize: 10})
+
+		published, err := relay.RelayPending(t.Context())
+		if err == nil {
+			t.Fatalf("Expected the failure to be returned")
+		}
+
+		if published != 2 {
+			t.Errorf("Expected 2 messages published, got %v", published)
+		}
+		failed := store.message(3)
+		if failed.Attempts != 1 || failed.LastError == nil || !failed.IsPending() {
+			t.Errorf("Expected the failure to be recorded on the message, got %+v", failed)
+		}
+		if store.message(4).SentAt != nil {
+			t.Errorf("Expected the messages after the failure not to be published")
+		}
+
+		client.setFailing()
+		published, err = relay.RelayPending(t.Context())
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if published != 3 {
+			t.Errorf("Expected 3 messages published, got %v", published)
+		}
+		expected := []string{"event-1", "event-2", "event-3", "event-4", "event-5"}
+		if !slices.Equal(client.producedEvents(), expected) {
+			t.Errorf("Expected %v to be produced, got %v", expected, client.producedEvents())
+		}
+	})
+
+	t.Run("should give up on a message failing too many times", func(t *testing.T) {
+		store := newFakeOutboxStore(3)
+		client := &fakeBroker{}
+		client.setFailing("event-2")
+		relay := newTestRelay(t, store, client, NewOutboxRelayArgs{BatchSize: 10, MaxAttempts: 3})
+
+		for attempt := 1; attempt <= 3; attempt++ {
+			if _, err := relay.RelayPending(t.Context()); err == nil {
+				t.Fatalf("Expected attempt %v to fail", attempt)
+			}
+		}
+
+		poison := store.message(2)
+		if poison.Attempts != 3 || poison.FailedAt == nil || poison.IsPending() {
+			t.Errorf("Expected the message to be given up on, got %+v", poison)
+		}
+
+		published, err := relay.RelayPending(t.Context())
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if published != 1 || store.message(3).SentAt == nil {
+			t.Errorf("Expected the message after it to be published, got %v", published)
+		}
+		stats, _ := store.Stats(t.Context())
+		if stats.Pending != 0 || stats.Failed != 1 {
+			t.Errorf("Expected no pending and 1 failed message, got %+v", stats)
+		}
+	})
+
+	t.Run("should back off exponentially while failing", func(t *testing.T) {
+		relay := newTestRelay(t, newFakeOutboxStore(0), &fakeBroker{}, NewOutboxRelayArgs{Interval: 10 * time.Second})
+
+		wait := relay.interval
+		expected := []time.Duration{20 * time.Second, 40 * time.Second, maxOutboxBackoff, maxOutboxBackoff}
+		for _, want := range expected {
+			wait = relay.nextWait(wait, true)
+			if wait != want {
+				t.Errorf("Expected to wait %v, got %v", want, wait)
+			}
+		}
+
+		if wait = relay.nextWait(wait, false); wait != relay.interval {
+			t.Errorf("Expected the wait to go back to %v, got %v", relay.interval, wait)
+		}
+	})
+
+	t.Run("should keep relaying until the context is done", func(t *testing.T) {
+		store := newFakeOutboxStore(3)
+		client := &fakeBroker{}
+		client.setFailing("event-1")
+		relay := newTestRelay(t, store, client, NewOutboxRelayArgs{Interval: time.Millisecond})
+
+		ctx, cancel := context.WithCancel(t.Context())
+		done := make(chan struct{})
+		go func() {
+			relay.Run(ctx)
+			close(done)
+		}()
+
+		time.Sleep(5 * time.Millisecond)
+		client.setFailing()
+
+		deadline := time.After(5 * time.Second)
+		for len(client.producedEvents()) < 3 {
+			select {
+			case <-deadline:
+				t.Fatalf("Expected the relay to publish every message, got %v", client.producedEvents())
+			case <-time.After(time.Millisecond):
+			}
+		}
+
+		cancel()
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Expected the relay to stop once the context is done")
+		}
+	})
+}
@@ -0,0 +1,46 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+)
+
+type AcceptInvitationUseCaseInput struct {
+	MemberId string
+	Member   *models.Member
+	Token    string
+}
+
+// AcceptInvitation adds the member to the wallet it was invited to.
+func (usecase *UseCase) AcceptInvitation(ctx context.Context, input AcceptInvitationUseCaseInput) (*models.Invitation, error) {
+	member, err := usecase.resolveMember(ctx, input.MemberId, input.Member)
+	if err != nil {
+		return nil, err
+	}
+
+	var invitation *models.Invitation
+	err = retryOnConflict(ctx, func() error {
+		found, err := usecase.repos.Invitation.FindByToken(ctx, input.Token)
+		if err != nil {
+			return err
+		}
+		invitation = found
+
+		wallet, err := usecase.repos.Wallet.FindById(ctx, invitation.WalletId)
+		if err != nil {
+			return err
+		}
+
+		if err := invitation.Accept(wallet, *member); err != nil {
+			return err
+		}
+
+		return usecase.repos.Wallet.SaveWithInvitation(ctx, wallet, invitation)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return invitation, nil
+}
@@ -0,0 +1,199 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lopesgabriel/tellawl/packages/logger"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/infra/database"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/infra/publisher"
+	usecases "github.com/lopesgabriel/tellawl/services/wallet/internal/use-cases"
+	lognoop "go.opentelemetry.io/otel/log/noop"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestBudgets(t *testing.T) {
+	appLogger, err := logger.Init(t.Context(), logger.InitLoggerArgs{
+		LoggerProvider: lognoop.NewLoggerProvider(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to initialize logger: %v", err)
+	}
+	defer appLogger.Shutdown(t.Context())
+
+	eventPublisher := publisher.NewInMemoryEventPublisher(appLogger)
+	user := createMember("member1", "Gabriel", "Lopes", "gabriel@example.com")
+
+	setup := func(t *testing.T) (*usecases.UseCase, *models.Wallet) {
+		repos := database.NewInMemory(eventPublisher)
+		memberRepo := database.NewInMemoryMemberRepository(eventPublisher)
+		repos.Member = memberRepo
+		memberRepo.Items = append(memberRepo.Items, *user)
+		useCases := usecases.NewUseCases(usecases.NewUseCasesArgs{
+			Repos:  repos,
+			Tracer: tracenoop.NewTracerProvider().Tracer("test"),
+			Logger: appLogger,
+		})
+
+		wallet := models.CreateNewWallet("Test wallet", user, models.DefaultCurrency)
+		repos.Wallet.Save(t.Context(), wallet)
+
+		return useCases, wallet
+	}
+
+	withdraw := func(t *testing.T, useCases *usecases.UseCase, walletId string, amount int, categoryId *string) *models.Transaction {
+		transaction, err := useCases.RegisterTransaction(t.Context(), usecases.RegisterTransactionUseCaseInput{
+			TransactionRegisteredByUserId: user.Id,
+			WalletId:                      walletId,
+			Amount:                        amount,
+			TransactionType:               string(models.TransactionTypeWithdraw),
+			Description:                   "Groceries",
+			CategoryId:                    categoryId,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		return transaction
+	}
+
+	currentPeriod := func(t *testing.T, useCases *usecases.UseCase, walletId string) models.BudgetPeriodSummary {
+		budgets, err := useCases.ListBudgets(t.Context(), usecases.ListBudgetsUseCaseInput{
+			MemberId: user.Id,
+			WalletId: walletId,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(budgets) != 1 {
+			t.Fatalf("Expected 1 budget, got %v", len(budgets))
+		}
+
+		return budgets[0].Summaries(time.Now())[0]
+	}
+
+	t.Run("should track the remaining amount of the current period", func(t *testing.T) {
+		useCases, wallet := setup(t)
+
+		_, err := useCases.CreateBudget(t.Context(), usecases.CreateBudgetUseCaseInput{
+			MemberId: user.Id,
+			WalletId: wallet.Id,
+			Amount:   100000,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		withdraw(t, useCases, wallet.Id, 30000, nil)
+		withdraw(t, useCases, wallet.Id, 55000, nil)
+
+		summary := currentPeriod(t, useCases, wallet.Id)
+		if summary.Spent.Value != 85000 {
+			t.Errorf("Expected spent to be 85000, got %v", summary.Spent.Value)
+		}
+		if summary.Remaining.Value != 15000 {
+			t.Errorf("Expected remaining to be 15000, got %v", summary.Remaining.Value)
+		}
+		if summary.Percentage != 85 {
+			t.Errorf("Expected percentage to be 85, got %v", summary.Percentage)
+		}
+	})
+
+	t.Run("should restore the consumption of voided withdrawals", func(t *testing.T) {
+		useCases, wallet := setup(t)
+
+		_, err := useCases.CreateBudget(t.Context(), usecases.CreateBudgetUseCaseInput{
+			MemberId: user.Id,
+			WalletId: wallet.Id,
+			Amount:   100000,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		transaction := withdraw(t, useCases, wallet.Id, 90000, nil)
+
+		_, err = useCases.VoidTransaction(t.Context(), usecases.VoidTransactionUseCaseInput{
+			MemberId:      user.Id,
+			WalletId:      wallet.Id,
+			TransactionId: transaction.Id,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		summary := currentPeriod(t, useCases, wallet.Id)
+		if summary.Spent.Value != 0 {
+			t.Errorf("Expected spent to be 0, got %v", summary.Spent.Value)
+		}
+		if summary.Remaining.Value != 100000 {
+			t.Errorf("Expected remaining to be 100000, got %v", summary.Remaining.Value)
+		}
+	})
+
+	t.Run("should only count withdrawals of the budget category", func(t *testing.T) {
+		useCases, wallet := setup(t)
+
+		supermarket, err := useCases.CreateCategory(t.Context(), usecases.CreateCategoryUseCaseInput{
+			MemberId: user.Id,
+			WalletId: wallet.Id,
+			Name:     "Supermarket",
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		_, err = useCases.CreateBudget(t.Context(), usecases.CreateBudgetUseCaseInput{
+			MemberId:   user.Id,
+			WalletId:   wallet.Id,
+			Amount:     50000,
+			CategoryId: &supermarket.Id,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		withdraw(t, useCases, wallet.Id, 20000, &supermarket.Id)
+		withdraw(t, useCases, wallet.Id, 70000, nil)
+
+		summary := currentPeriod(t, useCases, wallet.Id)
+		if summary.Spent.Value != 20000 {
+			t.Errorf("Expected spent to be 20000, got %v", summary.Spent.Value)
+		}
+	})
+
+	t.Run("should not create two budgets for the same scope", func(t *testing.T) {
+		useCases, wallet := setup(t)
+
+		input := usecases.CreateBudgetUseCaseInput{
+			MemberId: user.Id,
+			WalletId: wallet.Id,
+			Amount:   100000,
+		}
+		if _, err := useCases.CreateBudget(t.Context(), input); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		_, err := useCases.CreateBudget(t.Context(), input)
+		if !errors.Is(err, errx.ErrBudgetAlreadyExists) {
+			t.Errorf("Expected budget already exists error, got %v", err)
+		}
+	})
+
+	t.Run("should reject thresholds that are not positive", func(t *testing.T) {
+		useCases, wallet := setup(t)
+
+		_, err := useCases.CreateBudget(t.Context(), usecases.CreateBudgetUseCaseInput{
+			MemberId:   user.Id,
+			WalletId:   wallet.Id,
+			Amount:     100000,
+			Thresholds: []int{0, 100},
+		})
+		if !errors.Is(err, errx.ErrInvalidBudgetThreshold) {
+			t.Errorf("Expected invalid budget threshold error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,37 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+)
+
+type CancelRecurringTransactionUseCaseInput struct {
+	MemberId               string
+	Member                 *models.Member
+	WalletId               string
+	RecurringTransactionId string
+}
+
+func (usecase *UseCase) CancelRecurringTransaction(ctx context.Context, input CancelRecurringTransactionUseCaseInput) (*models.RecurringTransaction, error) {
+	member, err := usecase.resolveMember(ctx, input.MemberId, input.Member)
+	if err != nil {
+		return nil, err
+	}
+
+	wallet, err := usecase.repos.Wallet.FindById(ctx, input.WalletId)
+	if err != nil {
+		return nil, err
+	}
+
+	recurring, err := wallet.CancelRecurringTransaction(input.RecurringTransactionId, *member)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := usecase.repos.Wallet.Save(ctx, wallet); err != nil {
+		return nil, err
+	}
+
+	return recurring, nil
+}
@@ -0,0 +1,46 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+)
+
+type ChangeMemberRoleUseCaseInput struct {
+	OwnerId  string
+	Owner    *models.Member
+	WalletId string
+	MemberId string
+	Role     string
+}
+
+func (usecase *UseCase) ChangeMemberRole(ctx context.Context, input ChangeMemberRoleUseCaseInput) (*models.Wallet, error) {
+	owner, err := usecase.resolveMember(ctx, input.OwnerId, input.Owner)
+	if err != nil {
+		return nil, err
+	}
+
+	role, err := models.ParseWalletRole(input.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	wallet, err := usecase.repos.Wallet.FindById(ctx, input.WalletId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := wallet.CheckPermission(owner.Id, models.PermissionManage); err != nil {
+		return nil, err
+	}
+
+	if err := wallet.ChangeMemberRole(input.MemberId, role, owner.Id); err != nil {
+		return nil, err
+	}
+
+	if err := usecase.repos.Wallet.Save(ctx, wallet); err != nil {
+		return nil, err
+	}
+
+	return wallet, nil
+}
@@ -0,0 +1,288 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lopesgabriel/tellawl/packages/logger"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/infra/database"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/infra/publisher"
+	usecases "github.com/lopesgabriel/tellawl/services/wallet/internal/use-cases"
+	lognoop "go.opentelemetry.io/otel/log/noop"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestWalletRoles(t *testing.T) {
+	appLogger, err := logger.Init(t.Context(), logger.InitLoggerArgs{
+		LoggerProvider: lognoop.NewLoggerProvider(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to initialize logger: %v", err)
+	}
+	defer appLogger.Shutdown(t.Context())
+
+	eventPublisher := publisher.NewInMemoryEventPublisher(appLogger)
+
+	owner := createMember("member1", "Gabriel", "Lopes", "gabriel@example.com")
+	editor := createMember("member2", "Matheus", "Lopes", "matheus@example.com")
+	viewer := createMember("member3", "Maria", "Lopes", "maria@example.com")
+
+	setup := func(t *testing.T) (*usecases.UseCase, *models.Wallet) {
+		repos := database.NewInMemory(eventPublisher)
+		memberRepo := database.NewInMemoryMemberRepository(eventPublisher)
+		repos.Member = memberRepo
+		memberRepo.Items = append(memberRepo.Items, *owner, *editor, *viewer)
+		useCases := usecases.NewUseCases(usecases.NewUseCasesArgs{
+			Repos:  repos,
+			Tracer: tracenoop.NewTracerProvider().Tracer("test"),
+			Logger: appLogger,
+		})
+
+		wallet := models.CreateNewWallet("Test wallet", owner, models.DefaultCurrency)
+		repos.Wallet.Save(t.Context(), wallet)
+
+		share := func(invitee *models.Member, role models.WalletRole) {
+			invitation, err := useCases.ShareWallet(t.Context(), usecases.ShareWalletUseCaseInput{
+				WalletCreatorId: owner.Id,
+				WalletId:        wallet.Id,
+				SharedUserEmail: invitee.Email,
+				Role:            string(role),
+			})
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			_, err = useCases.AcceptInvitation(t.Context(), usecases.AcceptInvitationUseCaseInput{
+				MemberId: invitee.Id,
+				Token:    invitation.Token,
+			})
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+		}
+
+		share(editor, "")
+		share(viewer, models.WalletRoleViewer)
+
+		wallet, err := repos.Wallet.FindById(t.Context(), wallet.Id)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		return useCases, wallet
+	}
+
+	t.Run("should share the wallet as editor by default", func(t *testing.T) {
+		_, wallet := setup(t)
+
+		role, ok := wallet.MemberRole(editor.Id)
+		if !ok || role != models.WalletRoleEditor {
+			t.Errorf("Expected editor role, got %v", role)
+		}
+
+		role, ok = wallet.MemberRole(owner.Id)
+		if !ok || role != models.WalletRoleOwner {
+			t.Errorf("Expected owner role, got %v", role)
+		}
+	})
+
+	t.Run("should reject an unknown role", func(t *testing.T) {
+		useCases, wallet := setup(t)
+
+		_, err := useCases.ChangeMemberRole(t.Context(), usecases.ChangeMemberRoleUseCaseInput{
+			OwnerId:  owner.Id,
+			WalletId: wallet.Id,
+			MemberId: editor.Id,
+			Role:     "admin",
+		})
+		if !errors.Is(err, errx.ErrInvalidWalletRole) {
+			t.Errorf("Expected invalid wallet role error, got %v", err)
+		}
+	})
+
+	t.Run("viewer should not register transactions", func(t *testing.T) {
+		useCases, wallet := setup(t)
+
+		_, err := useCases.RegisterTransaction(t.Context(), usecases.RegisterTransactionUseCaseInput{
+			TransactionRegisteredByUserId: viewer.Id,
+			WalletId:                      wallet.Id,
+			Amount:                        1000,
+			Offset:                        100,
+			TransactionType:               string(models.TransactionTypeDeposit),
+		})
+		if !errors.Is(err, errx.ErrInsufficientPermissions) {
+			t.Errorf("Expected insufficient permissions error, got %v", err)
+		}
+	})
+
+	t.Run("viewer should list the wallet categories", func(t *testing.T) {
+		useCases, wallet := setup(t)
+
+		_, err := useCases.ListWalletCategories(t.Context(), usecases.ListWalletCategoriesUseCaseInput{
+			MemberId: viewer.Id,
+			WalletId: wallet.Id,
+		})
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+	})
+
+	t.Run("editor should not rename the wallet", func(t *testing.T) {
+		useCases, wallet := setup(t)
+
+		_, err := useCases.RenameWallet(t.Context(), usecases.RenameWalletUseCaseInput{
+			MemberId: editor.Id,
+			WalletId: wallet.Id,
+			Name:     "Renamed",
+		})
+		if !errors.Is(err, errx.ErrInsufficientPermissions) {
+			t.Errorf("Expected insufficient permissions error, got %v", err)
+		}
+	})
+
+	t.Run("promoted owner should rename and share the wallet", func(t *testing.T) {
+		useCases, wallet := setup(t)
+
+		_, err := useCases.ChangeMemberRole(t.Context(), usecases.ChangeMemberRoleUseCaseInput{
+			OwnerId:  owner.Id,
+			WalletId: wallet.Id,
+			MemberId: editor.Id,
+			Role:     string(models.WalletRoleOwner),
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		renamed, err := useCases.RenameWallet(t.Context(), usecases.RenameWalletUseCaseInput{
+			MemberId: editor.Id,
+			WalletId: wallet.Id,
+			Name:     "  Renamed  ",
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if renamed.Name != "Renamed" {
+			t.Errorf("Expected wallet name to be Renamed, got %v", renamed.Name)
+		}
+
+		_, err = useCases.UnshareWallet(t.Context(), usecases.UnshareWalletUseCaseInput{
+			WalletCreatorId: editor.Id,
+			WalletId:        wallet.Id,
+			MemberId:        viewer.Id,
+		})
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+	})
+
+	t.Run("should not change the creator role", func(t *testing.T) {
+		useCases, wallet := setup(t)
+
+		_, err := useCases.ChangeMemberRole(t.Context(), usecases.ChangeMemberRoleUseCaseInput{
+			OwnerId:  owner.Id,
+			WalletId: wallet.Id,
+			MemberId: owner.Id,
+			Role:     string(models.WalletRoleViewer),
+		})
+		if !errors.Is(err, errx.ErrCannotChangeCreatorRole) {
+			t.Errorf("Expected cannot change creator role error, got %v", err)
+		}
+	})
+
+	t.Run("should cancel the recurring transactions of members losing write access", func(t *testing.T) {
+		for _, change := range []string{"demoted", "removed"} {
+			useCases, wallet := setup(t)
+
+			recurring, err := useCases.CreateRecurringTransaction(t.Context(), usecases.CreateRecurringTransactionUseCaseInput{
+				MemberId:        editor.Id,
+				WalletId:        wallet.Id,
+				Amount:          5990,
+				TransactionType: string(models.TransactionTypeWithdraw),
+				Description:     "Gym",
+				Frequency:       string(models.RecurrenceMonthly),
+				StartDate:       time.Now().AddDate(0, -2, 0),
+			})
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			if change == "demoted" {
+				_, err = useCases.ChangeMemberRole(t.Context(), usecases.ChangeMemberRoleUseCaseInput{
+					OwnerId:  owner.Id,
+					WalletId: wallet.Id,
+					MemberId: editor.Id,
+					Role:     string(models.WalletRoleViewer),
+				})
+			} else {
+				_, err = useCases.UnshareWallet(t.Context(), usecases.UnshareWalletUseCaseInput{
+					WalletCreatorId: owner.Id,
+					WalletId:        wallet.Id,
+					MemberId:        editor.Id,
+				})
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			recurringTransactions, err := useCases.ListRecurringTransactions(t.Context(), usecases.ListRecurringTransactionsUseCaseInput{
+				MemberId: owner.Id,
+				WalletId: wallet.Id,
+			})
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			if recurringTransactions[0].Id != recurring.Id || recurringTransactions[0].Status != models.RecurringTransactionStatusCancelled {
+				t.Errorf("Expected the recurring transaction of the %v member to be cancelled, got %v", change, recurringTransactions[0].Status)
+			}
+
+			registered, err := useCases.MaterializeRecurringTransactions(t.Context(), usecases.MaterializeRecurringTransactionsUseCaseInput{})
+			if err != nil || registered != 0 {
+				t.Errorf("Expected no occurrence to be registered, got %v and error %v", registered, err)
+			}
+		}
+	})
+
+	t.Run("should keep the recurring transactions of members still allowed to write", func(t *testing.T) {
+		useCases, wallet := setup(t)
+
+		_, err := useCases.CreateRecurringTransaction(t.Context(), usecases.CreateRecurringTransactionUseCaseInput{
+			MemberId:        editor.Id,
+			WalletId:        wallet.Id,
+			Amount:          5990,
+			TransactionType: string(models.TransactionTypeWithdraw),
+			Description:     "Gym",
+			Frequency:       string(models.RecurrenceMonthly),
+			StartDate:       time.Now(),
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		_, err = useCases.ChangeMemberRole(t.Context(), usecases.ChangeMemberRoleUseCaseInput{
+			OwnerId:  owner.Id,
+			WalletId: wallet.Id,
+			MemberId: editor.Id,
+			Role:     string(models.WalletRoleOwner),
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		recurringTransactions, err := useCases.ListRecurringTransactions(t.Context(), usecases.ListRecurringTransactionsUseCaseInput{
+			MemberId: owner.Id,
+			WalletId: wallet.Id,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if !recurringTransactions[0].IsActive() {
+			t.Errorf("Expected the recurring transaction to stay active, got %v", recurringTransactions[0].Status)
+		}
+	})
+}
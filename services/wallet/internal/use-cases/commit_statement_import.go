@@ -0,0 +1,63 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+)
+
+type CommitStatementImportUseCaseInput struct {
+	MemberId string
+	Member   *models.Member
+	WalletId string
+	ImportId string
+
+	// Lines to register, nil registers every valid row not flagged as a duplicate
+	AcceptedLines []int
+}
+
+// CommitStatementImport registers the accepted rows of a previewed import in
+// a single save of the wallet, along with the import.
+func (usecase *UseCase) CommitStatementImport(ctx context.Context, input CommitStatementImportUseCaseInput) (*models.StatementImport, error) {
+	member, err := usecase.resolveMember(ctx, input.MemberId, input.Member)
+	if err != nil {
+		return nil, err
+	}
+
+	var statementImport *models.StatementImport
+	err = retryOnConflict(ctx, func() error {
+		found, err := usecase.GetStatementImport(ctx, GetStatementImportUseCaseInput{
+			MemberId: member.Id,
+			Member:   member,
+			WalletId: input.WalletId,
+			ImportId: input.ImportId,
+		})
+		if err != nil {
+			return err
+		}
+		statementImport = found
+
+		// Transactions registered since the preview are flagged as well
+		if statementImport.Status == models.StatementImportStatusPreview {
+			if err := usecase.detectDuplicates(ctx, statementImport); err != nil {
+				return err
+			}
+		}
+
+		wallet, err := usecase.repos.Wallet.FindById(ctx, input.WalletId)
+		if err != nil {
+			return err
+		}
+
+		if _, err := statementImport.Commit(wallet, *member, input.AcceptedLines); err != nil {
+			return err
+		}
+
+		return usecase.repos.Wallet.SaveWithStatementImport(ctx, wallet, statementImport)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return statementImport, nil
+}
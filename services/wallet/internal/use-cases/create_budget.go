@@ -0,0 +1,58 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+)
+
+type CreateBudgetUseCaseInput struct {
+	MemberId   string
+	Member     *models.Member
+	WalletId   string
+	Amount     int
+	Offset     int
+	Currency   string
+	CategoryId *string
+
+	// Consumption percentages to be notified, defaults to models.DefaultBudgetThresholds
+	Thresholds []int
+}
+
+func (usecase *UseCase) CreateBudget(ctx context.Context, input CreateBudgetUseCaseInput) (*models.Budget, error) {
+	member, err := usecase.resolveMember(ctx, input.MemberId, input.Member)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Currency != "" {
+		currency, err := models.ParseCurrency(input.Currency)
+		if err != nil {
+			return nil, err
+		}
+		input.Currency = currency
+	}
+
+	wallet, err := usecase.repos.Wallet.FindById(ctx, input.WalletId)
+	if err != nil {
+		return nil, err
+	}
+
+	input.Offset = amountOffset(input.Offset, input.Currency, wallet)
+
+	budget, err := wallet.AddBudget(
+		*member,
+		models.Monetary{Value: input.Amount, Offset: input.Offset, Currency: input.Currency},
+		input.CategoryId,
+		input.Thresholds,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := usecase.repos.Wallet.Save(ctx, wallet); err != nil {
+		return nil, err
+	}
+
+	return budget, nil
+}
@@ -0,0 +1,41 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+)
+
+type CreateCategoryUseCaseInput struct {
+	MemberId string
+	Member   *models.Member
+	WalletId string
+	Name     string
+}
+
+func (usecase *UseCase) CreateCategory(ctx context.Context, input CreateCategoryUseCaseInput) (*models.Category, error) {
+	member, err := usecase.resolveMember(ctx, input.MemberId, input.Member)
+	if err != nil {
+		return nil, err
+	}
+
+	wallet, err := usecase.repos.Wallet.FindById(ctx, input.WalletId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := wallet.CheckPermission(member.Id, models.PermissionWrite); err != nil {
+		return nil, err
+	}
+
+	category, err := wallet.AddCategory(input.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := usecase.repos.Wallet.Save(ctx, wallet); err != nil {
+		return nil, err
+	}
+
+	return category, nil
+}
@@ -0,0 +1,180 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lopesgabriel/tellawl/packages/logger"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/infra/database"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/infra/publisher"
+	usecases "github.com/lopesgabriel/tellawl/services/wallet/internal/use-cases"
+	lognoop "go.opentelemetry.io/otel/log/noop"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestCategoriesUseCases(t *testing.T) {
+	appLogger, err := logger.Init(t.Context(), logger.InitLoggerArgs{
+		LoggerProvider: lognoop.NewLoggerProvider(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to initialize logger: %v", err)
+	}
+	defer appLogger.Shutdown(t.Context())
+
+	eventPublisher := publisher.NewInMemoryEventPublisher(appLogger)
+
+	t.Run("new wallets should have the default categories", func(t *testing.T) {
+		user := createMember("member1", "Gabriel", "Lopes", "gabriel@example.com")
+		wallet := models.CreateNewWallet("Test wallet", user, models.DefaultCurrency)
+
+		if len(wallet.Categories) != len(models.DefaultCategoryNames) {
+			t.Errorf("Expected %d categories, got %d", len(models.DefaultCategoryNames), len(wallet.Categories))
+		}
+	})
+
+	t.Run("should create, rename and delete a category", func(t *testing.T) {
+		repos := database.NewInMemory(eventPublisher)
+		memberRepo := database.NewInMemoryMemberRepository(eventPublisher)
+		repos.Member = memberRepo
+		useCases := usecases.NewUseCases(usecases.NewUseCasesArgs{
+			Repos:  repos,
+			Tracer: tracenoop.NewTracerProvider().Tracer("test"),
+			Logger: appLogger,
+		})
+
+		user := createMember("member1", "Gabriel", "Lopes", "gabriel@example.com")
+		memberRepo.Items = append(memberRepo.Items, *user)
+
+		wallet := models.CreateNewWallet("Test wallet", user, models.DefaultCurrency)
+		repos.Wallet.Save(t.Context(), wallet)
+
+		category, err := useCases.CreateCategory(t.Context(), usecases.CreateCategoryUseCaseInput{
+			MemberId: user.Id,
+			WalletId: wallet.Id,
+			Name:     "Pets",
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		_, err = useCases.CreateCategory(t.Context(), usecases.CreateCategoryUseCaseInput{
+			MemberId: user.Id,
+			WalletId: wallet.Id,
+			Name:     "pets",
+		})
+		if !errors.Is(err, errx.ErrCategoryAlreadyExists) {
+			t.Errorf("Expected category already exists error, got %v", err)
+		}
+
+		renamed, err := useCases.UpdateCategory(t.Context(), usecases.UpdateCategoryUseCaseInput{
+			MemberId:   user.Id,
+			WalletId:   wallet.Id,
+			CategoryId: category.Id,
+			Name:       "Pet care",
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if renamed.Name != "Pet care" {
+			t.Errorf("Expected category name to be 'Pet care', got %v", renamed.Name)
+		}
+
+		transaction, err := useCases.RegisterTransaction(t.Context(), usecases.RegisterTransactionUseCaseInput{
+			TransactionRegisteredByUserId: user.Id,
+			WalletId:                      wallet.Id,
+			Amount:                        5000,
+			TransactionType:               "withdraw",
+			Description:                   "Dog food",
+			CategoryId:                    &category.Id,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if transaction.CategoryId == nil || *transaction.CategoryId != category.Id {
+			t.Errorf("Expected transaction category to be %v, got %v", category.Id, transaction.CategoryId)
+		}
+
+		err = useCases.DeleteCategory(t.Context(), usecases.DeleteCategoryUseCaseInput{
+			MemberId:   user.Id,
+			WalletId:   wallet.Id,
+			CategoryId: category.Id,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		categories, err := useCases.ListWalletCategories(t.Context(), usecases.ListWalletCategoriesUseCaseInput{
+			MemberId: user.Id,
+			WalletId: wallet.Id,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(categories) != len(models.DefaultCategoryNames) {
+			t.Errorf("Expected %d categories, got %d", len(models.DefaultCategoryNames), len(categories))
+		}
+
+		wallet, _ = repos.Wallet.FindById(t.Context(), wallet.Id)
+		if wallet.Transactions[0].CategoryId != nil {
+			t.Errorf("Expected transaction category to be cleared, got %v", *wallet.Transactions[0].CategoryId)
+		}
+	})
+
+	t.Run("should not register a transaction with an unknown category", func(t *testing.T) {
+		repos := database.NewInMemory(eventPublisher)
+		memberRepo := database.NewInMemoryMemberRepository(eventPublisher)
+		repos.Member = memberRepo
+		useCases := usecases.NewUseCases(usecases.NewUseCasesArgs{
+			Repos:  repos,
+			Tracer: tracenoop.NewTracerProvider().Tracer("test"),
+			Logger: appLogger,
+		})
+
+		user := createMember("member1", "Gabriel", "Lopes", "gabriel@example.com")
+		memberRepo.Items = append(memberRepo.Items, *user)
+
+		wallet := models.CreateNewWallet("Test wallet", user, models.DefaultCurrency)
+		repos.Wallet.Save(t.Context(), wallet)
+
+		unknown := "unknown"
+		_, err := useCases.RegisterTransaction(t.Context(), usecases.RegisterTransactionUseCaseInput{
+			TransactionRegisteredByUserId: user.Id,
+			WalletId:                      wallet.Id,
+			Amount:                        5000,
+			TransactionType:               "withdraw",
+			CategoryId:                    &unknown,
+		})
+		if !errors.Is(err, errx.ErrCategoryNotFound) {
+			t.Errorf("Expected category not found error, got %v", err)
+		}
+	})
+
+	t.Run("non members should not manage categories", func(t *testing.T) {
+		repos := database.NewInMemory(eventPublisher)
+		memberRepo := database.NewInMemoryMemberRepository(eventPublisher)
+		repos.Member = memberRepo
+		useCases := usecases.NewUseCases(usecases.NewUseCasesArgs{
+			Repos:  repos,
+			Tracer: tracenoop.NewTracerProvider().Tracer("test"),
+			Logger: appLogger,
+		})
+
+		user := createMember("member1", "Gabriel", "Lopes", "gabriel@example.com")
+		user2 := createMember("member2", "Matheus", "Lopes", "matheus@example.com")
+		memberRepo.Items = append(memberRepo.Items, *user, *user2)
+
+		wallet := models.CreateNewWallet("Test wallet", user, models.DefaultCurrency)
+		repos.Wallet.Save(t.Context(), wallet)
+
+		_, err := useCases.CreateCategory(t.Context(), usecases.CreateCategoryUseCaseInput{
+			MemberId: user2.Id,
+			WalletId: wallet.Id,
+			Name:     "Pets",
+		})
+		if !errors.Is(err, errx.ErrInsufficientPermissions) {
+			t.Errorf("Expected insufficient permissions error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,70 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+)
+
+type CreateRecurringTransactionUseCaseInput struct {
+	MemberId        string
+	Member          *models.Member
+	WalletId        string
+	Amount          int
+	Offset          int
+	Currency        string
+	TransactionType string
+	Description     string
+	CategoryId      *string
+
+	Frequency  string
+	DayOfMonth int
+	StartDate  time.Time
+	EndDate    *time.Time
+}
+
+func (usecase *UseCase) CreateRecurringTransaction(ctx context.Context, input CreateRecurringTransactionUseCaseInput) (*models.RecurringTransaction, error) {
+	member, err := usecase.resolveMember(ctx, input.MemberId, input.Member)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Currency != "" {
+		currency, err := models.ParseCurrency(input.Currency)
+		if err != nil {
+			return nil, err
+		}
+		input.Currency = currency
+	}
+
+	wallet, err := usecase.repos.Wallet.FindById(ctx, input.WalletId)
+	if err != nil {
+		return nil, err
+	}
+
+	input.Offset = amountOffset(input.Offset, input.Currency, wallet)
+
+	recurring, err := wallet.AddRecurringTransaction(
+		models.Monetary{Value: input.Amount, Offset: input.Offset, Currency: input.Currency},
+		*member,
+		models.TransactionType(input.TransactionType),
+		input.Description,
+		input.CategoryId,
+		models.RecurrenceSchedule{
+			Frequency:  models.RecurrenceFrequency(input.Frequency),
+			DayOfMonth: input.DayOfMonth,
+			StartDate:  input.StartDate,
+			EndDate:    input.EndDate,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := usecase.repos.Wallet.Save(ctx, wallet); err != nil {
+		return nil, err
+	}
+
+	return recurring, nil
+}
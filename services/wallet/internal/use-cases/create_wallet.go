@@ -13,6 +13,9 @@ type CreateWalletUseCaseInput struct {
 	CreatorID string
 	Creator   *models.Member
 	Name      string
+
+	// ISO 4217 currency code, defaults to models.DefaultCurrency when empty
+	Currency string
 }
 
 func (usecase *UseCase) CreateWallet(ctx context.Context, input CreateWalletUseCaseInput) (*models.Wallet, error) {
@@ -29,6 +32,12 @@ func (usecase *UseCase) CreateWallet(ctx context.Context, input CreateWalletUseC
 		return nil, errx.MissingRequiredFieldsError("Name")
 	}
 
+	currency, err := models.ParseCurrency(input.Currency)
+	if err != nil {
+		span.AddEvent("invalid currency: " + input.Currency)
+		return nil, err
+	}
+
 	var creator *models.Member
 	if input.Creator != nil {
 		creator = input.Creator
@@ -42,7 +51,7 @@ func (usecase *UseCase) CreateWallet(ctx context.Context, input CreateWalletUseC
 		creator = member
 	}
 
-	wallet := models.CreateNewWallet(input.Name, creator)
+	wallet := models.CreateNewWallet(input.Name, creator, currency)
 
 	if err := usecase.repos.Wallet.Save(ctx, wallet); err != nil {
 		span.SetStatus(codes.Error, "could not persist the wallet")
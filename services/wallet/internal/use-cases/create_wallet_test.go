@@ -1,11 +1,13 @@
 package usecases_test
 
 import (
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/lopesgabriel/tellawl/packages/logger"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
 	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
 	"github.com/lopesgabriel/tellawl/services/wallet/internal/infra/database"
 	"github.com/lopesgabriel/tellawl/services/wallet/internal/infra/publisher"
@@ -66,3 +68,112 @@ func TestWalletCreation(t *testing.T) {
 		t.Errorf("Expected persisted wallet name to be 'My Wallet', got %v", persistedWallet.Name)
 	}
 }
+
+func TestMultiCurrencyWallet(t *testing.T) {
+	appLogger, err := logger.Init(t.Context(), logger.InitLoggerArgs{
+		LoggerProvider: lognoop.NewLoggerProvider(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to initialize logger: %v", err)
+	}
+	defer appLogger.Shutdown(t.Context())
+
+	publisher := publisher.NewInMemoryEventPublisher(appLogger)
+	repos := database.NewInMemory(publisher)
+	memberRepo := database.NewInMemoryMemberRepository(publisher)
+	repos.Member = memberRepo
+
+	useCases := usecases.NewUseCases(usecases.NewUseCasesArgs{
+		Repos:  repos,
+		Tracer: tracenoop.NewTracerProvider().Tracer("test"),
+		Logger: appLogger,
+	})
+
+	user := createMember(uuid.NewString(), "Gabriel", "Lopes", "example@example.com")
+	memberRepo.Items = append(memberRepo.Items, *user)
+
+	t.Run("should default to BRL", func(t *testing.T) {
+		wallet, err := useCases.CreateWallet(t.Context(), usecases.CreateWalletUseCaseInput{
+			CreatorID: user.Id,
+			Name:      "Default wallet",
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if wallet.Balance.Currency != "BRL" || wallet.Balance.Offset != 100 {
+			t.Errorf("Expected BRL balance with offset 100, got %v with offset %v", wallet.Balance.Currency, wallet.Balance.Offset)
+		}
+	})
+
+	t.Run("should use the currency minor unit as offset", func(t *testing.T) {
+		wallet, err := useCases.CreateWallet(t.Context(), usecases.CreateWalletUseCaseInput{
+			CreatorID: user.Id,
+			Name:      "Japan trip",
+			Currency:  "jpy",
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if wallet.Balance.Currency != "JPY" || wallet.Balance.Offset != 1 {
+			t.Errorf("Expected JPY balance with offset 1, got %v with offset %v", wallet.Balance.Currency, wallet.Balance.Offset)
+		}
+
+		transaction, err := useCases.RegisterTransaction(t.Context(), usecases.RegisterTransactionUseCaseInput{
+			TransactionRegisteredByUserId: user.Id,
+			WalletId:                      wallet.Id,
+			Amount:                        1500,
+			TransactionType:               string(models.TransactionTypeDeposit),
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if transaction.Amount.Currency != "JPY" {
+			t.Errorf("Expected transaction currency to be JPY, got %v", transaction.Amount.Currency)
+		}
+
+		persistedWallet, err := repos.Wallet.FindById(t.Context(), wallet.Id)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if persistedWallet.Balance.Value != 1500 {
+			t.Errorf("Expected balance to be 1500, got %v", persistedWallet.Balance.Value)
+		}
+	})
+
+	t.Run("should reject an unknown currency", func(t *testing.T) {
+		_, err := useCases.CreateWallet(t.Context(), usecases.CreateWalletUseCaseInput{
+			CreatorID: user.Id,
+			Name:      "Invalid wallet",
+			Currency:  "XYZ",
+		})
+		if !errors.Is(err, errx.ErrInvalidCurrency) {
+			t.Errorf("Expected invalid currency error, got %v", err)
+		}
+	})
+
+	t.Run("should reject transactions in another currency", func(t *testing.T) {
+		wallet, err := useCases.CreateWallet(t.Context(), usecases.CreateWalletUseCaseInput{
+			CreatorID: user.Id,
+			Name:      "Travel wallet",
+			Currency:  "USD",
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		_, err = useCases.RegisterTransaction(t.Context(), usecases.RegisterTransactionUseCaseInput{
+			TransactionRegisteredByUserId: user.Id,
+			WalletId:                      wallet.Id,
+			Amount:                        1000,
+			TransactionType:               string(models.TransactionTypeDeposit),
+			Currency:                      "BRL",
+		})
+		if !errors.Is(err, errx.ErrCurrencyMismatch) {
+			t.Errorf("Expected currency mismatch error, got %v", err)
+		}
+	})
+}
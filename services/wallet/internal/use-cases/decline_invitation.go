@@ -0,0 +1,35 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+)
+
+type DeclineInvitationUseCaseInput struct {
+	MemberId string
+	Member   *models.Member
+	Token    string
+}
+
+func (usecase *UseCase) DeclineInvitation(ctx context.Context, input DeclineInvitationUseCaseInput) (*models.Invitation, error) {
+	member, err := usecase.resolveMember(ctx, input.MemberId, input.Member)
+	if err != nil {
+		return nil, err
+	}
+
+	invitation, err := usecase.repos.Invitation.FindByToken(ctx, input.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := invitation.Decline(*member); err != nil {
+		return nil, err
+	}
+
+	if err := usecase.repos.Invitation.Save(ctx, invitation); err != nil {
+		return nil, err
+	}
+
+	return invitation, nil
+}
@@ -0,0 +1,32 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+)
+
+type DeleteBudgetUseCaseInput struct {
+	MemberId string
+	Member   *models.Member
+	WalletId string
+	BudgetId string
+}
+
+func (usecase *UseCase) DeleteBudget(ctx context.Context, input DeleteBudgetUseCaseInput) error {
+	member, err := usecase.resolveMember(ctx, input.MemberId, input.Member)
+	if err != nil {
+		return err
+	}
+
+	wallet, err := usecase.repos.Wallet.FindById(ctx, input.WalletId)
+	if err != nil {
+		return err
+	}
+
+	if err := wallet.RemoveBudget(input.BudgetId, *member); err != nil {
+		return err
+	}
+
+	return usecase.repos.Wallet.Save(ctx, wallet)
+}
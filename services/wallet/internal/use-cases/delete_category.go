@@ -0,0 +1,36 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+)
+
+type DeleteCategoryUseCaseInput struct {
+	MemberId   string
+	Member     *models.Member
+	WalletId   string
+	CategoryId string
+}
+
+func (usecase *UseCase) DeleteCategory(ctx context.Context, input DeleteCategoryUseCaseInput) error {
+	member, err := usecase.resolveMember(ctx, input.MemberId, input.Member)
+	if err != nil {
+		return err
+	}
+
+	wallet, err := usecase.repos.Wallet.FindById(ctx, input.WalletId)
+	if err != nil {
+		return err
+	}
+
+	if err := wallet.CheckPermission(member.Id, models.PermissionWrite); err != nil {
+		return err
+	}
+
+	if err := wallet.RemoveCategory(input.CategoryId); err != nil {
+		return err
+	}
+
+	return usecase.repos.Wallet.Save(ctx, wallet)
+}
@@ -0,0 +1,82 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+)
+
+// TransactionExportWriter encodes an export as it is streamed. WriteHeader is
+// called once before the entries and Close once after the last one.
+type TransactionExportWriter interface {
+	WriteHeader(header models.TransactionExportHeader) error
+	WriteEntry(entry models.TransactionExportEntry) error
+	Close(closingBalance models.Monetary) error
+}
+
+type ExportTransactionsUseCaseInput struct {
+	MemberId string
+	Member   *models.Member
+	WalletId string
+
+	// From is inclusive and To exclusive, both optional
+	From *time.Time
+	To   *time.Time
+}
+
+// ExportTransactions streams the active transactions of the wallet, oldest
+// first, with the running balance. Nothing is written to the writer unless
+// the member can view the wallet.
+func (usecase *UseCase) ExportTransactions(ctx context.Context, input ExportTransactionsUseCaseInput, writer TransactionExportWriter) error {
+	if input.From != nil && input.To != nil && !input.From.Before(*input.To) {
+		return errx.ErrInvalidReportRange
+	}
+
+	member, err := usecase.resolveMember(ctx, input.MemberId, input.Member)
+	if err != nil {
+		return err
+	}
+
+	if err := usecase.checkWalletPermission(ctx, input.WalletId, member.Id, models.PermissionView); err != nil {
+		return err
+	}
+
+	// Transactions before the zero time do not exist, so the balance starts empty
+	var openingAt time.Time
+	if input.From != nil {
+		openingAt = *input.From
+	}
+
+	balance, err := usecase.repos.Wallet.BalanceAt(ctx, input.WalletId, openingAt)
+	if err != nil {
+		return err
+	}
+
+	err = writer.WriteHeader(models.TransactionExportHeader{
+		WalletId:       input.WalletId,
+		Currency:       balance.Currency,
+		From:           input.From,
+		To:             input.To,
+		OpeningBalance: balance,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = usecase.repos.Wallet.StreamTransactions(ctx, input.WalletId, input.From, input.To, func(transaction models.Transaction) error {
+		// Settlements are paid between members, outside of the wallet
+		if transaction.IsSettlement() {
+			return nil
+		}
+		balance = balance.Sum(transaction.BalanceEffect())
+
+		return writer.WriteEntry(models.TransactionExportEntry{Transaction: transaction, Balance: balance})
+	})
+	if err != nil {
+		return err
+	}
+
+	return writer.Close(balance)
+}
@@ -0,0 +1,151 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lopesgabriel/tellawl/packages/logger"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/infra/database"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/infra/publisher"
+	usecases "github.com/lopesgabriel/tellawl/services/wallet/internal/use-cases"
+	lognoop "go.opentelemetry.io/otel/log/noop"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+)
+
+type recordingExportWriter struct {
+	header  *models.TransactionExportHeader
+	entries []models.TransactionExportEntry
+	closing *models.Monetary
+}
+
+func (w *recordingExportWriter) WriteHeader(header models.TransactionExportHeader) error {
+	w.header = &header
+	return nil
+}
+
+func (w *recordingExportWriter) WriteEntry(entry models.TransactionExportEntry) error {
+	w.entries = append(w.entries, entry)
+	return nil
+}
+
+func (w *recordingExportWriter) Close(closingBalance models.Monetary) error {
+	w.closing = &closingBalance
+	return nil
+}
+
+func TestExportTransactionsUseCase(t *testing.T) {
+	appLogger, err := logger.Init(t.Context(), logger.InitLoggerArgs{
+		LoggerProvider: lognoop.NewLoggerProvider(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to initialize logger: %v", err)
+	}
+	defer appLogger.Shutdown(t.Context())
+
+	eventPublisher := publisher.NewInMemoryEventPublisher(appLogger)
+	user := createMember("member1", "Gabriel", "Lopes", "gabriel@example.com")
+	outsider := createMember("member2", "Matheus", "Lopes", "matheus@example.com")
+
+	repos := database.NewInMemory(eventPublisher)
+	memberRepo := database.NewInMemoryMemberRepository(eventPublisher)
+	repos.Member = memberRepo
+	memberRepo.Items = append(memberRepo.Items, *user, *outsider)
+	useCases := usecases.NewUseCases(usecases.NewUseCasesArgs{
+		Repos:  repos,
+		Tracer: tracenoop.NewTracerProvider().Tracer("test"),
+		Logger: appLogger,
+	})
+
+	brl := func(value int) models.Monetary {
+		return models.Monetary{Value: value, Offset: 100, Currency: models.DefaultCurrency}
+	}
+
+	wallet := models.CreateNewWallet("Household", user, models.DefaultCurrency)
+	wallet.RegisterNewTransaction(brl(500000), *user, models.TransactionTypeDeposit, "Salary", nil)
+	wallet.RegisterNewTransaction(brl(120000), *user, models.TransactionTypeWithdraw, "Rent", nil)
+	voided, _ := wallet.RegisterNewTransaction(brl(9900), *user, models.TransactionTypeWithdraw, "Mistake", nil)
+	wallet.RegisterNewTransaction(brl(35000), *user, models.TransactionTypeWithdraw, "Groceries", nil)
+
+	// One transaction a day, starting on the first of March
+	start := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+	for i := range wallet.Transactions {
+		wallet.Transactions[i].CreatedAt = start.AddDate(0, 0, i)
+	}
+	if _, err := wallet.VoidTransaction(voided.Id, *user); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	repos.Wallet.Save(t.Context(), wallet)
+
+	t.Run("should stream the active transactions with the running balance", func(t *testing.T) {
+		writer := &recordingExportWriter{}
+		err := useCases.ExportTransactions(t.Context(), usecases.ExportTransactionsUseCaseInput{
+			MemberId: user.Id,
+			WalletId: wallet.Id,
+		}, writer)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if writer.header == nil || !writer.header.OpeningBalance.IsZero() {
+			t.Fatalf("Expected an empty opening balance, got %v", writer.header)
+		}
+
+		expected := []struct {
+			description string
+			balance     int
+		}{{"Salary", 500000}, {"Rent", 380000}, {"Groceries", 345000}}
+		if len(writer.entries) != len(expected) {
+			t.Fatalf("Expected %v entries, got %v", len(expected), len(writer.entries))
+		}
+		for i, entry := range writer.entries {
+			if entry.Transaction.Description != expected[i].description || entry.Balance.Cmp(brl(expected[i].balance)) != 0 {
+				t.Errorf("Entry %v: expected %v with %v, got %v with %v", i, expected[i].description, expected[i].balance, entry.Transaction.Description, entry.Balance)
+			}
+		}
+
+		if writer.closing == nil || writer.closing.Cmp(brl(345000)) != 0 {
+			t.Errorf("Expected closing balance to be 345000, got %v", writer.closing)
+		}
+	})
+
+	t.Run("should open the range with the previous balance", func(t *testing.T) {
+		from := start.AddDate(0, 0, 1)
+		to := start.AddDate(0, 0, 2)
+
+		writer := &recordingExportWriter{}
+		err := useCases.ExportTransactions(t.Context(), usecases.ExportTransactionsUseCaseInput{
+			MemberId: user.Id,
+			WalletId: wallet.Id,
+			From:     &from,
+			To:       &to,
+		}, writer)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if writer.header.OpeningBalance.Cmp(brl(500000)) != 0 {
+			t.Errorf("Expected opening balance to be 500000, got %v", writer.header.OpeningBalance)
+		}
+		if len(writer.entries) != 1 || writer.entries[0].Transaction.Description != "Rent" {
+			t.Errorf("Expected only the rent, got %v", writer.entries)
+		}
+	})
+
+	t.Run("should not write anything for members outside the wallet", func(t *testing.T) {
+		writer := &recordingExportWriter{}
+		err := useCases.ExportTransactions(t.Context(), usecases.ExportTransactionsUseCaseInput{
+			MemberId: outsider.Id,
+			WalletId: wallet.Id,
+		}, writer)
+		if !errors.Is(err, errx.ErrInsufficientPermissions) {
+			t.Errorf("Expected insufficient permissions error, got %v", err)
+		}
+
+		if writer.header != nil || writer.entries != nil {
+			t.Errorf("Expected nothing to be written, got %v", writer)
+		}
+	})
+}
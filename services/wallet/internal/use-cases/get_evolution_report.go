@@ -0,0 +1,89 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/repository"
+)
+
+type GetEvolutionReportUseCaseInput struct {
+	MemberId    string
+	Member      *models.Member
+	WalletId    string
+	Granularity string
+
+	// IANA timezone of the member, buckets start at midnight there. Defaults to UTC
+	Timezone string
+
+	// From is inclusive and To exclusive. When not informed the report
+	// covers the last 12 buckets up to now
+	From *time.Time
+	To   *time.Time
+}
+
+func (usecase *UseCase) GetEvolutionReport(ctx context.Context, input GetEvolutionReportUseCaseInput) (*models.EvolutionReport, error) {
+	query, err := newEvolutionQuery(input.Granularity, input.Timezone, input.From, input.To)
+	if err != nil {
+		return nil, err
+	}
+
+	member, err := usecase.resolveMember(ctx, input.MemberId, input.Member)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := usecase.checkWalletPermission(ctx, input.WalletId, member.Id, models.PermissionView); err != nil {
+		return nil, err
+	}
+
+	aggregates, err := usecase.repos.Wallet.AggregateEvolution(ctx, input.WalletId, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.BuildEvolutionReport(input.WalletId, query.Granularity, query.Location, query.From, query.To, aggregates.Opening, aggregates.Totals)
+}
+
+// newEvolutionQuery validates the report parameters. Without a range the
+// report covers the last 12 buckets up to now.
+func newEvolutionQuery(rawGranularity, timezone string, rawFrom, rawTo *time.Time) (repository.EvolutionQuery, error) {
+	granularity, err := models.ParseReportGranularity(rawGranularity)
+	if err != nil {
+		return repository.EvolutionQuery{}, err
+	}
+
+	location := time.UTC
+	if timezone != "" {
+		location, err = time.LoadLocation(timezone)
+		if err != nil {
+			return repository.EvolutionQuery{}, errx.ErrInvalidTimezone
+		}
+	}
+
+	to := time.Now().In(location)
+	if rawTo != nil {
+		to = rawTo.In(location)
+	}
+
+	from := granularity.BucketStart(to, location)
+	for range 11 {
+		from = granularity.BucketStart(from.Add(-time.Nanosecond), location)
+	}
+	if rawFrom != nil {
+		from = rawFrom.In(location)
+	}
+
+	if !from.Before(to) {
+		return repository.EvolutionQuery{}, errx.ErrInvalidReportRange
+	}
+
+	return repository.EvolutionQuery{
+		Granularity: granularity,
+		Location:    location,
+		From:        from,
+		To:          to,
+	}, nil
+}
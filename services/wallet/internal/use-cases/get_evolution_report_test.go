@@ -0,0 +1,108 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lopesgabriel/tellawl/packages/logger"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/infra/database"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/infra/publisher"
+	usecases "github.com/lopesgabriel/tellawl/services/wallet/internal/use-cases"
+	lognoop "go.opentelemetry.io/otel/log/noop"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestGetEvolutionReportUseCase(t *testing.T) {
+	appLogger, err := logger.Init(t.Context(), logger.InitLoggerArgs{
+		LoggerProvider: lognoop.NewLoggerProvider(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to initialize logger: %v", err)
+	}
+	defer appLogger.Shutdown(t.Context())
+
+	eventPublisher := publisher.NewInMemoryEventPublisher(appLogger)
+	user := createMember("member1", "Gabriel", "Lopes", "gabriel@example.com")
+
+	repos := database.NewInMemory(eventPublisher)
+	memberRepo := database.NewInMemoryMemberRepository(eventPublisher)
+	repos.Member = memberRepo
+	memberRepo.Items = append(memberRepo.Items, *user)
+	useCases := usecases.NewUseCases(usecases.NewUseCasesArgs{
+		Repos:  repos,
+		Tracer: tracenoop.NewTracerProvider().Tracer("test"),
+		Logger: appLogger,
+	})
+
+	household := models.CreateNewWallet("Household", user, models.DefaultCurrency)
+	savings := models.CreateNewWallet("Savings", user, models.DefaultCurrency)
+	repos.Wallet.SaveAll(t.Context(), household, savings)
+
+	register := func(amount int, transactionType models.TransactionType) {
+		_, err := useCases.RegisterTransaction(t.Context(), usecases.RegisterTransactionUseCaseInput{
+			TransactionRegisteredByUserId: user.Id,
+			WalletId:                      household.Id,
+			Amount:                        amount,
+			TransactionType:               string(transactionType),
+			Description:                   "Test",
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	register(500000, models.TransactionTypeDeposit)
+	register(120000, models.TransactionTypeWithdraw)
+
+	_, err = useCases.RegisterTransfer(t.Context(), usecases.RegisterTransferUseCaseInput{
+		MemberId:     user.Id,
+		FromWalletId: household.Id,
+		ToWalletId:   savings.Id,
+		Amount:       100000,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	t.Run("should keep transfers out of income and outcome", func(t *testing.T) {
+		report, err := useCases.GetEvolutionReport(t.Context(), usecases.GetEvolutionReportUseCaseInput{
+			MemberId:    user.Id,
+			WalletId:    household.Id,
+			Granularity: "month",
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if len(report.Buckets) != 12 {
+			t.Fatalf("Expected 12 buckets, got %v", len(report.Buckets))
+		}
+
+		current := report.Buckets[len(report.Buckets)-1]
+		if current.Income.Value != 500000 {
+			t.Errorf("Expected income to be 500000, got %v", current.Income.Value)
+		}
+		if current.Outcome.Value != 120000 {
+			t.Errorf("Expected outcome to be 120000, got %v", current.Outcome.Value)
+		}
+		if current.Transfers.Value != -100000 {
+			t.Errorf("Expected transfers to be -100000, got %v", current.Transfers.Value)
+		}
+		if current.ClosingBalance.Value != 280000 {
+			t.Errorf("Expected closing balance to be 280000, got %v", current.ClosingBalance.Value)
+		}
+	})
+
+	t.Run("should reject unknown granularities", func(t *testing.T) {
+		_, err := useCases.GetEvolutionReport(t.Context(), usecases.GetEvolutionReportUseCaseInput{
+			MemberId:    user.Id,
+			WalletId:    household.Id,
+			Granularity: "day",
+		})
+		if !errors.Is(err, errx.ErrInvalidReportGranularity) {
+			t.Errorf("Expected invalid report granularity error, got %v", err)
+		}
+	})
+}
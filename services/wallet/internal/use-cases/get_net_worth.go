@@ -0,0 +1,142 @@
+package usecases
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+)
+
+type GetNetWorthUseCaseInput struct {
+	MemberId string
+	Member   *models.Member
+
+	// Currency of the consolidated amounts, defaults to models.DefaultCurrency
+	BaseCurrency string
+
+	// When informed only these wallets are consolidated, they must be
+	// accessible to the member
+	IncludeWalletIds []string
+	ExcludeWalletIds []string
+
+	// Evolution parameters, the same as GetEvolutionReport
+	Granularity string
+	Timezone    string
+	From        *time.Time
+	To          *time.Time
+}
+
+// GetNetWorth consolidates the balances and the evolution of the wallets the
+// member has access to. Amounts are converted to the base currency with the
+// current exchange rates, past buckets included.
+func (usecase *UseCase) GetNetWorth(ctx context.Context, input GetNetWorthUseCaseInput) (*models.NetWorth, error) {
+	currency, err := models.ParseCurrency(input.BaseCurrency)
+	if err != nil {
+		return nil, err
+	}
+
+	query, err := newEvolutionQuery(input.Granularity, input.Timezone, input.From, input.To)
+	if err != nil {
+		return nil, err
+	}
+
+	member, err := usecase.resolveMember(ctx, input.MemberId, input.Member)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries, err := usecase.repos.Wallet.FindSummariesByUserId(ctx, member.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, walletId := range input.IncludeWalletIds {
+		if !slices.ContainsFunc(summaries, func(summary models.WalletSummary) bool { return summary.Id == walletId }) {
+			return nil, errx.ErrNotFound
+		}
+	}
+
+	wallets := []models.NetWorthWallet{}
+	opening := models.Monetary{Offset: models.CurrencyOffset(currency), Currency: currency}
+	totals := []models.EvolutionTotals{}
+
+	for _, summary := range summaries {
+		if len(input.IncludeWalletIds) > 0 && !slices.Contains(input.IncludeWalletIds, summary.Id) {
+			continue
+		}
+		if slices.Contains(input.ExcludeWalletIds, summary.Id) {
+			continue
+		}
+
+		rate, err := usecase.repos.ExchangeRate.FindRate(ctx, summary.Balance.Currency, currency)
+		if err != nil {
+			return nil, err
+		}
+
+		balance, err := summary.Balance.Convert(*rate, models.RoundHalfEven)
+		if err != nil {
+			return nil, err
+		}
+
+		wallets = append(wallets, models.NetWorthWallet{
+			Wallet:  summary,
+			Rate:    *rate,
+			Balance: balance,
+		})
+
+		aggregates, err := usecase.repos.Wallet.AggregateEvolution(ctx, summary.Id, query)
+		if err != nil {
+			return nil, err
+		}
+
+		walletOpening, err := aggregates.Opening.Convert(*rate, models.RoundHalfEven)
+		if err != nil {
+			return nil, err
+		}
+		opening = opening.Sum(walletOpening)
+
+		// Totals of the same bucket are added up when the report is built
+		for _, total := range aggregates.Totals {
+			converted, err := convertEvolutionTotals(total, *rate)
+			if err != nil {
+				return nil, err
+			}
+			totals = append(totals, converted)
+		}
+	}
+
+	netWorth := models.NewNetWorth(currency, wallets)
+
+	netWorth.Evolution, err = models.BuildEvolutionReport("", query.Granularity, query.Location, query.From, query.To, opening, totals)
+	if err != nil {
+		return nil, err
+	}
+
+	return netWorth, nil
+}
+
+func convertEvolutionTotals(total models.EvolutionTotals, rate models.ExchangeRate) (models.EvolutionTotals, error) {
+	income, err := total.Income.Convert(rate, models.RoundHalfEven)
+	if err != nil {
+		return models.EvolutionTotals{}, err
+	}
+
+	outcome, err := total.Outcome.Convert(rate, models.RoundHalfEven)
+	if err != nil {
+		return models.EvolutionTotals{}, err
+	}
+
+	transfers, err := total.Transfers.Convert(rate, models.RoundHalfEven)
+	if err != nil {
+		return models.EvolutionTotals{}, err
+	}
+
+	return models.EvolutionTotals{
+		Start:     total.Start,
+		Income:    income,
+		Outcome:   outcome,
+		Transfers: transfers,
+	}, nil
+}
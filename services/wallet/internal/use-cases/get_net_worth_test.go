@@ -0,0 +1,128 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lopesgabriel/tellawl/packages/logger"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/infra/database"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/infra/publisher"
+	usecases "github.com/lopesgabriel/tellawl/services/wallet/internal/use-cases"
+	lognoop "go.opentelemetry.io/otel/log/noop"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestGetNetWorthUseCase(t *testing.T) {
+	appLogger, err := logger.Init(t.Context(), logger.InitLoggerArgs{
+		LoggerProvider: lognoop.NewLoggerProvider(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to initialize logger: %v", err)
+	}
+	defer appLogger.Shutdown(t.Context())
+
+	eventPublisher := publisher.NewInMemoryEventPublisher(appLogger)
+	user := createMember("member1", "Gabriel", "Lopes", "gabriel@example.com")
+
+	rates, err := database.ParseExchangeRates("USD/BRL=5.10")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	repos := database.NewInMemory(eventPublisher)
+	memberRepo := database.NewInMemoryMemberRepository(eventPublisher)
+	repos.Member = memberRepo
+	repos.ExchangeRate = database.NewStaticExchangeRateRepository(rates...)
+	memberRepo.Items = append(memberRepo.Items, *user)
+	useCases := usecases.NewUseCases(usecases.NewUseCasesArgs{
+		Repos:  repos,
+		Tracer: tracenoop.NewTracerProvider().Tracer("test"),
+		Logger: appLogger,
+	})
+
+	household := models.CreateNewWallet("Household", user, models.DefaultCurrency)
+	travel := models.CreateNewWallet("Travel", user, "USD")
+	repos.Wallet.SaveAll(t.Context(), household, travel)
+
+	register := func(walletId string, amount int) {
+		_, err := useCases.RegisterTransaction(t.Context(), usecases.RegisterTransactionUseCaseInput{
+			TransactionRegisteredByUserId: user.Id,
+			WalletId:                      walletId,
+			Amount:                        amount,
+			TransactionType:               string(models.TransactionTypeDeposit),
+			Description:                   "Test",
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	register(household.Id, 500000)
+	register(travel.Id, 10000)
+
+	t.Run("should convert the balances to the base currency", func(t *testing.T) {
+		netWorth, err := useCases.GetNetWorth(t.Context(), usecases.GetNetWorthUseCaseInput{
+			MemberId: user.Id,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if netWorth.Total.Value != 551000 || netWorth.Total.Currency != "BRL" {
+			t.Errorf("Expected total to be 5510.00 BRL, got %v", netWorth.Total)
+		}
+
+		shares := map[string]float64{}
+		for _, wallet := range netWorth.Wallets {
+			shares[wallet.Wallet.Id] = wallet.Share
+		}
+		if shares[household.Id] != 90.74 || shares[travel.Id] != 9.25 {
+			t.Errorf("Expected shares of 90.74 and 9.25, got %v", shares)
+		}
+
+		current := netWorth.Evolution.Buckets[len(netWorth.Evolution.Buckets)-1]
+		if current.Income.Value != 551000 {
+			t.Errorf("Expected consolidated income to be 551000, got %v", current.Income.Value)
+		}
+	})
+
+	t.Run("should leave excluded wallets out", func(t *testing.T) {
+		netWorth, err := useCases.GetNetWorth(t.Context(), usecases.GetNetWorthUseCaseInput{
+			MemberId:         user.Id,
+			BaseCurrency:     "USD",
+			ExcludeWalletIds: []string{household.Id},
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if len(netWorth.Wallets) != 1 || netWorth.Wallets[0].Share != 100 {
+			t.Fatalf("Expected only the travel wallet with the whole share, got %v", netWorth.Wallets)
+		}
+		if netWorth.Total.Value != 10000 || netWorth.Total.Currency != "USD" {
+			t.Errorf("Expected total to be 100.00 USD, got %v", netWorth.Total)
+		}
+	})
+
+	t.Run("should reject wallets the member cannot access", func(t *testing.T) {
+		_, err := useCases.GetNetWorth(t.Context(), usecases.GetNetWorthUseCaseInput{
+			MemberId:         user.Id,
+			IncludeWalletIds: []string{"unknown"},
+		})
+		if !errors.Is(err, errx.ErrNotFound) {
+			t.Errorf("Expected not found error, got %v", err)
+		}
+	})
+
+	t.Run("should fail without an exchange rate", func(t *testing.T) {
+		_, err := useCases.GetNetWorth(t.Context(), usecases.GetNetWorthUseCaseInput{
+			MemberId:     user.Id,
+			BaseCurrency: "EUR",
+		})
+		if !errors.Is(err, errx.ErrExchangeRateNotFound) {
+			t.Errorf("Expected exchange rate not found error, got %v", err)
+		}
+	})
+}
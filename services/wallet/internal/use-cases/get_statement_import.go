@@ -0,0 +1,37 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+)
+
+type GetStatementImportUseCaseInput struct {
+	MemberId string
+	Member   *models.Member
+	WalletId string
+	ImportId string
+}
+
+func (usecase *UseCase) GetStatementImport(ctx context.Context, input GetStatementImportUseCaseInput) (*models.StatementImport, error) {
+	member, err := usecase.resolveMember(ctx, input.MemberId, input.Member)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := usecase.checkWalletPermission(ctx, input.WalletId, member.Id, models.PermissionView); err != nil {
+		return nil, err
+	}
+
+	statementImport, err := usecase.repos.StatementImport.FindById(ctx, input.ImportId)
+	if err != nil {
+		return nil, err
+	}
+
+	if statementImport.WalletId != input.WalletId {
+		return nil, errx.ErrStatementImportNotFound
+	}
+
+	return statementImport, nil
+}
@@ -0,0 +1,53 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/repository"
+)
+
+// DefaultRecentTransactions is how many transactions the wallet detail
+// includes when no limit is informed.
+const DefaultRecentTransactions = 20
+
+type GetWalletUseCaseInput struct {
+	MemberId string
+	Member   *models.Member
+	WalletId string
+
+	// Bounded by repository.MaxTransactionPageSize
+	RecentTransactions int
+}
+
+func (usecase *UseCase) GetWallet(ctx context.Context, input GetWalletUseCaseInput) (*models.WalletDetail, error) {
+	member, err := usecase.resolveMember(ctx, input.MemberId, input.Member)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := usecase.checkWalletPermission(ctx, input.WalletId, member.Id, models.PermissionView); err != nil {
+		return nil, err
+	}
+
+	wallet, err := usecase.repos.Wallet.FindDetailById(ctx, input.WalletId)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.RecentTransactions <= 0 {
+		input.RecentTransactions = DefaultRecentTransactions
+	}
+
+	page, err := usecase.repos.Wallet.ListTransactions(ctx, input.WalletId, repository.TransactionFilter{
+		Limit: input.RecentTransactions,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	wallet.RecentTransactions = page.Transactions
+	wallet.NextTransactionsCursor = page.NextCursor
+
+	return wallet, nil
+}
@@ -0,0 +1,111 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lopesgabriel/tellawl/packages/logger"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/infra/database"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/infra/publisher"
+	usecases "github.com/lopesgabriel/tellawl/services/wallet/internal/use-cases"
+	lognoop "go.opentelemetry.io/otel/log/noop"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestGetWalletUseCase(t *testing.T) {
+	appLogger, err := logger.Init(t.Context(), logger.InitLoggerArgs{
+		LoggerProvider: lognoop.NewLoggerProvider(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to initialize logger: %v", err)
+	}
+	defer appLogger.Shutdown(t.Context())
+
+	eventPublisher := publisher.NewInMemoryEventPublisher(appLogger)
+	user := createMember("member1", "Gabriel", "Lopes", "gabriel@example.com")
+	outsider := createMember("member2", "Matheus", "Lopes", "matheus@example.com")
+
+	repos := database.NewInMemory(eventPublisher)
+	memberRepo := database.NewInMemoryMemberRepository(eventPublisher)
+	repos.Member = memberRepo
+	memberRepo.Items = append(memberRepo.Items, *user, *outsider)
+	useCases := usecases.NewUseCases(usecases.NewUseCasesArgs{
+		Repos:  repos,
+		Tracer: tracenoop.NewTracerProvider().Tracer("test"),
+		Logger: appLogger,
+	})
+
+	wallet := models.CreateNewWallet("Test wallet", user, models.DefaultCurrency)
+	repos.Wallet.Save(t.Context(), wallet)
+
+	for range 5 {
+		_, err := useCases.RegisterTransaction(t.Context(), usecases.RegisterTransactionUseCaseInput{
+			TransactionRegisteredByUserId: user.Id,
+			WalletId:                      wallet.Id,
+			Amount:                        1000,
+			TransactionType:               string(models.TransactionTypeDeposit),
+			Description:                   "Deposit",
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	t.Run("should return a bounded page of recent transactions", func(t *testing.T) {
+		detail, err := useCases.GetWallet(t.Context(), usecases.GetWalletUseCaseInput{
+			MemberId:           user.Id,
+			WalletId:           wallet.Id,
+			RecentTransactions: 3,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if len(detail.RecentTransactions) != 3 {
+			t.Errorf("Expected 3 recent transactions, got %v", len(detail.RecentTransactions))
+		}
+		if detail.NextTransactionsCursor == "" {
+			t.Errorf("Expected a cursor to the older transactions")
+		}
+		if detail.Balance.Value != 5000 {
+			t.Errorf("Expected balance to be 5000, got %v", detail.Balance.Value)
+		}
+		if len(detail.Members) != 1 {
+			t.Errorf("Expected 1 member, got %v", len(detail.Members))
+		}
+	})
+
+	t.Run("should not show the wallet to outsiders", func(t *testing.T) {
+		_, err := useCases.GetWallet(t.Context(), usecases.GetWalletUseCaseInput{
+			MemberId: outsider.Id,
+			WalletId: wallet.Id,
+		})
+		if !errors.Is(err, errx.ErrInsufficientPermissions) {
+			t.Errorf("Expected insufficient permissions error, got %v", err)
+		}
+	})
+
+	t.Run("should summarize the activity of listed wallets", func(t *testing.T) {
+		wallets, err := useCases.ListUserWallets(t.Context(), usecases.ListUserWalletsUseCaseInput{
+			UserId: user.Id,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if len(wallets) != 1 {
+			t.Fatalf("Expected 1 wallet, got %v", len(wallets))
+		}
+		if wallets[0].TransactionCount != 5 {
+			t.Errorf("Expected 5 transactions, got %v", wallets[0].TransactionCount)
+		}
+		if wallets[0].LastActivityAt == nil {
+			t.Errorf("Expected the last activity to be set")
+		}
+		if wallets[0].Role != models.WalletRoleOwner {
+			t.Errorf("Expected owner role, got %v", wallets[0].Role)
+		}
+	})
+}
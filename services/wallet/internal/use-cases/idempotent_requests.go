@@ -0,0 +1,83 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+)
+
+type BeginIdempotentRequestUseCaseInput struct {
+	MemberId string
+	Key      string
+	Method   string
+	Path     string
+	Body     []byte
+}
+
+// BeginIdempotentRequest reserves the key for the request. When the member
+// already sent the same request with the key and it completed, the stored
+// request is returned with replay set and its response must be sent again.
+// Otherwise the request runs and is finished with CompleteIdempotentRequest.
+func (usecase *UseCase) BeginIdempotentRequest(ctx context.Context, input BeginIdempotentRequestUseCaseInput) (request *models.IdempotentRequest, replay bool, err error) {
+	request, err = models.NewIdempotentRequest(input.MemberId, input.Key, input.Method, input.Path, input.Body)
+	if err != nil {
+		return nil, false, err
+	}
+
+	existing, err := usecase.repos.IdempotencyKey.Reserve(ctx, request)
+	if err != nil {
+		return nil, false, err
+	}
+	if existing == nil {
+		return request, false, nil
+	}
+
+	if existing.Fingerprint != request.Fingerprint {
+		return nil, false, errx.ErrIdempotencyKeyReused
+	}
+
+	if !existing.IsCompleted() {
+		return nil, false, errx.ErrIdempotencyKeyInProgress
+	}
+
+	return existing, true, nil
+}
+
+type CompleteIdempotentRequestUseCaseInput struct {
+	Request     *models.IdempotentRequest
+	StatusCode  int
+	ContentType string
+	Body        []byte
+}
+
+// CompleteIdempotentRequest stores the response to replay on retries. Server
+// errors are not stored, the key is released so the request can be retried.
+func (usecase *UseCase) CompleteIdempotentRequest(ctx context.Context, input CompleteIdempotentRequestUseCaseInput) error {
+	if input.StatusCode >= 500 {
+		return usecase.ReleaseIdempotentRequest(ctx, input.Request)
+	}
+
+	input.Request.Complete(input.StatusCode, input.ContentType, input.Body)
+	return usecase.repos.IdempotencyKey.Complete(ctx, input.Request)
+}
+
+type PurgeIdempotencyKeysUseCaseInput struct {
+	Now time.Time
+}
+
+// PurgeIdempotencyKeys deletes the keys expired by now along with their
+// stored responses, returning how many were deleted.
+func (usecase *UseCase) PurgeIdempotencyKeys(ctx context.Context, input PurgeIdempotencyKeysUseCaseInput) (int, error) {
+	if input.Now.IsZero() {
+		input.Now = time.Now()
+	}
+
+	return usecase.repos.IdempotencyKey.DeleteExpired(ctx, input.Now)
+}
+
+// ReleaseIdempotentRequest forgets a request that did not complete.
+func (usecase *UseCase) ReleaseIdempotentRequest(ctx context.Context, request *models.IdempotentRequest) error {
+	return usecase.repos.IdempotencyKey.Release(ctx, request)
+}
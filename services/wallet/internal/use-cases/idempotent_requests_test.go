@@ -0,0 +1,175 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lopesgabriel/tellawl/packages/logger"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/infra/database"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/infra/publisher"
+	usecases "github.com/lopesgabriel/tellawl/services/wallet/internal/use-cases"
+	lognoop "go.opentelemetry.io/otel/log/noop"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestIdempotentRequests(t *testing.T) {
+	appLogger, err := logger.Init(t.Context(), logger.InitLoggerArgs{
+		LoggerProvider: lognoop.NewLoggerProvider(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to initialize logger: %v", err)
+	}
+	defer appLogger.Shutdown(t.Context())
+
+	eventPublisher := publisher.NewInMemoryEventPublisher(appLogger)
+	repos := database.NewInMemory(eventPublisher)
+	useCases := usecases.NewUseCases(usecases.NewUseCasesArgs{
+		Repos:  repos,
+		Tracer: tracenoop.NewTracerProvider().Tracer("test"),
+		Logger: appLogger,
+	})
+
+	begin := func(key, body string) (bool, error) {
+		request, replay, err := useCases.BeginIdempotentRequest(t.Context(), usecases.BeginIdempotentRequestUseCaseInput{
+			MemberId: "member1",
+			Key:      key,
+			Method:   "POST",
+			Path:     "/wallets/wallet1/transactions",
+			Body:     []byte(body),
+		})
+		if err != nil {
+			return false, err
+		}
+
+		if !replay {
+			err = useCases.CompleteIdempotentRequest(t.Context(), usecases.CompleteIdempotentRequestUseCaseInput{
+				Request:     request,
+				StatusCode:  201,
+				ContentType: "application/json",
+				Body:        []byte(`{"id":"transaction1"}`),
+			})
+		} else if string(request.Body) != `{"id":"transaction1"}` || request.StatusCode != 201 {
+			t.Errorf("Expected the first response to be replayed, got %v %s", request.StatusCode, request.Body)
+		}
+
+		return replay, err
+	}
+
+	t.Run("should replay the response of a retried request", func(t *testing.T) {
+		if replay, err := begin("key1", `{"amount":1000}`); err != nil || replay {
+			t.Fatalf("Expected the first request to run, got replay %v and error %v", replay, err)
+		}
+
+		if replay, err := begin("key1", `{"amount":1000}`); err != nil || !replay {
+			t.Errorf("Expected the retry to be replayed, got replay %v and error %v", replay, err)
+		}
+	})
+
+	t.Run("should reject a reused key with a different payload", func(t *testing.T) {
+		if _, err := begin("key1", `{"amount":2000}`); !errors.Is(err, errx.ErrIdempotencyKeyReused) {
+			t.Errorf("Expected idempotency key reused error, got %v", err)
+		}
+	})
+
+	t.Run("should reject retries while the first request runs", func(t *testing.T) {
+		input := usecases.BeginIdempotentRequestUseCaseInput{MemberId: "member1", Key: "key2", Method: "POST", Path: "/wallets"}
+		if _, _, err := useCases.BeginIdempotentRequest(t.Context(), input); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if _, _, err := useCases.BeginIdempotentRequest(t.Context(), input); !errors.Is(err, errx.ErrIdempotencyKeyInProgress) {
+			t.Errorf("Expected idempotency key in progress error, got %v", err)
+		}
+	})
+
+	t.Run("should release the key after a server error", func(t *testing.T) {
+		input := usecases.BeginIdempotentRequestUseCaseInput{MemberId: "member1", Key: "key3", Method: "POST", Path: "/wallets"}
+		request, _, err := useCases.BeginIdempotentRequest(t.Context(), input)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		err = useCases.CompleteIdempotentRequest(t.Context(), usecases.CompleteIdempotentRequestUseCaseInput{Request: request, StatusCode: 503})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if _, replay, err := useCases.BeginIdempotentRequest(t.Context(), input); err != nil || replay {
+			t.Errorf("Expected the request to run again, got replay %v and error %v", replay, err)
+		}
+	})
+
+	t.Run("should let a retry take over a request that never completed", func(t *testing.T) {
+		input := usecases.BeginIdempotentRequestUseCaseInput{MemberId: "member1", Key: "key4", Method: "POST", Path: "/wallets"}
+		stale, err := models.NewIdempotentRequest(input.MemberId, input.Key, input.Method, input.Path, input.Body)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		stale.CreatedAt = time.Now().Add(-models.IdempotencyLockTimeout)
+		if _, err := repos.IdempotencyKey.Reserve(t.Context(), stale); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		retry, replay, err := useCases.BeginIdempotentRequest(t.Context(), input)
+		if err != nil || replay {
+			t.Fatalf("Expected the retry to run the request, got replay %v and error %v", replay, err)
+		}
+
+		if _, _, err := useCases.BeginIdempotentRequest(t.Context(), input); !errors.Is(err, errx.ErrIdempotencyKeyInProgress) {
+			t.Errorf("Expected the retry to hold the key, got %v", err)
+		}
+
+		// The slow original request finishing late leaves the retry alone
+		err = useCases.CompleteIdempotentRequest(t.Context(), usecases.CompleteIdempotentRequestUseCaseInput{Request: stale, StatusCode: 201, Body: []byte(`{"id":"stale"}`)})
+		if !errors.Is(err, errx.ErrIdempotencyKeyTakenOver) {
+			t.Errorf("Expected idempotency key taken over error, got %v", err)
+		}
+		if err := useCases.ReleaseIdempotentRequest(t.Context(), stale); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if _, _, err := useCases.BeginIdempotentRequest(t.Context(), input); !errors.Is(err, errx.ErrIdempotencyKeyInProgress) {
+			t.Errorf("Expected the retry to still hold the key, got %v", err)
+		}
+
+		err = useCases.CompleteIdempotentRequest(t.Context(), usecases.CompleteIdempotentRequestUseCaseInput{Request: retry, StatusCode: 201, Body: []byte(`{"id":"retry"}`)})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		replayed, replay, err := useCases.BeginIdempotentRequest(t.Context(), input)
+		if err != nil || !replay {
+			t.Fatalf("Expected the retry to be replayed, got replay %v and error %v", replay, err)
+		}
+		if string(replayed.Body) != `{"id":"retry"}` {
+			t.Errorf("Expected the response of the retry to be replayed, got %s", replayed.Body)
+		}
+	})
+
+	t.Run("should purge the expired keys", func(t *testing.T) {
+		if replay, err := begin("key5", `{"amount":1000}`); err != nil || replay {
+			t.Fatalf("Expected the request to run, got replay %v and error %v", replay, err)
+		}
+
+		deleted, err := useCases.PurgeIdempotencyKeys(t.Context(), usecases.PurgeIdempotencyKeysUseCaseInput{Now: time.Now()})
+		if err != nil || deleted != 0 {
+			t.Errorf("Expected no key to be purged before it expires, got %v and error %v", deleted, err)
+		}
+
+		deleted, err = useCases.PurgeIdempotencyKeys(t.Context(), usecases.PurgeIdempotencyKeysUseCaseInput{
+			Now: time.Now().Add(models.IdempotencyKeyTTL),
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if deleted != 5 {
+			t.Errorf("Expected the 5 keys stored to be purged, got %v", deleted)
+		}
+
+		if replay, err := begin("key5", `{"amount":2000}`); err != nil || replay {
+			t.Errorf("Expected the purged key to be usable again, got replay %v and error %v", replay, err)
+		}
+	})
+}
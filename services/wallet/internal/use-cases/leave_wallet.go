@@ -0,0 +1,31 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+)
+
+type LeaveWalletUseCaseInput struct {
+	MemberId string
+	Member   *models.Member
+	WalletId string
+}
+
+func (usecase *UseCase) LeaveWallet(ctx context.Context, input LeaveWalletUseCaseInput) error {
+	member, err := usecase.resolveMember(ctx, input.MemberId, input.Member)
+	if err != nil {
+		return err
+	}
+
+	wallet, err := usecase.repos.Wallet.FindById(ctx, input.WalletId)
+	if err != nil {
+		return err
+	}
+
+	if err := wallet.RemoveMember(member.Id, member.Id); err != nil {
+		return err
+	}
+
+	return usecase.repos.Wallet.Save(ctx, wallet)
+}
@@ -0,0 +1,31 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+)
+
+type ListBudgetsUseCaseInput struct {
+	MemberId string
+	Member   *models.Member
+	WalletId string
+}
+
+func (usecase *UseCase) ListBudgets(ctx context.Context, input ListBudgetsUseCaseInput) ([]models.Budget, error) {
+	member, err := usecase.resolveMember(ctx, input.MemberId, input.Member)
+	if err != nil {
+		return nil, err
+	}
+
+	wallet, err := usecase.repos.Wallet.FindById(ctx, input.WalletId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := wallet.CheckPermission(member.Id, models.PermissionView); err != nil {
+		return nil, err
+	}
+
+	return wallet.Budgets, nil
+}
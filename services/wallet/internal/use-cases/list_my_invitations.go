@@ -0,0 +1,37 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+)
+
+type ListMyInvitationsUseCaseInput struct {
+	MemberId string
+	Member   *models.Member
+}
+
+// ListMyInvitations returns the invitations the member can still answer,
+// newest first.
+func (usecase *UseCase) ListMyInvitations(ctx context.Context, input ListMyInvitationsUseCaseInput) ([]models.Invitation, error) {
+	member, err := usecase.resolveMember(ctx, input.MemberId, input.Member)
+	if err != nil {
+		return nil, err
+	}
+
+	pending, err := usecase.repos.Invitation.FindPendingFor(ctx, *member)
+	if err != nil {
+		return nil, err
+	}
+
+	currentTime := time.Now()
+	invitations := []models.Invitation{}
+	for _, invitation := range pending {
+		if invitation.StatusAt(currentTime) == models.InvitationStatusPending {
+			invitations = append(invitations, invitation)
+		}
+	}
+
+	return invitations, nil
+}
@@ -0,0 +1,36 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/repository"
+)
+
+type ListTransactionsUseCaseInput struct {
+	MemberId string
+	Member   *models.Member
+	WalletId string
+	Filter   repository.TransactionFilter
+}
+
+func (usecase *UseCase) ListTransactions(ctx context.Context, input ListTransactionsUseCaseInput) (*repository.TransactionPage, error) {
+	member, err := usecase.resolveMember(ctx, input.MemberId, input.Member)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := input.Filter
+	switch filter.Type {
+	case "", models.TransactionTypeDeposit, models.TransactionTypeWithdraw, models.TransactionTypeSettlement:
+	default:
+		return nil, errx.ErrInvalidTransactionType
+	}
+
+	if err := usecase.checkWalletPermission(ctx, input.WalletId, member.Id, models.PermissionView); err != nil {
+		return nil, err
+	}
+
+	return usecase.repos.Wallet.ListTransactions(ctx, input.WalletId, filter)
+}
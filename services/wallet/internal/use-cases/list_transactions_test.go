@@ -0,0 +1,146 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lopesgabriel/tellawl/packages/logger"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/repository"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/infra/database"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/infra/publisher"
+	usecases "github.com/lopesgabriel/tellawl/services/wallet/internal/use-cases"
+	lognoop "go.opentelemetry.io/otel/log/noop"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestListTransactionsUseCase(t *testing.T) {
+	appLogger, err := logger.Init(t.Context(), logger.InitLoggerArgs{
+		LoggerProvider: lognoop.NewLoggerProvider(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to initialize logger: %v", err)
+	}
+	defer appLogger.Shutdown(t.Context())
+
+	eventPublisher := publisher.NewInMemoryEventPublisher(appLogger)
+	user := createMember("member1", "Gabriel", "Lopes", "gabriel@example.com")
+	user2 := createMember("member2", "Matheus", "Lopes", "matheus@example.com")
+
+	repos := database.NewInMemory(eventPublisher)
+	memberRepo := database.NewInMemoryMemberRepository(eventPublisher)
+	repos.Member = memberRepo
+	memberRepo.Items = append(memberRepo.Items, *user, *user2)
+	useCases := usecases.NewUseCases(usecases.NewUseCasesArgs{
+		Repos:  repos,
+		Tracer: tracenoop.NewTracerProvider().Tracer("test"),
+		Logger: appLogger,
+	})
+
+	wallet := models.CreateNewWallet("Test wallet", user, models.DefaultCurrency)
+	wallet.AddUser(user2, models.WalletRoleEditor)
+	repos.Wallet.Save(t.Context(), wallet)
+
+	register := func(member *models.Member, amount int, transactionType models.TransactionType, description string) {
+		_, err := useCases.RegisterTransaction(t.Context(), usecases.RegisterTransactionUseCaseInput{
+			TransactionRegisteredByUserId: member.Id,
+			WalletId:                      wallet.Id,
+			Amount:                        amount,
+			TransactionType:               string(transactionType),
+			Description:                   description,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	register(user, 500000, models.TransactionTypeDeposit, "Salary")
+	register(user, 2500, models.TransactionTypeWithdraw, "Coffee")
+	register(user2, 35000, models.TransactionTypeWithdraw, "Mercado Central")
+	register(user2, 12000, models.TransactionTypeWithdraw, "Mercado da esquina")
+	register(user, 8000, models.TransactionTypeWithdraw, "Pharmacy")
+
+	list := func(t *testing.T, filter repository.TransactionFilter) *repository.TransactionPage {
+		page, err := useCases.ListTransactions(t.Context(), usecases.ListTransactionsUseCaseInput{
+			MemberId: user.Id,
+			WalletId: wallet.Id,
+			Filter:   filter,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		return page
+	}
+
+	t.Run("should walk every page with the cursor", func(t *testing.T) {
+		seen := map[string]bool{}
+		filter := repository.TransactionFilter{Limit: 2}
+		pages := 0
+
+		for {
+			page := list(t, filter)
+			pages++
+			for _, transaction := range page.Transactions {
+				if seen[transaction.Id] {
+					t.Fatalf("Transaction %v listed twice", transaction.Id)
+				}
+				seen[transaction.Id] = true
+			}
+
+			if page.NextCursor == "" {
+				break
+			}
+			filter.Cursor = page.NextCursor
+		}
+
+		if len(seen) != 5 {
+			t.Errorf("Expected 5 transactions, got %v", len(seen))
+		}
+		if pages != 3 {
+			t.Errorf("Expected 3 pages, got %v", pages)
+		}
+	})
+
+	t.Run("should combine filters", func(t *testing.T) {
+		minAmount := models.Monetary{Value: 150, Offset: 1}
+		page := list(t, repository.TransactionFilter{
+			Type:      models.TransactionTypeWithdraw,
+			CreatedBy: user2.Id,
+			MinAmount: &minAmount,
+			Search:    "mercado",
+		})
+
+		if len(page.Transactions) != 1 {
+			t.Fatalf("Expected 1 transaction, got %v", len(page.Transactions))
+		}
+		if page.Transactions[0].Description != "Mercado Central" {
+			t.Errorf("Expected Mercado Central, got %v", page.Transactions[0].Description)
+		}
+	})
+
+	t.Run("should reject an invalid cursor", func(t *testing.T) {
+		_, err := useCases.ListTransactions(t.Context(), usecases.ListTransactionsUseCaseInput{
+			MemberId: user.Id,
+			WalletId: wallet.Id,
+			Filter:   repository.TransactionFilter{Cursor: "not a cursor"},
+		})
+		if !errors.Is(err, errx.ErrInvalidCursor) {
+			t.Errorf("Expected invalid cursor error, got %v", err)
+		}
+	})
+
+	t.Run("should not list transactions to outsiders", func(t *testing.T) {
+		outsider := createMember("member3", "Outsider", "User", "outsider@example.com")
+		memberRepo.Items = append(memberRepo.Items, *outsider)
+
+		_, err := useCases.ListTransactions(t.Context(), usecases.ListTransactionsUseCaseInput{
+			MemberId: outsider.Id,
+			WalletId: wallet.Id,
+		})
+		if !errors.Is(err, errx.ErrInsufficientPermissions) {
+			t.Errorf("Expected insufficient permissions error, got %v", err)
+		}
+	})
+}
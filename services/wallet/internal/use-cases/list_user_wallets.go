@@ -12,7 +12,8 @@ type ListUserWalletsUseCaseInput struct {
 	Member *models.Member
 }
 
-func (usecase *UseCase) ListUserWallets(ctx context.Context, input ListUserWalletsUseCaseInput) ([]models.Wallet, error) {
+// ListUserWallets lists a summary of the wallets the user has access to.
+func (usecase *UseCase) ListUserWallets(ctx context.Context, input ListUserWalletsUseCaseInput) ([]models.WalletSummary, error) {
 	var user *models.Member
 	if input.Member == nil {
 		member, err := usecase.repos.Member.FindByID(ctx, input.UserId)
@@ -24,7 +25,7 @@ func (usecase *UseCase) ListUserWallets(ctx context.Context, input ListUserWalle
 		user = input.Member
 	}
 
-	userWallets, err := usecase.repos.Wallet.FindByUserId(ctx, user.Id)
+	userWallets, err := usecase.repos.Wallet.FindSummariesByUserId(ctx, user.Id)
 	if err != nil {
 		return nil, err
 	}
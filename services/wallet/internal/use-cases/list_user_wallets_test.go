@@ -40,11 +40,11 @@ func TestListUserWalletsUseCase(t *testing.T) {
 		memberRepo.Items = append(memberRepo.Items, *user)
 		memberRepo.Items = append(memberRepo.Items, *user2)
 
-		wallet := models.CreateNewWallet("Test wallet", user)
-		wallet.AddUser(user2)
+		wallet := models.CreateNewWallet("Test wallet", user, models.DefaultCurrency)
+		wallet.AddUser(user2, models.WalletRoleEditor)
 		repos.Wallet.Save(t.Context(), wallet)
 
-		wallet2 := models.CreateNewWallet("Test wallet 2", user2)
+		wallet2 := models.CreateNewWallet("Test wallet 2", user2, models.DefaultCurrency)
 		repos.Wallet.Save(t.Context(), wallet2)
 
 		wallets, err := useCases.ListUserWallets(t.Context(), usecases.ListUserWalletsUseCaseInput{
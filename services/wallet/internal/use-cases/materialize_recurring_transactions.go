@@ -0,0 +1,71 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+type MaterializeRecurringTransactionsUseCaseInput struct {
+	Now time.Time
+}
+
+// MaterializeRecurringTransactions registers every occurrence due until now,
+// catching up on the ones missed while the service was down. Each occurrence
+// is registered through RegisterTransaction, which only accepts the next
+// pending occurrence of a definition, so running it again or concurrently
+// never registers an occurrence twice.
+func (usecase *UseCase) MaterializeRecurringTransactions(ctx context.Context, input MaterializeRecurringTransactionsUseCaseInput) (int, error) {
+	ctx, span := usecase.tracer.Start(ctx, "MaterializeRecurringTransactions")
+	defer span.End()
+
+	if input.Now.IsZero() {
+		input.Now = time.Now()
+	}
+
+	dueRecurringTransactions, err := usecase.repos.Wallet.FindDueRecurringTransactions(ctx, input.Now)
+	if err != nil {
+		span.SetStatus(codes.Error, "could not find due recurring transactions")
+		span.RecordError(err)
+		return 0, err
+	}
+
+	registered := 0
+	for _, recurring := range dueRecurringTransactions {
+		occurrence := recurring.NextOccurrence
+		for !occurrence.After(input.Now) && (recurring.EndDate == nil || !occurrence.After(*recurring.EndDate)) {
+			_, err := usecase.RegisterTransaction(ctx, RegisterTransactionUseCaseInput{
+				TransactionRegisteredByUserId: recurring.CreatedBy.Id,
+				WalletId:                      recurring.WalletId,
+				TransactionType:               string(recurring.Type),
+				RecurringTransactionId:        recurring.Id,
+				OccurrenceDate:                occurrence,
+			})
+			if errors.Is(err, errx.ErrOccurrenceNotDue) {
+				// Already registered by a previous or concurrent run
+				break
+			}
+			if err != nil {
+				usecase.logger.Error(ctx, "could not register recurring transaction occurrence",
+					slog.String("recurring_transaction_id", recurring.Id),
+					slog.String("wallet_id", recurring.WalletId),
+					slog.String("occurrence", occurrence.Format(time.DateOnly)),
+					slog.String("error", err.Error()),
+				)
+				break
+			}
+
+			registered++
+			occurrence = recurring.OccurrenceAfter(occurrence)
+		}
+	}
+
+	span.SetAttributes(attribute.Int("recurring_transactions.registered", registered))
+	span.SetStatus(codes.Ok, "recurring transactions materialized")
+	return registered, nil
+}
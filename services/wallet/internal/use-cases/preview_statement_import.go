@@ -0,0 +1,112 @@
+package usecases
+
+import (
+	"context"
+	"io"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/repository"
+)
+
+type PreviewStatementImportUseCaseInput struct {
+	MemberId string
+	Member   *models.Member
+	WalletId string
+
+	// csv or ofx, defaults to csv
+	Format  string
+	Content io.Reader
+	// Only used by CSV statements
+	CSVMapping models.CSVMapping
+}
+
+// PreviewStatementImport parses a bank statement and flags the rows already
+// registered in the wallet. Nothing is registered until the import is
+// committed.
+func (usecase *UseCase) PreviewStatementImport(ctx context.Context, input PreviewStatementImportUseCaseInput) (*models.StatementImport, error) {
+	format, err := models.ParseStatementFormat(input.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	member, err := usecase.resolveMember(ctx, input.MemberId, input.Member)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := usecase.checkWalletPermission(ctx, input.WalletId, member.Id, models.PermissionWrite); err != nil {
+		return nil, err
+	}
+
+	wallet, err := usecase.repos.Wallet.FindDetailById(ctx, input.WalletId)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []models.StatementRow
+	switch format {
+	case models.StatementFormatOFX:
+		rows, err = models.ParseOFXStatement(input.Content, wallet.Balance.Currency)
+	default:
+		rows, err = models.ParseCSVStatement(input.Content, input.CSVMapping, wallet.Balance.Currency)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	statementImport := models.NewStatementImport(input.WalletId, format, member.Id, rows)
+	if err := usecase.detectDuplicates(ctx, statementImport); err != nil {
+		return nil, err
+	}
+
+	if err := usecase.repos.StatementImport.Save(ctx, statementImport); err != nil {
+		return nil, err
+	}
+
+	return statementImport, nil
+}
+
+// detectDuplicates compares the import with the bank ids already imported,
+// and with the transactions and previously imported rows within its dates.
+func (usecase *UseCase) detectDuplicates(ctx context.Context, statementImport *models.StatementImport) error {
+	importedExternalIds, err := usecase.repos.StatementImport.FindImportedExternalIds(ctx, statementImport.WalletId, statementImport.ExternalIds())
+	if err != nil {
+		return err
+	}
+
+	from, to, ok := statementImport.DateRange()
+	if !ok {
+		statementImport.DetectDuplicates(nil, nil, importedExternalIds)
+		return nil
+	}
+
+	until := to.AddDate(0, 0, 1)
+	filter := repository.TransactionFilter{
+		From:   &from,
+		To:     &until,
+		Status: models.TransactionStatusActive,
+		Limit:  repository.MaxTransactionPageSize,
+	}
+
+	transactions := []models.Transaction{}
+	for {
+		page, err := usecase.repos.Wallet.ListTransactions(ctx, statementImport.WalletId, filter)
+		if err != nil {
+			return err
+		}
+
+		transactions = append(transactions, page.Transactions...)
+		if page.NextCursor == "" {
+			break
+		}
+		filter.Cursor = page.NextCursor
+	}
+
+	previousRows, err := usecase.repos.StatementImport.FindCommittedRows(ctx, statementImport.WalletId, from, to)
+	if err != nil {
+		return err
+	}
+
+	statementImport.DetectDuplicates(transactions, previousRows, importedExternalIds)
+	return nil
+}
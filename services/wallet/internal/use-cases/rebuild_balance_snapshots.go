@@ -0,0 +1,65 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+type RebuildBalanceSnapshotsUseCaseInput struct {
+	// Wallets to rebuild, every wallet when empty
+	WalletIds []string
+}
+
+// RebuildBalanceSnapshots recomputes the daily balance snapshots from the
+// transactions, e.g. after they drifted or were restored. Wallets are rebuilt
+// one at a time and a failure does not stop the others; the number of wallets
+// rebuilt is returned along with the failures joined in a single error.
+func (usecase *UseCase) RebuildBalanceSnapshots(ctx context.Context, input RebuildBalanceSnapshotsUseCaseInput) (int, error) {
+	ctx, span := usecase.tracer.Start(ctx, "RebuildBalanceSnapshots")
+	defer span.End()
+
+	walletIds := input.WalletIds
+	if len(walletIds) == 0 {
+		ids, err := usecase.repos.Wallet.ListIds(ctx)
+		if err != nil {
+			span.SetStatus(codes.Error, "could not list the wallets")
+			span.RecordError(err)
+			return 0, err
+		}
+		walletIds = ids
+	}
+
+	rebuilt := 0
+	failures := []error{}
+	for _, walletId := range walletIds {
+		if err := usecase.repos.Wallet.RebuildBalanceSnapshots(ctx, walletId); err != nil {
+			usecase.logger.Error(ctx, "could not rebuild the balance snapshots",
+				slog.String("wallet_id", walletId),
+				slog.String("error", err.Error()),
+			)
+			failures = append(failures, fmt.Errorf("wallet %s: %w", walletId, err))
+			continue
+		}
+		rebuilt++
+	}
+
+	span.SetAttributes(
+		attribute.Int("wallets.count", len(walletIds)),
+		attribute.Int("wallets.rebuilt", rebuilt),
+		attribute.Int("wallets.failed", len(failures)),
+	)
+	if len(failures) > 0 {
+		err := fmt.Errorf("could not rebuild the balance snapshots of %d of %d wallets: %w", len(failures), len(walletIds), errors.Join(failures...))
+		span.SetStatus(codes.Error, "some balance snapshots could not be rebuilt")
+		span.RecordError(err)
+		return rebuilt, err
+	}
+
+	span.SetStatus(codes.Ok, "balance snapshots rebuilt")
+	return rebuilt, nil
+}
@@ -0,0 +1,167 @@
+package usecases_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lopesgabriel/tellawl/packages/logger"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/infra/database"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/infra/publisher"
+	usecases "github.com/lopesgabriel/tellawl/services/wallet/internal/use-cases"
+	lognoop "go.opentelemetry.io/otel/log/noop"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestBalanceSnapshots(t *testing.T) {
+	appLogger, err := logger.Init(t.Context(), logger.InitLoggerArgs{
+		LoggerProvider: lognoop.NewLoggerProvider(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to initialize logger: %v", err)
+	}
+	defer appLogger.Shutdown(t.Context())
+
+	eventPublisher := publisher.NewInMemoryEventPublisher(appLogger)
+	user := createMember("member1", "Gabriel", "Lopes", "gabriel@example.com")
+
+	repos := database.NewInMemory(eventPublisher)
+	memberRepo := database.NewInMemoryMemberRepository(eventPublisher)
+	repos.Member = memberRepo
+	memberRepo.Items = append(memberRepo.Items, *user)
+	useCases := usecases.NewUseCases(usecases.NewUseCasesArgs{
+		Repos:  repos,
+		Tracer: tracenoop.NewTracerProvider().Tracer("test"),
+		Logger: appLogger,
+	})
+
+	// One transaction a day through January and February, the first one is
+	// created right after midnight UTC, still December 31st in Sao Paulo
+	wallet := models.CreateNewWallet("Household", user, models.DefaultCurrency)
+	start := time.Date(2026, time.January, 1, 1, 0, 0, 0, time.UTC)
+	for i := range 59 {
+		transactionType := models.TransactionTypeWithdraw
+		if i%10 == 0 {
+			transactionType = models.TransactionTypeDeposit
+		}
+		transaction, err := wallet.RegisterNewTransaction(models.Monetary{Value: 1000 * (i + 1), Offset: 100}, *user, transactionType, "Test", nil)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		transaction.CreatedAt = start.AddDate(0, 0, i)
+		wallet.Transactions[len(wallet.Transactions)-1] = *transaction
+	}
+	repos.Wallet.Save(t.Context(), wallet)
+	first := wallet.Transactions[0]
+
+	report := func(t *testing.T, timezone string) *models.EvolutionReport {
+		from := time.Date(2025, time.December, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+		report, err := useCases.GetEvolutionReport(t.Context(), usecases.GetEvolutionReportUseCaseInput{
+			MemberId: user.Id,
+			WalletId: wallet.Id,
+			Timezone: timezone,
+			From:     &from,
+			To:       &to,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		return report
+	}
+
+	// expected sums the transactions of the wallet by month, in the location
+	expected := func(location *time.Location) map[int64][2]int {
+		totals := map[int64][2]int{}
+		stored, _ := repos.Wallet.FindById(t.Context(), wallet.Id)
+		for _, transaction := range stored.Transactions {
+			if !transaction.IsActive() {
+				continue
+			}
+			start := models.ReportMonthly.BucketStart(transaction.CreatedAt, location).Unix()
+			total := totals[start]
+			if transaction.Type == models.TransactionTypeDeposit {
+				total[0] += transaction.Amount.Value
+			} else {
+				total[1] += transaction.Amount.Value
+			}
+			totals[start] = total
+		}
+
+		return totals
+	}
+
+	check := func(t *testing.T, timezone string) {
+		location, err := time.LoadLocation(timezone)
+		if err != nil {
+			t.Skipf("timezone database not available: %v", err)
+		}
+
+		totals := expected(location)
+		for _, bucket := range report(t, timezone).Buckets {
+			total := totals[bucket.Start.Unix()]
+			if bucket.Income.Cmp(models.Monetary{Value: total[0], Offset: 100}) != 0 || bucket.Outcome.Cmp(models.Monetary{Value: total[1], Offset: 100}) != 0 {
+				t.Errorf("Expected %v to have %v in and %v out, got %v and %v", bucket.Start, total[0], total[1], bucket.Income, bucket.Outcome)
+			}
+		}
+	}
+
+	t.Run("should report the same totals as the transactions", func(t *testing.T) {
+		check(t, "UTC")
+		check(t, "America/Sao_Paulo")
+	})
+
+	t.Run("should keep the snapshots along when voiding an old transaction", func(t *testing.T) {
+		if _, err := useCases.VoidTransaction(t.Context(), usecases.VoidTransactionUseCaseInput{
+			MemberId:      user.Id,
+			WalletId:      wallet.Id,
+			TransactionId: first.Id,
+		}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		check(t, "UTC")
+		check(t, "America/Sao_Paulo")
+
+		stored, _ := repos.Wallet.FindById(t.Context(), wallet.Id)
+		closing := report(t, "UTC").Buckets[2].ClosingBalance
+		if closing.Cmp(stored.Balance) != 0 {
+			t.Errorf("Expected the closing balance to be the wallet balance %v, got %v", stored.Balance, closing)
+		}
+	})
+
+	t.Run("should rebuild the snapshots from the transactions", func(t *testing.T) {
+		before := report(t, "UTC")
+
+		rebuilt, err := useCases.RebuildBalanceSnapshots(t.Context(), usecases.RebuildBalanceSnapshotsUseCaseInput{})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if rebuilt != 1 {
+			t.Errorf("Expected 1 wallet to be rebuilt, got %v", rebuilt)
+		}
+
+		after := report(t, "UTC")
+		for i := range before.Buckets {
+			if before.Buckets[i].ClosingBalance.Cmp(after.Buckets[i].ClosingBalance) != 0 {
+				t.Errorf("Expected bucket %v to close at %v, got %v", i, before.Buckets[i].ClosingBalance, after.Buckets[i].ClosingBalance)
+			}
+		}
+	})
+
+	t.Run("should report the wallets that could not be rebuilt", func(t *testing.T) {
+		rebuilt, err := useCases.RebuildBalanceSnapshots(t.Context(), usecases.RebuildBalanceSnapshotsUseCaseInput{
+			WalletIds: []string{wallet.Id, "missing-wallet"},
+		})
+		if !errors.Is(err, errx.ErrNotFound) || !strings.Contains(err.Error(), "missing-wallet") {
+			t.Errorf("Expected the failure of the missing wallet, got %v", err)
+		}
+		if rebuilt != 1 {
+			t.Errorf("Expected the other wallet to be rebuilt, got %v", rebuilt)
+		}
+	})
+}
@@ -0,0 +1,285 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lopesgabriel/tellawl/packages/logger"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/infra/database"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/infra/publisher"
+	usecases "github.com/lopesgabriel/tellawl/services/wallet/internal/use-cases"
+	lognoop "go.opentelemetry.io/otel/log/noop"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestRecurringTransactions(t *testing.T) {
+	appLogger, err := logger.Init(t.Context(), logger.InitLoggerArgs{
+		LoggerProvider: lognoop.NewLoggerProvider(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to initialize logger: %v", err)
+	}
+	defer appLogger.Shutdown(t.Context())
+
+	eventPublisher := publisher.NewInMemoryEventPublisher(appLogger)
+	user := createMember("member1", "Gabriel", "Lopes", "gabriel@example.com")
+
+	setup := func(t *testing.T) (*usecases.UseCase, *models.Wallet) {
+		repos := database.NewInMemory(eventPublisher)
+		memberRepo := database.NewInMemoryMemberRepository(eventPublisher)
+		repos.Member = memberRepo
+		memberRepo.Items = append(memberRepo.Items, *user)
+		useCases := usecases.NewUseCases(usecases.NewUseCasesArgs{
+			Repos:  repos,
+			Tracer: tracenoop.NewTracerProvider().Tracer("test"),
+			Logger: appLogger,
+		})
+
+		wallet := models.CreateNewWallet("Test wallet", user, models.DefaultCurrency)
+		repos.Wallet.Save(t.Context(), wallet)
+
+		return useCases, wallet
+	}
+
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	t.Run("should clamp monthly occurrences to the end of shorter months", func(t *testing.T) {
+		useCases, wallet := setup(t)
+
+		recurring, err := useCases.CreateRecurringTransaction(t.Context(), usecases.CreateRecurringTransactionUseCaseInput{
+			MemberId:        user.Id,
+			WalletId:        wallet.Id,
+			Amount:          150000,
+			TransactionType: string(models.TransactionTypeWithdraw),
+			Description:     "Rent",
+			Frequency:       string(models.RecurrenceMonthly),
+			DayOfMonth:      31,
+			StartDate:       date(2025, time.January, 10),
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if !recurring.NextOccurrence.Equal(date(2025, time.January, 31)) {
+			t.Errorf("Expected next occurrence on 2025-01-31, got %v", recurring.NextOccurrence)
+		}
+
+		if next := recurring.OccurrenceAfter(recurring.NextOccurrence); !next.Equal(date(2025, time.February, 28)) {
+			t.Errorf("Expected occurrence after 2025-01-31 to be 2025-02-28, got %v", next)
+		}
+	})
+
+	t.Run("should materialize due occurrences only once", func(t *testing.T) {
+		useCases, wallet := setup(t)
+
+		endDate := date(2025, time.March, 31)
+		_, err := useCases.CreateRecurringTransaction(t.Context(), usecases.CreateRecurringTransactionUseCaseInput{
+			MemberId:        user.Id,
+			WalletId:        wallet.Id,
+			Amount:          500000,
+			TransactionType: string(models.TransactionTypeDeposit),
+			Description:     "Salary",
+			Frequency:       string(models.RecurrenceMonthly),
+			DayOfMonth:      5,
+			StartDate:       date(2025, time.January, 1),
+			EndDate:         &endDate,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		registered, err := useCases.MaterializeRecurringTransactions(t.Context(), usecases.MaterializeRecurringTransactionsUseCaseInput{})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if registered != 3 {
+			t.Errorf("Expected 3 occurrences to be registered, got %v", registered)
+		}
+
+		registered, err = useCases.MaterializeRecurringTransactions(t.Context(), usecases.MaterializeRecurringTransactionsUseCaseInput{})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if registered != 0 {
+			t.Errorf("Expected no occurrence to be registered twice, got %v", registered)
+		}
+
+		recurringTransactions, err := useCases.ListRecurringTransactions(t.Context(), usecases.ListRecurringTransactionsUseCaseInput{
+			MemberId: user.Id,
+			WalletId: wallet.Id,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if recurringTransactions[0].Status != models.RecurringTransactionStatusFinished {
+			t.Errorf("Expected recurring transaction to be finished, got %v", recurringTransactions[0].Status)
+		}
+
+		wallets, err := useCases.ListUserWallets(t.Context(), usecases.ListUserWalletsUseCaseInput{
+			UserId: user.Id,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if wallets[0].Balance.Value != 1500000 {
+			t.Errorf("Expected balance to be 1500000, got %v", wallets[0].Balance.Value)
+		}
+	})
+
+	t.Run("should date occurrences caught up late on their occurrence", func(t *testing.T) {
+		useCases, wallet := setup(t)
+
+		endDate := date(2025, time.March, 31)
+		_, err := useCases.CreateRecurringTransaction(t.Context(), usecases.CreateRecurringTransactionUseCaseInput{
+			MemberId:        user.Id,
+			WalletId:        wallet.Id,
+			Amount:          500000,
+			TransactionType: string(models.TransactionTypeDeposit),
+			Description:     "Salary",
+			Frequency:       string(models.RecurrenceMonthly),
+			DayOfMonth:      5,
+			StartDate:       date(2025, time.January, 1),
+			EndDate:         &endDate,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// The scheduler was down through the whole schedule
+		if _, err := useCases.MaterializeRecurringTransactions(t.Context(), usecases.MaterializeRecurringTransactionsUseCaseInput{}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		transactions, err := useCases.ListTransactions(t.Context(), usecases.ListTransactionsUseCaseInput{
+			MemberId: user.Id,
+			WalletId: wallet.Id,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if len(transactions.Transactions) != 3 {
+			t.Fatalf("Expected 3 transactions, got %v", len(transactions.Transactions))
+		}
+		for _, transaction := range transactions.Transactions {
+			if !transaction.CreatedAt.Equal(*transaction.OccurrenceDate) {
+				t.Errorf("Expected the transaction to be dated %v, got %v", transaction.OccurrenceDate, transaction.CreatedAt)
+			}
+		}
+
+		from, to := date(2025, time.January, 1), date(2025, time.April, 1)
+		report, err := useCases.GetEvolutionReport(t.Context(), usecases.GetEvolutionReportUseCaseInput{
+			MemberId:    user.Id,
+			WalletId:    wallet.Id,
+			Granularity: "month",
+			From:        &from,
+			To:          &to,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		for _, bucket := range report.Buckets {
+			if bucket.Income.Value != 500000 {
+				t.Errorf("Expected one salary in %v, got %v", bucket.Start.Month(), bucket.Income)
+			}
+		}
+	})
+
+	t.Run("should not register the same occurrence twice", func(t *testing.T) {
+		useCases, wallet := setup(t)
+
+		recurring, err := useCases.CreateRecurringTransaction(t.Context(), usecases.CreateRecurringTransactionUseCaseInput{
+			MemberId:        user.Id,
+			WalletId:        wallet.Id,
+			Amount:          2990,
+			TransactionType: string(models.TransactionTypeWithdraw),
+			Description:     "Streaming subscription",
+			Frequency:       string(models.RecurrenceWeekly),
+			StartDate:       time.Now(),
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		input := usecases.RegisterTransactionUseCaseInput{
+			TransactionRegisteredByUserId: user.Id,
+			WalletId:                      wallet.Id,
+			TransactionType:               string(recurring.Type),
+			RecurringTransactionId:        recurring.Id,
+			OccurrenceDate:                recurring.NextOccurrence,
+		}
+
+		transaction, err := useCases.RegisterTransaction(t.Context(), input)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if transaction.RecurringTransactionId == nil || *transaction.RecurringTransactionId != recurring.Id {
+			t.Errorf("Expected transaction to reference the recurring transaction")
+		}
+
+		_, err = useCases.RegisterTransaction(t.Context(), input)
+		if !errors.Is(err, errx.ErrOccurrenceNotDue) {
+			t.Errorf("Expected occurrence not due error, got %v", err)
+		}
+	})
+
+	t.Run("should stop materializing cancelled recurring transactions", func(t *testing.T) {
+		useCases, wallet := setup(t)
+
+		recurring, err := useCases.CreateRecurringTransaction(t.Context(), usecases.CreateRecurringTransactionUseCaseInput{
+			MemberId:        user.Id,
+			WalletId:        wallet.Id,
+			Amount:          10000,
+			TransactionType: string(models.TransactionTypeDeposit),
+			Frequency:       string(models.RecurrenceYearly),
+			StartDate:       date(2024, time.February, 29),
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		_, err = useCases.CancelRecurringTransaction(t.Context(), usecases.CancelRecurringTransactionUseCaseInput{
+			MemberId:               user.Id,
+			WalletId:               wallet.Id,
+			RecurringTransactionId: recurring.Id,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		registered, err := useCases.MaterializeRecurringTransactions(t.Context(), usecases.MaterializeRecurringTransactionsUseCaseInput{})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if registered != 0 {
+			t.Errorf("Expected no occurrence to be registered, got %v", registered)
+		}
+	})
+
+	t.Run("should reject an unknown frequency", func(t *testing.T) {
+		useCases, wallet := setup(t)
+
+		_, err := useCases.CreateRecurringTransaction(t.Context(), usecases.CreateRecurringTransactionUseCaseInput{
+			MemberId:        user.Id,
+			WalletId:        wallet.Id,
+			Amount:          10000,
+			TransactionType: string(models.TransactionTypeDeposit),
+			Frequency:       "daily",
+		})
+		if !errors.Is(err, errx.ErrInvalidRecurrence) {
+			t.Errorf("Expected invalid recurrence error, got %v", err)
+		}
+	})
+}
@@ -2,6 +2,7 @@ package usecases
 
 import (
 	"context"
+	"time"
 
 	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
 	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
@@ -15,17 +16,73 @@ type RegisterTransactionUseCaseInput struct {
 	Offset                        int
 	TransactionType               string
 	Description                   string
+	CategoryId                    *string
+
+	// ISO 4217 currency code, must match the wallet currency when informed
+	Currency string
+
+	// Set when materialising an occurrence of a recurring transaction, the
+	// amount, type, description and category then come from its definition
+	RecurringTransactionId string
+	OccurrenceDate         time.Time
+
+	// Shares a withdraw among members of the wallet
+	Split *TransactionSplitInput
+	// Member paid back by a settlement. Settlements without an amount settle
+	// the debt suggested for the member
+	SettledWithId string
 }
 
-func (usecase *UseCase) RegisterTransaction(ctx context.Context, input RegisterTransactionUseCaseInput) (*models.Transaction, error) {
-	if input.Offset == 0 {
-		input.Offset = 100
+type TransactionSplitInput struct {
+	Method string
+	Shares []TransactionSplitShareInput
+}
+
+type TransactionSplitShareInput struct {
+	MemberId string
+	// Hundredths of a percent, only used by percentage splits
+	Percentage int
+	// Only used by exact splits, in the same offset as the transaction amount
+	Amount int
+}
+
+func (input TransactionSplitInput) toSplit(offset int, currency string) (*models.TransactionSplit, error) {
+	method, err := models.ParseSplitMethod(input.Method)
+	if err != nil {
+		return nil, err
+	}
+
+	split := &models.TransactionSplit{Method: method}
+	for _, share := range input.Shares {
+		split.Shares = append(split.Shares, models.SplitShare{
+			MemberId:   share.MemberId,
+			Percentage: share.Percentage,
+			Amount:     models.Monetary{Value: share.Amount, Offset: offset, Currency: currency},
+		})
 	}
 
-	if models.TransactionType(input.TransactionType) != models.TransactionTypeDeposit && models.TransactionType(input.TransactionType) != models.TransactionTypeWithdraw {
+	return split, nil
+}
+
+func (usecase *UseCase) RegisterTransaction(ctx context.Context, input RegisterTransactionUseCaseInput) (*models.Transaction, error) {
+	switch models.TransactionType(input.TransactionType) {
+	case models.TransactionTypeDeposit, models.TransactionTypeWithdraw, models.TransactionTypeSettlement:
+	default:
 		return nil, errx.ErrInvalidTransactionType
 	}
 
+	if input.Split != nil && models.TransactionType(input.TransactionType) != models.TransactionTypeWithdraw {
+		return nil, errx.ErrInvalidSplit
+	}
+
+	if input.Currency != "" {
+		currency, err := models.ParseCurrency(input.Currency)
+		if err != nil {
+			return nil, err
+		}
+		input.Currency = currency
+	}
+
 	var user *models.Member
 	if input.TransactionRegisteredByUser != nil {
 		user = input.TransactionRegisteredByUser
@@ -37,26 +94,50 @@ func (usecase *UseCase) RegisterTransaction(ctx context.Context, input RegisterT
 		user = member
 	}
 
-	wallet, err := usecase.repos.Wallet.FindById(ctx, input.WalletId)
-	if err != nil {
-		return nil, err
-	}
-
-	transaction, err := wallet.RegisterNewTransaction(
-		models.Monetary{Value: input.Amount, Offset: input.Offset},
-		*user,
-		models.TransactionType(input.TransactionType),
-		input.Description,
-	)
-	if err != nil {
-		if err.Error() == "user is not allowed to register transactions" {
-			return nil, errx.ErrInsufficientPermissions
+	var transaction *models.Transaction
+	err := retryOnConflict(ctx, func() error {
+		wallet, err := usecase.repos.Wallet.FindById(ctx, input.WalletId)
+		if err != nil {
+			return err
 		}
 
-		return nil, err
-	}
+		offset := amountOffset(input.Offset, input.Currency, wallet)
+
+		amount := models.Monetary{Value: input.Amount, Offset: offset, Currency: input.Currency}
+
+		switch {
+		case input.RecurringTransactionId != "":
+			transaction, err = wallet.RegisterRecurringOccurrence(input.RecurringTransactionId, input.OccurrenceDate)
+		case models.TransactionType(input.TransactionType) == models.TransactionTypeSettlement:
+			if input.CategoryId != nil {
+				return errx.ErrInvalidSettlement
+			}
+			var settled *models.Monetary
+			if input.Amount != 0 {
+				settled = &amount
+			}
+			transaction, err = wallet.RegisterSettlement(*user, input.SettledWithId, settled, input.Description)
+		case input.Split != nil:
+			split, splitErr := input.Split.toSplit(offset, input.Currency)
+			if splitErr != nil {
+				return splitErr
+			}
+			transaction, err = wallet.RegisterSplitTransaction(amount, *user, input.Description, input.CategoryId, *split)
+		default:
+			transaction, err = wallet.RegisterNewTransaction(
+				amount,
+				*user,
+				models.TransactionType(input.TransactionType),
+				input.Description,
+				input.CategoryId,
+			)
+		}
+		if err != nil {
+			return err
+		}
 
-	err = usecase.repos.Wallet.Save(ctx, wallet)
+		return usecase.repos.Wallet.Save(ctx, wallet)
+	})
 	if err != nil {
 		return nil, err
 	}
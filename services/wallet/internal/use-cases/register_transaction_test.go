@@ -1,6 +1,8 @@
 package usecases_test
 
 import (
+	"context"
+	"errors"
 	"testing"
 
 	"github.com/lopesgabriel/tellawl/packages/logger"
@@ -37,7 +39,7 @@ func TestRegisterTransactionUseCase(t *testing.T) {
 		user := createMember("member1", "Matheus", "Lopes", "matheus@example.com")
 		memberRepo.Items = append(memberRepo.Items, *user)
 
-		wallet := models.CreateNewWallet("Test wallet", user)
+		wallet := models.CreateNewWallet("Test wallet", user, models.DefaultCurrency)
 		repos.Wallet.Save(t.Context(), wallet)
 
 		transaction, err := useCases.RegisterTransaction(t.Context(), usecases.RegisterTransactionUseCaseInput{
@@ -86,7 +88,7 @@ func TestRegisterTransactionUseCase(t *testing.T) {
 		user := createMember("member1", "Matheus", "Lopes", "matheus@example.com")
 		memberRepo.Items = append(memberRepo.Items, *user)
 
-		wallet := models.CreateNewWallet("Test wallet", user)
+		wallet := models.CreateNewWallet("Test wallet", user, models.DefaultCurrency)
 		repos.Wallet.Save(t.Context(), wallet)
 
 		transaction, err := useCases.RegisterTransaction(t.Context(), usecases.RegisterTransactionUseCaseInput{
@@ -123,6 +125,54 @@ func TestRegisterTransactionUseCase(t *testing.T) {
 		}
 	})
 
+	t.Run("should read amounts without offset in the currency minor unit after a finer one", func(t *testing.T) {
+		repos := database.NewInMemory(eventPublisher)
+		memberRepo := database.NewInMemoryMemberRepository(eventPublisher)
+		repos.Member = memberRepo
+		useCases := usecases.NewUseCases(usecases.NewUseCasesArgs{
+			Repos:  repos,
+			Tracer: tracenoop.NewTracerProvider().Tracer("test"),
+			Logger: appLogger,
+		})
+
+		user := createMember("member1", "Matheus", "Lopes", "matheus@example.com")
+		memberRepo.Items = append(memberRepo.Items, *user)
+
+		wallet := models.CreateNewWallet("Test wallet", user, models.DefaultCurrency)
+		repos.Wallet.Save(t.Context(), wallet)
+
+		// 1.005 moves the balance to thousandths
+		_, err := useCases.RegisterTransaction(t.Context(), usecases.RegisterTransactionUseCaseInput{
+			TransactionRegisteredByUserId: user.Id,
+			WalletId:                      wallet.Id,
+			Amount:                        1005,
+			Offset:                        1000,
+			TransactionType:               "deposit",
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		transaction, err := useCases.RegisterTransaction(t.Context(), usecases.RegisterTransactionUseCaseInput{
+			TransactionRegisteredByUserId: user.Id,
+			WalletId:                      wallet.Id,
+			Amount:                        1050,
+			TransactionType:               "deposit",
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if transaction.Amount.Cmp(models.Monetary{Value: 1050, Offset: 100, Currency: models.DefaultCurrency}) != 0 {
+			t.Errorf("Expected the amount to be 10.50, got %v", transaction.Amount)
+		}
+
+		wallet, _ = repos.Wallet.FindById(t.Context(), wallet.Id)
+		if wallet.Balance.Cmp(models.Monetary{Value: 11505, Offset: 1000, Currency: models.DefaultCurrency}) != 0 {
+			t.Errorf("Expected wallet balance to be 11.505, got %v", wallet.Balance)
+		}
+	})
+
 	t.Run("User with no access should not register a transaction", func(t *testing.T) {
 		repos := database.NewInMemory(eventPublisher)
 		memberRepo := database.NewInMemoryMemberRepository(eventPublisher)
@@ -138,7 +188,7 @@ func TestRegisterTransactionUseCase(t *testing.T) {
 		memberRepo.Items = append(memberRepo.Items, *user)
 		memberRepo.Items = append(memberRepo.Items, *user2)
 
-		wallet := models.CreateNewWallet("Test wallet", user)
+		wallet := models.CreateNewWallet("Test wallet", user, models.DefaultCurrency)
 		repos.Wallet.Save(t.Context(), wallet)
 
 		_, err := useCases.RegisterTransaction(t.Context(), usecases.RegisterTransactionUseCaseInput{
@@ -183,9 +233,9 @@ func TestRegisterTransactionUseCase(t *testing.T) {
 		memberRepo.Items = append(memberRepo.Items, *user)
 		memberRepo.Items = append(memberRepo.Items, *user2)
 
-		wallet := models.CreateNewWallet("Test wallet", user)
+		wallet := models.CreateNewWallet("Test wallet", user, models.DefaultCurrency)
 		repos.Wallet.Save(t.Context(), wallet)
-		wallet.AddUser(user2)
+		wallet.AddUser(user2, models.WalletRoleEditor)
 		repos.Wallet.Save(t.Context(), wallet)
 
 		_, err := useCases.RegisterTransaction(t.Context(), usecases.RegisterTransactionUseCaseInput{
@@ -214,3 +264,117 @@ func TestRegisterTransactionUseCase(t *testing.T) {
 		}
 	})
 }
+
+// racingWalletRepository registers a deposit on the stored wallet right before
+// each of the first saves, as if another request had saved it first.
+type racingWalletRepository struct {
+	*database.InMemoryWalletRepository
+	member *models.Member
+	races  int
+}
+
+func (r *racingWalletRepository) Save(ctx context.Context, wallet *models.Wallet) error {
+	if err := r.race(ctx, wallet.Id); err != nil {
+		return err
+	}
+
+	return r.InMemoryWalletRepository.Save(ctx, wallet)
+}
+
+func (r *racingWalletRepository) SaveWithStatementImport(ctx context.Context, wallet *models.Wallet, statementImport *models.StatementImport) error {
+	if err := r.race(ctx, wallet.Id); err != nil {
+		return err
+	}
+
+	return r.InMemoryWalletRepository.SaveWithStatementImport(ctx, wallet, statementImport)
+}
+
+func (r *racingWalletRepository) SaveAll(ctx context.Context, wallets ...*models.Wallet) error {
+	if err := r.race(ctx, wallets[0].Id); err != nil {
+		return err
+	}
+
+	return r.InMemoryWalletRepository.SaveAll(ctx, wallets...)
+}
+
+// race saves a concurrent deposit to the wallet while races are left.
+func (r *racingWalletRepository) race(ctx context.Context, walletId string) error {
+	if r.races == 0 {
+		return nil
+	}
+	r.races--
+
+	stored, err := r.InMemoryWalletRepository.FindById(ctx, walletId)
+	if err != nil {
+		return err
+	}
+	stored.RegisterNewTransaction(models.Monetary{Value: 500, Offset: 100}, *r.member, models.TransactionTypeDeposit, "Concurrent deposit", nil)
+	return r.InMemoryWalletRepository.Save(ctx, stored)
+}
+
+func TestRegisterTransactionUseCaseConflicts(t *testing.T) {
+	appLogger, err := logger.Init(t.Context(), logger.InitLoggerArgs{
+		LoggerProvider: lognoop.NewLoggerProvider(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to initialize logger: %v", err)
+	}
+	defer appLogger.Shutdown(t.Context())
+
+	eventPublisher := publisher.NewInMemoryEventPublisher(appLogger)
+	user := createMember("member1", "Matheus", "Lopes", "matheus@example.com")
+
+	repos := database.NewInMemory(eventPublisher)
+	memberRepo := database.NewInMemoryMemberRepository(eventPublisher)
+	repos.Member = memberRepo
+	memberRepo.Items = append(memberRepo.Items, *user)
+
+	walletRepo := &racingWalletRepository{
+		InMemoryWalletRepository: database.NewInMemoryWalletRepository(eventPublisher),
+		member:                   user,
+	}
+	repos.Wallet = walletRepo
+	useCases := usecases.NewUseCases(usecases.NewUseCasesArgs{
+		Repos:  repos,
+		Tracer: tracenoop.NewTracerProvider().Tracer("test"),
+		Logger: appLogger,
+	})
+
+	wallet := models.CreateNewWallet("Test wallet", user, models.DefaultCurrency)
+	repos.Wallet.Save(t.Context(), wallet)
+
+	register := func() error {
+		_, err := useCases.RegisterTransaction(t.Context(), usecases.RegisterTransactionUseCaseInput{
+			TransactionRegisteredByUserId: user.Id,
+			WalletId:                      wallet.Id,
+			Amount:                        1000,
+			TransactionType:               "deposit",
+			Description:                   "Test deposit",
+		})
+		return err
+	}
+
+	t.Run("should keep the concurrent transaction when retrying", func(t *testing.T) {
+		walletRepo.races = 2
+
+		if err := register(); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		stored, _ := repos.Wallet.FindById(t.Context(), wallet.Id)
+		if stored.Balance.Value != 2000 {
+			t.Errorf("Expected balance to be 2000, got %v", stored.Balance.Value)
+		}
+		if len(stored.Transactions) != 3 {
+			t.Errorf("Expected 3 transactions, got %v", len(stored.Transactions))
+		}
+	})
+
+	t.Run("should give up once the retries are exhausted", func(t *testing.T) {
+		walletRepo.races = 10
+
+		if err := register(); !errors.Is(err, errx.ErrWalletConflict) {
+			t.Errorf("Expected wallet conflict error, got %v", err)
+		}
+	})
+}
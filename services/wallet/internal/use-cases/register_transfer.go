@@ -0,0 +1,73 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+)
+
+type RegisterTransferUseCaseInput struct {
+	MemberId     string
+	Member       *models.Member
+	FromWalletId string
+	ToWalletId   string
+	Amount       int
+	Offset       int
+	Description  string
+
+	// ISO 4217 currency code, must match the currency of both wallets when informed
+	Currency string
+}
+
+func (usecase *UseCase) RegisterTransfer(ctx context.Context, input RegisterTransferUseCaseInput) (*models.Transfer, error) {
+	if input.FromWalletId == input.ToWalletId {
+		return nil, errx.ErrInvalidTransfer
+	}
+
+	if input.Currency != "" {
+		currency, err := models.ParseCurrency(input.Currency)
+		if err != nil {
+			return nil, err
+		}
+		input.Currency = currency
+	}
+
+	member, err := usecase.resolveMember(ctx, input.MemberId, input.Member)
+	if err != nil {
+		return nil, err
+	}
+
+	var transfer *models.Transfer
+	err = retryOnConflict(ctx, func() error {
+		from, err := usecase.repos.Wallet.FindById(ctx, input.FromWalletId)
+		if err != nil {
+			return err
+		}
+
+		to, err := usecase.repos.Wallet.FindById(ctx, input.ToWalletId)
+		if err != nil {
+			return err
+		}
+
+		registered, err := models.RegisterTransfer(
+			from,
+			to,
+			models.Monetary{Value: input.Amount, Offset: amountOffset(input.Offset, input.Currency, from), Currency: input.Currency},
+			*member,
+			input.Description,
+		)
+		if err != nil {
+			return err
+		}
+		transfer = registered
+
+		// Both legs are persisted in the same database transaction
+		return usecase.repos.Wallet.SaveAll(ctx, from, to)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return transfer, nil
+}
@@ -0,0 +1,259 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lopesgabriel/tellawl/packages/logger"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/infra/database"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/infra/publisher"
+	usecases "github.com/lopesgabriel/tellawl/services/wallet/internal/use-cases"
+	lognoop "go.opentelemetry.io/otel/log/noop"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestRegisterTransferUseCase(t *testing.T) {
+	appLogger, err := logger.Init(t.Context(), logger.InitLoggerArgs{
+		LoggerProvider: lognoop.NewLoggerProvider(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to initialize logger: %v", err)
+	}
+	defer appLogger.Shutdown(t.Context())
+
+	eventPublisher := publisher.NewInMemoryEventPublisher(appLogger)
+	user := createMember("member1", "Gabriel", "Lopes", "gabriel@example.com")
+	user2 := createMember("member2", "Matheus", "Lopes", "matheus@example.com")
+
+	setup := func(t *testing.T) (*usecases.UseCase, *database.InMemoryWalletRepository, *models.Wallet, *models.Wallet) {
+		repos := database.NewInMemory(eventPublisher)
+		memberRepo := database.NewInMemoryMemberRepository(eventPublisher)
+		repos.Member = memberRepo
+		memberRepo.Items = append(memberRepo.Items, *user, *user2)
+		walletRepo := database.NewInMemoryWalletRepository(eventPublisher)
+		repos.Wallet = walletRepo
+		useCases := usecases.NewUseCases(usecases.NewUseCasesArgs{
+			Repos:  repos,
+			Tracer: tracenoop.NewTracerProvider().Tracer("test"),
+			Logger: appLogger,
+		})
+
+		household := models.CreateNewWallet("Household", user, models.DefaultCurrency)
+		household.Balance.Value = 100000
+		walletRepo.Save(t.Context(), household)
+
+		savings := models.CreateNewWallet("Savings", user, models.DefaultCurrency)
+		walletRepo.Save(t.Context(), savings)
+
+		return useCases, walletRepo, household, savings
+	}
+
+	t.Run("should move money between wallets linking both legs", func(t *testing.T) {
+		useCases, walletRepo, household, savings := setup(t)
+
+		transfer, err := useCases.RegisterTransfer(t.Context(), usecases.RegisterTransferUseCaseInput{
+			MemberId:     user.Id,
+			FromWalletId: household.Id,
+			ToWalletId:   savings.Id,
+			Amount:       30000,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		household, _ = walletRepo.FindById(t.Context(), household.Id)
+		savings, _ = walletRepo.FindById(t.Context(), savings.Id)
+
+		if household.Balance.Value != 70000 {
+			t.Errorf("Expected household balance to be 70000, got %v", household.Balance.Value)
+		}
+		if savings.Balance.Value != 30000 {
+			t.Errorf("Expected savings balance to be 30000, got %v", savings.Balance.Value)
+		}
+
+		withdraw, err := household.FindTransaction(transfer.Withdraw.Id)
+		if err != nil {
+			t.Fatalf("Expected withdraw leg to be saved, got %v", err)
+		}
+		deposit, err := savings.FindTransaction(transfer.Deposit.Id)
+		if err != nil {
+			t.Fatalf("Expected deposit leg to be saved, got %v", err)
+		}
+
+		if withdraw.TransferId == nil || deposit.TransferId == nil || *withdraw.TransferId != transfer.Id || *deposit.TransferId != transfer.Id {
+			t.Errorf("Expected both legs to reference transfer %v", transfer.Id)
+		}
+		if withdraw.Type != models.TransactionTypeWithdraw || deposit.Type != models.TransactionTypeDeposit {
+			t.Errorf("Expected a withdraw and a deposit leg, got %v and %v", withdraw.Type, deposit.Type)
+		}
+	})
+
+	t.Run("should require write access to both wallets", func(t *testing.T) {
+		useCases, walletRepo, household, savings := setup(t)
+
+		household.AddUser(user2, models.WalletRoleEditor)
+		savings.AddUser(user2, models.WalletRoleViewer)
+		walletRepo.SaveAll(t.Context(), household, savings)
+
+		_, err := useCases.RegisterTransfer(t.Context(), usecases.RegisterTransferUseCaseInput{
+			MemberId:     user2.Id,
+			FromWalletId: household.Id,
+			ToWalletId:   savings.Id,
+			Amount:       30000,
+		})
+		if !errors.Is(err, errx.ErrInsufficientPermissions) {
+			t.Errorf("Expected insufficient permissions error, got %v", err)
+		}
+
+		household, _ = walletRepo.FindById(t.Context(), household.Id)
+		if household.Balance.Value != 100000 || len(household.Transactions) != 0 {
+			t.Errorf("Expected household wallet to be unchanged, got balance %v and %v transactions", household.Balance.Value, len(household.Transactions))
+		}
+	})
+
+	t.Run("should reject transfers to the same wallet", func(t *testing.T) {
+		useCases, _, household, _ := setup(t)
+
+		_, err := useCases.RegisterTransfer(t.Context(), usecases.RegisterTransferUseCaseInput{
+			MemberId:     user.Id,
+			FromWalletId: household.Id,
+			ToWalletId:   household.Id,
+			Amount:       30000,
+		})
+		if !errors.Is(err, errx.ErrInvalidTransfer) {
+			t.Errorf("Expected invalid transfer error, got %v", err)
+		}
+	})
+
+	t.Run("should reject transfers between currencies", func(t *testing.T) {
+		useCases, walletRepo, household, _ := setup(t)
+
+		dollars := models.CreateNewWallet("Dollars", user, "USD")
+		walletRepo.Save(t.Context(), dollars)
+
+		_, err := useCases.RegisterTransfer(t.Context(), usecases.RegisterTransferUseCaseInput{
+			MemberId:     user.Id,
+			FromWalletId: household.Id,
+			ToWalletId:   dollars.Id,
+			Amount:       30000,
+		})
+		if !errors.Is(err, errx.ErrCurrencyMismatch) {
+			t.Errorf("Expected currency mismatch error, got %v", err)
+		}
+	})
+
+	t.Run("should not void a single leg of a transfer", func(t *testing.T) {
+		useCases, _, household, savings := setup(t)
+
+		transfer, err := useCases.RegisterTransfer(t.Context(), usecases.RegisterTransferUseCaseInput{
+			MemberId:     user.Id,
+			FromWalletId: household.Id,
+			ToWalletId:   savings.Id,
+			Amount:       30000,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		_, err = useCases.VoidTransaction(t.Context(), usecases.VoidTransactionUseCaseInput{
+			MemberId:      user.Id,
+			WalletId:      household.Id,
+			TransactionId: transfer.Withdraw.Id,
+		})
+		if !errors.Is(err, errx.ErrTransactionIsTransfer) {
+			t.Errorf("Expected transaction is transfer error, got %v", err)
+		}
+	})
+
+	t.Run("should not consume budgets", func(t *testing.T) {
+		useCases, walletRepo, household, savings := setup(t)
+
+		_, err := useCases.CreateBudget(t.Context(), usecases.CreateBudgetUseCaseInput{
+			MemberId: user.Id,
+			WalletId: household.Id,
+			Amount:   50000,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		_, err = useCases.RegisterTransfer(t.Context(), usecases.RegisterTransferUseCaseInput{
+			MemberId:     user.Id,
+			FromWalletId: household.Id,
+			ToWalletId:   savings.Id,
+			Amount:       30000,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		household, _ = walletRepo.FindById(t.Context(), household.Id)
+		for _, period := range household.Budgets[0].Periods {
+			if !period.Spent.IsZero() {
+				t.Errorf("Expected transfers not to be spent, got %v", period.Spent)
+			}
+		}
+	})
+}
+
+func TestRegisterTransferUseCaseConflicts(t *testing.T) {
+	appLogger, err := logger.Init(t.Context(), logger.InitLoggerArgs{
+		LoggerProvider: lognoop.NewLoggerProvider(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to initialize logger: %v", err)
+	}
+	defer appLogger.Shutdown(t.Context())
+
+	eventPublisher := publisher.NewInMemoryEventPublisher(appLogger)
+	user := createMember("member1", "Gabriel", "Lopes", "gabriel@example.com")
+
+	repos := database.NewInMemory(eventPublisher)
+	memberRepo := database.NewInMemoryMemberRepository(eventPublisher)
+	repos.Member = memberRepo
+	memberRepo.Items = append(memberRepo.Items, *user)
+
+	walletRepo := &racingWalletRepository{
+		InMemoryWalletRepository: database.NewInMemoryWalletRepository(eventPublisher),
+		member:                   user,
+	}
+	repos.Wallet = walletRepo
+	useCases := usecases.NewUseCases(usecases.NewUseCasesArgs{
+		Repos:  repos,
+		Tracer: tracenoop.NewTracerProvider().Tracer("test"),
+		Logger: appLogger,
+	})
+
+	household := models.CreateNewWallet("Household", user, models.DefaultCurrency)
+	household.Balance.Value = 100000
+	walletRepo.Save(t.Context(), household)
+
+	savings := models.CreateNewWallet("Savings", user, models.DefaultCurrency)
+	walletRepo.Save(t.Context(), savings)
+
+	t.Run("should transfer again when the wallet changed meanwhile", func(t *testing.T) {
+		walletRepo.races = 2
+
+		if _, err := useCases.RegisterTransfer(t.Context(), usecases.RegisterTransferUseCaseInput{
+			MemberId:     user.Id,
+			FromWalletId: household.Id,
+			ToWalletId:   savings.Id,
+			Amount:       30000,
+		}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		stored, _ := walletRepo.FindById(t.Context(), household.Id)
+		// Both concurrent deposits of 5.00 are kept
+		if stored.Balance.Value != 71000 {
+			t.Errorf("Expected household balance to be 71000, got %v", stored.Balance.Value)
+		}
+
+		stored, _ = walletRepo.FindById(t.Context(), savings.Id)
+		if stored.Balance.Value != 30000 {
+			t.Errorf("Expected savings balance to be 30000, got %v", stored.Balance.Value)
+		}
+	})
+}
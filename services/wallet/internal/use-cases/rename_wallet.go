@@ -0,0 +1,40 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+)
+
+type RenameWalletUseCaseInput struct {
+	MemberId string
+	Member   *models.Member
+	WalletId string
+	Name     string
+}
+
+func (usecase *UseCase) RenameWallet(ctx context.Context, input RenameWalletUseCaseInput) (*models.Wallet, error) {
+	member, err := usecase.resolveMember(ctx, input.MemberId, input.Member)
+	if err != nil {
+		return nil, err
+	}
+
+	wallet, err := usecase.repos.Wallet.FindById(ctx, input.WalletId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := wallet.CheckPermission(member.Id, models.PermissionManage); err != nil {
+		return nil, err
+	}
+
+	if err := wallet.Rename(input.Name, member.Id); err != nil {
+		return nil, err
+	}
+
+	if err := usecase.repos.Wallet.Save(ctx, wallet); err != nil {
+		return nil, err
+	}
+
+	return wallet, nil
+}
@@ -0,0 +1,35 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+)
+
+type RevokeInvitationUseCaseInput struct {
+	MemberId     string
+	WalletId     string
+	InvitationId string
+}
+
+func (usecase *UseCase) RevokeInvitation(ctx context.Context, input RevokeInvitationUseCaseInput) (*models.Invitation, error) {
+	invitation, err := usecase.repos.Invitation.FindById(ctx, input.InvitationId)
+	if err != nil {
+		return nil, err
+	}
+
+	wallet, err := usecase.repos.Wallet.FindById(ctx, input.WalletId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := invitation.Revoke(wallet, input.MemberId); err != nil {
+		return nil, err
+	}
+
+	if err := usecase.repos.Invitation.Save(ctx, invitation); err != nil {
+		return nil, err
+	}
+
+	return invitation, nil
+}
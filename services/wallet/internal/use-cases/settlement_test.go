@@ -0,0 +1,167 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lopesgabriel/tellawl/packages/logger"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/repository"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/infra/database"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/infra/publisher"
+	usecases "github.com/lopesgabriel/tellawl/services/wallet/internal/use-cases"
+	lognoop "go.opentelemetry.io/otel/log/noop"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestSettlementUseCases(t *testing.T) {
+	appLogger, err := logger.Init(t.Context(), logger.InitLoggerArgs{
+		LoggerProvider: lognoop.NewLoggerProvider(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to initialize logger: %v", err)
+	}
+	defer appLogger.Shutdown(t.Context())
+
+	eventPublisher := publisher.NewInMemoryEventPublisher(appLogger)
+
+	user1 := createMember("member1", "Gabriel", "Lopes", "gabriel@example.com")
+	user2 := createMember("member2", "Matheus", "Lopes", "matheus@example.com")
+
+	setup := func(t *testing.T) (*usecases.UseCase, *repository.Repositories, *models.Wallet) {
+		repos := database.NewInMemory(eventPublisher)
+		memberRepo := database.NewInMemoryMemberRepository(eventPublisher)
+		repos.Member = memberRepo
+		memberRepo.Items = append(memberRepo.Items, *user1, *user2)
+		useCases := usecases.NewUseCases(usecases.NewUseCasesArgs{
+			Repos:  repos,
+			Tracer: tracenoop.NewTracerProvider().Tracer("test"),
+			Logger: appLogger,
+		})
+
+		wallet := models.CreateNewWallet("Household", user1, models.DefaultCurrency)
+		if err := wallet.AddUser(user2, models.WalletRoleEditor); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		repos.Wallet.Save(t.Context(), wallet)
+
+		return useCases, repos, wallet
+	}
+
+	t.Run("should split an expense and settle it up without touching the balance", func(t *testing.T) {
+		useCases, repos, wallet := setup(t)
+
+		transaction, err := useCases.RegisterTransaction(t.Context(), usecases.RegisterTransactionUseCaseInput{
+			TransactionRegisteredByUserId: user1.Id,
+			WalletId:                      wallet.Id,
+			Amount:                        10000,
+			TransactionType:               "withdraw",
+			Description:                   "Rent",
+			Split: &usecases.TransactionSplitInput{
+				Method: "percentage",
+				Shares: []usecases.TransactionSplitShareInput{
+					{MemberId: user1.Id, Percentage: 4000},
+					{MemberId: user2.Id, Percentage: 6000},
+				},
+			},
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if transaction.Split == nil || transaction.Split.Shares[1].Amount.Value != 6000 {
+			t.Fatalf("Expected member2 to owe 6000, got %v", transaction.Split)
+		}
+
+		settlement, err := useCases.GetSettlement(t.Context(), usecases.GetSettlementUseCaseInput{
+			MemberId: user2.Id,
+			WalletId: wallet.Id,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(settlement.Payments) != 1 || settlement.Payments[0].FromMemberId != user2.Id || settlement.Payments[0].Amount.Value != 6000 {
+			t.Fatalf("Expected member2 to pay 6000 to member1, got %v", settlement.Payments)
+		}
+
+		settleUp, err := useCases.RegisterTransaction(t.Context(), usecases.RegisterTransactionUseCaseInput{
+			TransactionRegisteredByUserId: user2.Id,
+			WalletId:                      wallet.Id,
+			TransactionType:               "settlement",
+			SettledWithId:                 user1.Id,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if settleUp.Amount.Value != 6000 || settleUp.SettledWithId == nil || *settleUp.SettledWithId != user1.Id {
+			t.Errorf("Expected 6000 to be settled with member1, got %v to %v", settleUp.Amount.Value, settleUp.SettledWithId)
+		}
+
+		stored, _ := repos.Wallet.FindById(t.Context(), wallet.Id)
+		if stored.Balance.Value != -10000 {
+			t.Errorf("Expected the settlement to leave the balance at -10000, got %v", stored.Balance.Value)
+		}
+
+		settlement, _ = useCases.GetSettlement(t.Context(), usecases.GetSettlementUseCaseInput{
+			MemberId: user1.Id,
+			WalletId: wallet.Id,
+		})
+		if len(settlement.Payments) != 0 {
+			t.Errorf("Expected nothing left to settle, got %v", settlement.Payments)
+		}
+	})
+
+	t.Run("should reject splits of deposits and settlements with categories", func(t *testing.T) {
+		useCases, _, wallet := setup(t)
+
+		_, err := useCases.RegisterTransaction(t.Context(), usecases.RegisterTransactionUseCaseInput{
+			TransactionRegisteredByUserId: user1.Id,
+			WalletId:                      wallet.Id,
+			Amount:                        10000,
+			TransactionType:               "deposit",
+			Split:                         &usecases.TransactionSplitInput{Method: "equal"},
+		})
+		if !errors.Is(err, errx.ErrInvalidSplit) {
+			t.Errorf("Expected invalid split error, got %v", err)
+		}
+
+		categoryId := "category"
+		_, err = useCases.RegisterTransaction(t.Context(), usecases.RegisterTransactionUseCaseInput{
+			TransactionRegisteredByUserId: user2.Id,
+			WalletId:                      wallet.Id,
+			Amount:                        1000,
+			TransactionType:               "settlement",
+			SettledWithId:                 user1.Id,
+			CategoryId:                    &categoryId,
+		})
+		if !errors.Is(err, errx.ErrInvalidSettlement) {
+			t.Errorf("Expected invalid settlement error, got %v", err)
+		}
+	})
+
+	t.Run("should not edit settlements", func(t *testing.T) {
+		useCases, _, wallet := setup(t)
+
+		settleUp, err := useCases.RegisterTransaction(t.Context(), usecases.RegisterTransactionUseCaseInput{
+			TransactionRegisteredByUserId: user2.Id,
+			WalletId:                      wallet.Id,
+			Amount:                        1000,
+			TransactionType:               "settlement",
+			SettledWithId:                 user1.Id,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		amount := 2000
+		_, err = useCases.UpdateTransaction(t.Context(), usecases.UpdateTransactionUseCaseInput{
+			MemberId:      user2.Id,
+			WalletId:      wallet.Id,
+			TransactionId: settleUp.Id,
+			Amount:        &amount,
+		})
+		if !errors.Is(err, errx.ErrTransactionIsSettlement) {
+			t.Errorf("Expected transaction is settlement error, got %v", err)
+		}
+	})
+}
@@ -2,6 +2,8 @@ package usecases
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
 	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
@@ -12,19 +14,22 @@ type ShareWalletUseCaseInput struct {
 	WalletCreator   *models.Member
 	WalletId        string
 	SharedUserEmail string
+
+	// Role granted to the shared user, defaults to editor when empty
+	Role string
 }
 
-func (usecase *UseCase) ShareWallet(ctx context.Context, input ShareWalletUseCaseInput) (*models.Wallet, error) {
-	var creator *models.Member
+// ShareWallet invites the email to the wallet. Access is only granted once
+// the invitee accepts, which people without an account can do after signing
+// up with the email.
+func (usecase *UseCase) ShareWallet(ctx context.Context, input ShareWalletUseCaseInput) (*models.Invitation, error) {
+	if models.NormalizeEmail(input.SharedUserEmail) == "" {
+		return nil, errx.ErrInvalidInput
+	}
 
-	if input.WalletCreator != nil {
-		creator = input.WalletCreator
-	} else {
-		creatorUser, err := usecase.repos.Member.FindByID(ctx, input.WalletCreatorId)
-		if err != nil {
-			return nil, err
-		}
-		creator = creatorUser
+	creator, err := usecase.resolveMember(ctx, input.WalletCreatorId, input.WalletCreator)
+	if err != nil {
+		return nil, err
 	}
 
 	wallet, err := usecase.repos.Wallet.FindById(ctx, input.WalletId)
@@ -32,20 +37,43 @@ func (usecase *UseCase) ShareWallet(ctx context.Context, input ShareWalletUseCas
 		return nil, err
 	}
 
-	if wallet.CreatorId != creator.Id {
-		return nil, errx.ErrInsufficientPermissions
+	if err := wallet.CheckPermission(creator.Id, models.PermissionManage); err != nil {
+		return nil, err
 	}
 
-	sharedUser, err := usecase.repos.Member.FindByEmail(ctx, input.SharedUserEmail)
+	role := models.WalletRoleEditor
+	if input.Role != "" {
+		role, err = models.ParseWalletRole(input.Role)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	pending, err := usecase.repos.Invitation.FindPending(ctx, wallet.Id, input.SharedUserEmail)
+	if err == nil && pending.StatusAt(time.Now()) == models.InvitationStatusPending {
+		return nil, errx.ErrInvitationAlreadyPending
+	}
+	if err != nil && !errors.Is(err, errx.ErrInvitationNotFound) {
+		return nil, err
+	}
+
+	// Nobody signed up with the email yet
+	invitee, err := usecase.repos.Member.FindByEmail(ctx, input.SharedUserEmail)
+	if errors.Is(err, errx.ErrNotFound) {
+		invitee, err = nil, nil
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	wallet.AddUser(sharedUser)
-	err = usecase.repos.Wallet.Save(ctx, wallet)
+	invitation, err := models.NewInvitation(wallet, *creator, input.SharedUserEmail, invitee, role)
 	if err != nil {
 		return nil, err
 	}
 
-	return wallet, nil
+	if err := usecase.repos.Invitation.Save(ctx, invitation); err != nil {
+		return nil, err
+	}
+
+	return invitation, nil
 }
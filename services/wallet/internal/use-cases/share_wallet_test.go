@@ -1,10 +1,14 @@
 package usecases_test
 
 import (
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/lopesgabriel/tellawl/packages/logger"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
 	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/repository"
 	"github.com/lopesgabriel/tellawl/services/wallet/internal/infra/database"
 	"github.com/lopesgabriel/tellawl/services/wallet/internal/infra/publisher"
 	usecases "github.com/lopesgabriel/tellawl/services/wallet/internal/use-cases"
@@ -22,34 +26,207 @@ func TestShareWalletUseCase(t *testing.T) {
 	defer appLogger.Shutdown(t.Context())
 
 	eventPublisher := publisher.NewInMemoryEventPublisher(appLogger)
-	memberRepo := database.NewInMemoryMemberRepository(eventPublisher)
-	repos := database.NewInMemory(eventPublisher)
-	repos.Member = memberRepo
-	useCases := usecases.NewUseCases(usecases.NewUseCasesArgs{
-		Repos:  repos,
-		Tracer: tracenoop.NewTracerProvider().Tracer("test"),
-		Logger: appLogger,
-	})
 
 	user1 := createMember("member1", "Gabriel", "Lopes", "gabriel@example.com")
 	user2 := createMember("member2", "Matheus", "Lopes", "matheus@example.com")
-	memberRepo.Items = append(memberRepo.Items, *user1)
-	memberRepo.Items = append(memberRepo.Items, *user2)
 
-	wallet := models.CreateNewWallet("Test wallet", user1)
-	repos.Wallet.Save(t.Context(), wallet)
+	setup := func(t *testing.T) (*usecases.UseCase, *repository.Repositories, *models.Wallet) {
+		memberRepo := database.NewInMemoryMemberRepository(eventPublisher)
+		repos := database.NewInMemory(eventPublisher)
+		repos.Member = memberRepo
+		memberRepo.Items = append(memberRepo.Items, *user1, *user2)
+		useCases := usecases.NewUseCases(usecases.NewUseCasesArgs{
+			Repos:  repos,
+			Tracer: tracenoop.NewTracerProvider().Tracer("test"),
+			Logger: appLogger,
+		})
 
-	updatedWallet, err := useCases.ShareWallet(t.Context(), usecases.ShareWalletUseCaseInput{
-		WalletCreatorId: user1.Id,
-		WalletId:        wallet.Id,
-		SharedUserEmail: user2.Email,
-	})
+		wallet := models.CreateNewWallet("Test wallet", user1, models.DefaultCurrency)
+		repos.Wallet.Save(t.Context(), wallet)
 
-	if err != nil {
-		t.Errorf("Expected no error, got %v", err)
+		return useCases, repos, wallet
 	}
 
-	if len(updatedWallet.Members) != 2 {
-		t.Errorf("Expected wallet to have 2 users, got %v", len(updatedWallet.Members))
+	share := func(t *testing.T, useCases *usecases.UseCase, walletId, email string) *models.Invitation {
+		invitation, err := useCases.ShareWallet(t.Context(), usecases.ShareWalletUseCaseInput{
+			WalletCreatorId: user1.Id,
+			WalletId:        walletId,
+			SharedUserEmail: email,
+			Role:            string(models.WalletRoleViewer),
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		return invitation
 	}
+
+	t.Run("should only grant access once the invitation is accepted", func(t *testing.T) {
+		useCases, repos, wallet := setup(t)
+
+		invitation := share(t, useCases, wallet.Id, user2.Email)
+		if invitation.MemberId == nil || *invitation.MemberId != user2.Id {
+			t.Errorf("Expected invitation to be linked to %v, got %v", user2.Id, invitation.MemberId)
+		}
+
+		stored, _ := repos.Wallet.FindById(t.Context(), wallet.Id)
+		if stored.IsMember(user2.Id) {
+			t.Fatalf("Expected member not to have access before accepting")
+		}
+
+		invitations, err := useCases.ListMyInvitations(t.Context(), usecases.ListMyInvitationsUseCaseInput{MemberId: user2.Id})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(invitations) != 1 || invitations[0].Id != invitation.Id {
+			t.Fatalf("Expected the invitation to be listed, got %v", invitations)
+		}
+
+		accepted, err := useCases.AcceptInvitation(t.Context(), usecases.AcceptInvitationUseCaseInput{
+			MemberId: user2.Id,
+			Token:    invitation.Token,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if accepted.Status != models.InvitationStatusAccepted {
+			t.Errorf("Expected accepted status, got %v", accepted.Status)
+		}
+
+		stored, _ = repos.Wallet.FindById(t.Context(), wallet.Id)
+		role, ok := stored.MemberRole(user2.Id)
+		if !ok || role != models.WalletRoleViewer {
+			t.Errorf("Expected viewer role, got %v", role)
+		}
+
+		_, err = useCases.AcceptInvitation(t.Context(), usecases.AcceptInvitationUseCaseInput{
+			MemberId: user2.Id,
+			Token:    invitation.Token,
+		})
+		if !errors.Is(err, errx.ErrInvitationNotPending) {
+			t.Errorf("Expected invitation not pending error, got %v", err)
+		}
+	})
+
+	t.Run("should invite emails nobody signed up with yet", func(t *testing.T) {
+		useCases, _, wallet := setup(t)
+
+		invitation := share(t, useCases, wallet.Id, "Maria@Example.com")
+		if invitation.MemberId != nil {
+			t.Errorf("Expected invitation not to be linked, got %v", *invitation.MemberId)
+		}
+		if invitation.Email != "maria@example.com" {
+			t.Errorf("Expected email to be normalized, got %v", invitation.Email)
+		}
+
+		err := useCases.SyncMember(t.Context(), usecases.SyncMemberUseCaseInput{
+			MemberId:  "member3",
+			FirstName: "Maria",
+			LastName:  "Lopes",
+			Email:     "maria@example.com",
+			CreatedAt: time.Now(),
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		invitations, err := useCases.ListMyInvitations(t.Context(), usecases.ListMyInvitationsUseCaseInput{MemberId: "member3"})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(invitations) != 1 || invitations[0].MemberId == nil || *invitations[0].MemberId != "member3" {
+			t.Fatalf("Expected the invitation to be linked to the new member, got %v", invitations)
+		}
+
+		_, err = useCases.AcceptInvitation(t.Context(), usecases.AcceptInvitationUseCaseInput{
+			MemberId: "member3",
+			Token:    invitation.Token,
+		})
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+	})
+
+	t.Run("should not invite the same email twice", func(t *testing.T) {
+		useCases, _, wallet := setup(t)
+
+		share(t, useCases, wallet.Id, user2.Email)
+
+		_, err := useCases.ShareWallet(t.Context(), usecases.ShareWalletUseCaseInput{
+			WalletCreatorId: user1.Id,
+			WalletId:        wallet.Id,
+			SharedUserEmail: user2.Email,
+		})
+		if !errors.Is(err, errx.ErrInvitationAlreadyPending) {
+			t.Errorf("Expected invitation already pending error, got %v", err)
+		}
+	})
+
+	t.Run("should not let other members answer the invitation", func(t *testing.T) {
+		useCases, _, wallet := setup(t)
+
+		invitation := share(t, useCases, wallet.Id, "maria@example.com")
+
+		_, err := useCases.AcceptInvitation(t.Context(), usecases.AcceptInvitationUseCaseInput{
+			MemberId: user2.Id,
+			Token:    invitation.Token,
+		})
+		if !errors.Is(err, errx.ErrInvitationNotFound) {
+			t.Errorf("Expected invitation not found error, got %v", err)
+		}
+	})
+
+	t.Run("should decline the invitation", func(t *testing.T) {
+		useCases, repos, wallet := setup(t)
+
+		invitation := share(t, useCases, wallet.Id, user2.Email)
+
+		declined, err := useCases.DeclineInvitation(t.Context(), usecases.DeclineInvitationUseCaseInput{
+			MemberId: user2.Id,
+			Token:    invitation.Token,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if declined.Status != models.InvitationStatusDeclined {
+			t.Errorf("Expected declined status, got %v", declined.Status)
+		}
+
+		stored, _ := repos.Wallet.FindById(t.Context(), wallet.Id)
+		if stored.IsMember(user2.Id) {
+			t.Errorf("Expected member not to have access after declining")
+		}
+	})
+
+	t.Run("should only let managers revoke the invitation", func(t *testing.T) {
+		useCases, _, wallet := setup(t)
+
+		invitation := share(t, useCases, wallet.Id, user2.Email)
+
+		_, err := useCases.RevokeInvitation(t.Context(), usecases.RevokeInvitationUseCaseInput{
+			MemberId:     user2.Id,
+			WalletId:     wallet.Id,
+			InvitationId: invitation.Id,
+		})
+		if !errors.Is(err, errx.ErrInsufficientPermissions) {
+			t.Errorf("Expected insufficient permissions error, got %v", err)
+		}
+
+		_, err = useCases.RevokeInvitation(t.Context(), usecases.RevokeInvitationUseCaseInput{
+			MemberId:     user1.Id,
+			WalletId:     wallet.Id,
+			InvitationId: invitation.Id,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		_, err = useCases.AcceptInvitation(t.Context(), usecases.AcceptInvitationUseCaseInput{
+			MemberId: user2.Id,
+			Token:    invitation.Token,
+		})
+		if !errors.Is(err, errx.ErrInvitationNotPending) {
+			t.Errorf("Expected invitation not pending error, got %v", err)
+		}
+	})
 }
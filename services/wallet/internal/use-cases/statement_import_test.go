@@ -0,0 +1,340 @@
+package usecases_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lopesgabriel/tellawl/packages/logger"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/infra/database"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/infra/publisher"
+	usecases "github.com/lopesgabriel/tellawl/services/wallet/internal/use-cases"
+	lognoop "go.opentelemetry.io/otel/log/noop"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestStatementImport(t *testing.T) {
+	appLogger, err := logger.Init(t.Context(), logger.InitLoggerArgs{
+		LoggerProvider: lognoop.NewLoggerProvider(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to initialize logger: %v", err)
+	}
+	defer appLogger.Shutdown(t.Context())
+
+	eventPublisher := publisher.NewInMemoryEventPublisher(appLogger)
+	user := createMember("member1", "Gabriel", "Lopes", "gabriel@example.com")
+
+	repos := database.NewInMemory(eventPublisher)
+	memberRepo := database.NewInMemoryMemberRepository(eventPublisher)
+	repos.Member = memberRepo
+	memberRepo.Items = append(memberRepo.Items, *user)
+	useCases := usecases.NewUseCases(usecases.NewUseCasesArgs{
+		Repos:  repos,
+		Tracer: tracenoop.NewTracerProvider().Tracer("test"),
+		Logger: appLogger,
+	})
+
+	wallet := models.CreateNewWallet("Household", user, models.DefaultCurrency)
+	repos.Wallet.Save(t.Context(), wallet)
+
+	_, err = useCases.RegisterTransaction(t.Context(), usecases.RegisterTransactionUseCaseInput{
+		TransactionRegisteredByUserId: user.Id,
+		WalletId:                      wallet.Id,
+		Amount:                        4590,
+		TransactionType:               string(models.TransactionTypeWithdraw),
+		Description:                   "Padaria  Pão Quente",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	today := time.Now().UTC().Format("02/01/2006")
+	statement := "Data;Descrição;Valor\n" +
+		today + ";PADARIA PÃO QUENTE;-45,90\n" +
+		today + ";Mercado Central;-1.234,56\n" +
+		today + ";Salário;8.500,00\n" +
+		"31/02/2026;Data inválida;10,00\n"
+
+	mapping := models.CSVMapping{
+		DateColumn:        "Data",
+		DescriptionColumn: "Descrição",
+		AmountColumn:      "Valor",
+		DateFormat:        "DD/MM/YYYY",
+		DecimalSeparator:  ",",
+		Delimiter:         ';',
+		HasHeader:         true,
+	}
+
+	preview := func() *models.StatementImport {
+		statementImport, err := useCases.PreviewStatementImport(t.Context(), usecases.PreviewStatementImportUseCaseInput{
+			MemberId:   user.Id,
+			WalletId:   wallet.Id,
+			Content:    strings.NewReader(statement),
+			CSVMapping: mapping,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		return statementImport
+	}
+
+	statementImport := preview()
+
+	t.Run("should preview the rows and flag duplicates", func(t *testing.T) {
+		if len(statementImport.Rows) != 4 {
+			t.Fatalf("Expected 4 rows, got %v", len(statementImport.Rows))
+		}
+
+		if !statementImport.Rows[0].IsDuplicate() {
+			t.Errorf("Expected the bakery row to be flagged as a duplicate")
+		}
+		if statementImport.Rows[1].Amount.Value != 123456 || statementImport.Rows[1].Type != models.TransactionTypeWithdraw {
+			t.Errorf("Expected a withdraw of 1234.56, got %v %v", statementImport.Rows[1].Type, statementImport.Rows[1].Amount)
+		}
+		if statementImport.Rows[2].Type != models.TransactionTypeDeposit {
+			t.Errorf("Expected the salary to be a deposit, got %v", statementImport.Rows[2].Type)
+		}
+		if statementImport.Rows[3].IsValid() || statementImport.Rows[3].Line != 5 {
+			t.Errorf("Expected line 5 to be invalid, got %+v", statementImport.Rows[3])
+		}
+
+		stored, _ := repos.Wallet.FindById(t.Context(), wallet.Id)
+		if len(stored.Transactions) != 1 {
+			t.Errorf("Expected the preview not to register transactions, got %v", len(stored.Transactions))
+		}
+	})
+
+	t.Run("should register the rows that are not duplicates", func(t *testing.T) {
+		committed, err := useCases.CommitStatementImport(t.Context(), usecases.CommitStatementImportUseCaseInput{
+			MemberId: user.Id,
+			WalletId: wallet.Id,
+			ImportId: statementImport.Id,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if committed.Status != models.StatementImportStatusCommitted {
+			t.Errorf("Expected the import to be committed, got %v", committed.Status)
+		}
+
+		stored, _ := repos.Wallet.FindById(t.Context(), wallet.Id)
+		if len(stored.Transactions) != 3 {
+			t.Fatalf("Expected 3 transactions, got %v", len(stored.Transactions))
+		}
+		// 8500.00 - 1234.56 - 45.90
+		if stored.Balance.Value != 721954 {
+			t.Errorf("Expected balance to be 721954, got %v", stored.Balance.Value)
+		}
+	})
+
+	t.Run("should date the transactions on the day of the statement", func(t *testing.T) {
+		past := models.CreateNewWallet("Past statement", user, models.DefaultCurrency)
+		repos.Wallet.Save(t.Context(), past)
+
+		statementImport, err := useCases.PreviewStatementImport(t.Context(), usecases.PreviewStatementImportUseCaseInput{
+			MemberId: user.Id,
+			WalletId: past.Id,
+			Content: strings.NewReader("Data;Descrição;Valor\n" +
+				"15/01/2026;Salário;8.500,00\n" +
+				"20/02/2026;Aluguel;-2.000,00\n"),
+			CSVMapping: mapping,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		_, err = useCases.CommitStatementImport(t.Context(), usecases.CommitStatementImportUseCaseInput{
+			MemberId: user.Id,
+			WalletId: past.Id,
+			ImportId: statementImport.Id,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		stored, _ := repos.Wallet.FindById(t.Context(), past.Id)
+		if len(stored.Transactions) != 2 {
+			t.Fatalf("Expected 2 transactions, got %v", len(stored.Transactions))
+		}
+		expected := []time.Time{
+			time.Date(2026, time.January, 15, 0, 0, 0, 0, time.UTC),
+			time.Date(2026, time.February, 20, 0, 0, 0, 0, time.UTC),
+		}
+		for i, transaction := range stored.Transactions {
+			if !transaction.CreatedAt.Equal(expected[i]) {
+				t.Errorf("Expected transaction %v to be dated %v, got %v", i, expected[i], transaction.CreatedAt)
+			}
+		}
+		if stored.Balance.Value != 650000 {
+			t.Errorf("Expected balance to be 650000, got %v", stored.Balance.Value)
+		}
+
+		from := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+		report, err := useCases.GetEvolutionReport(t.Context(), usecases.GetEvolutionReportUseCaseInput{
+			MemberId:    user.Id,
+			WalletId:    past.Id,
+			Granularity: "month",
+			From:        &from,
+			To:          &to,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if len(report.Buckets) != 2 {
+			t.Fatalf("Expected 2 buckets, got %v", len(report.Buckets))
+		}
+		january, february := report.Buckets[0], report.Buckets[1]
+		if january.Income.Value != 850000 || january.ClosingBalance.Value != 850000 {
+			t.Errorf("Expected the salary in January, got %+v", january)
+		}
+		if february.Outcome.Value != 200000 || february.ClosingBalance.Value != 650000 {
+			t.Errorf("Expected the rent in February, got %+v", february)
+		}
+	})
+
+	t.Run("should not commit an import twice", func(t *testing.T) {
+		_, err := useCases.CommitStatementImport(t.Context(), usecases.CommitStatementImportUseCaseInput{
+			MemberId: user.Id,
+			WalletId: wallet.Id,
+			ImportId: statementImport.Id,
+		})
+		if !errors.Is(err, errx.ErrStatementImportCommitted) {
+			t.Errorf("Expected statement import committed error, got %v", err)
+		}
+	})
+
+	t.Run("should flag the rows of a statement imported again", func(t *testing.T) {
+		again := preview()
+
+		for _, row := range again.Rows[:3] {
+			if !row.IsDuplicate() {
+				t.Errorf("Expected line %v to be flagged as a duplicate", row.Line)
+			}
+		}
+	})
+
+	t.Run("should not register the FITIDs of an OFX statement twice", func(t *testing.T) {
+		statement := "<OFX><STMTRS><CURDEF>BRL<BANKTRANLIST>" +
+			"<STMTTRN><DTPOSTED>20260310<TRNAMT>-89.90<FITID>2026031001<NAME>Farmácia" +
+			"</STMTTRN></BANKTRANLIST></STMTRS></OFX>"
+
+		importOFX := func() *models.StatementImport {
+			statementImport, err := useCases.PreviewStatementImport(t.Context(), usecases.PreviewStatementImportUseCaseInput{
+				MemberId: user.Id,
+				WalletId: wallet.Id,
+				Format:   "ofx",
+				Content:  strings.NewReader(statement),
+			})
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			committed, err := useCases.CommitStatementImport(t.Context(), usecases.CommitStatementImportUseCaseInput{
+				MemberId: user.Id,
+				WalletId: wallet.Id,
+				ImportId: statementImport.Id,
+			})
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			return committed
+		}
+
+		first := importOFX()
+		if first.Rows[0].TransactionId == nil {
+			t.Fatalf("Expected the first import to register the entry")
+		}
+
+		second := importOFX()
+		if !second.Rows[0].AlreadyImported || second.Rows[0].TransactionId != nil {
+			t.Errorf("Expected the entry to be skipped as already imported, got %+v", second.Rows[0])
+		}
+
+		stored, _ := repos.Wallet.FindById(t.Context(), wallet.Id)
+		if len(stored.Transactions) != 4 {
+			t.Errorf("Expected 4 transactions, got %v", len(stored.Transactions))
+		}
+	})
+}
+
+func TestCommitStatementImportConflicts(t *testing.T) {
+	appLogger, err := logger.Init(t.Context(), logger.InitLoggerArgs{
+		LoggerProvider: lognoop.NewLoggerProvider(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to initialize logger: %v", err)
+	}
+	defer appLogger.Shutdown(t.Context())
+
+	eventPublisher := publisher.NewInMemoryEventPublisher(appLogger)
+	user := createMember("member1", "Gabriel", "Lopes", "gabriel@example.com")
+
+	repos := database.NewInMemory(eventPublisher)
+	memberRepo := database.NewInMemoryMemberRepository(eventPublisher)
+	repos.Member = memberRepo
+	memberRepo.Items = append(memberRepo.Items, *user)
+
+	walletRepo := &racingWalletRepository{
+		InMemoryWalletRepository: repos.Wallet.(*database.InMemoryWalletRepository),
+		member:                   user,
+	}
+	repos.Wallet = walletRepo
+	useCases := usecases.NewUseCases(usecases.NewUseCasesArgs{
+		Repos:  repos,
+		Tracer: tracenoop.NewTracerProvider().Tracer("test"),
+		Logger: appLogger,
+	})
+
+	wallet := models.CreateNewWallet("Household", user, models.DefaultCurrency)
+	repos.Wallet.Save(t.Context(), wallet)
+
+	t.Run("should commit the import again when the wallet changed meanwhile", func(t *testing.T) {
+		statementImport, err := useCases.PreviewStatementImport(t.Context(), usecases.PreviewStatementImportUseCaseInput{
+			MemberId: user.Id,
+			WalletId: wallet.Id,
+			Content:  strings.NewReader("Data;Descrição;Valor\n" + time.Now().UTC().Format("02/01/2006") + ";Salário;10,00\n"),
+			CSVMapping: models.CSVMapping{
+				DateColumn:        "Data",
+				DescriptionColumn: "Descrição",
+				AmountColumn:      "Valor",
+				DateFormat:        "DD/MM/YYYY",
+				DecimalSeparator:  ",",
+				Delimiter:         ';',
+				HasHeader:         true,
+			},
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		walletRepo.races = 2
+		if _, err := useCases.CommitStatementImport(t.Context(), usecases.CommitStatementImportUseCaseInput{
+			MemberId: user.Id,
+			WalletId: wallet.Id,
+			ImportId: statementImport.Id,
+		}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		stored, _ := repos.Wallet.FindById(t.Context(), wallet.Id)
+		// Both concurrent deposits of 5.00 and the imported 10.00
+		if stored.Balance.Value != 2000 || len(stored.Transactions) != 3 {
+			t.Errorf("Expected a balance of 2000 in 3 transactions, got %v in %v", stored.Balance.Value, len(stored.Transactions))
+		}
+
+		committed, err := repos.StatementImport.FindById(t.Context(), statementImport.Id)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if committed.Status != models.StatementImportStatusCommitted {
+			t.Errorf("Expected the import to be committed, got %v", committed.Status)
+		}
+	})
+}
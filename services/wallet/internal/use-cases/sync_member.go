@@ -0,0 +1,61 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+)
+
+type SyncMemberUseCaseInput struct {
+	MemberId  string
+	FirstName string
+	LastName  string
+	Email     string
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+}
+
+// SyncMember stores the copy of a member received from member-service. Copies
+// older than the stored one are ignored. Invitations sent to the email before
+// the member signed up are linked to it.
+func (usecase *UseCase) SyncMember(ctx context.Context, input SyncMemberUseCaseInput) error {
+	if input.MemberId == "" || input.Email == "" {
+		return errx.ErrInvalidInput
+	}
+
+	member := &models.Member{
+		Id:        input.MemberId,
+		FirstName: input.FirstName,
+		LastName:  input.LastName,
+		Email:     input.Email,
+		CreatedAt: input.CreatedAt,
+		UpdatedAt: input.UpdatedAt,
+	}
+	if err := usecase.repos.Member.Upsert(ctx, member); err != nil {
+		return err
+	}
+
+	return usecase.linkInvitations(ctx, *member)
+}
+
+func (usecase *UseCase) linkInvitations(ctx context.Context, member models.Member) error {
+	invitations, err := usecase.repos.Invitation.FindPendingFor(ctx, member)
+	if err != nil {
+		return err
+	}
+
+	for _, invitation := range invitations {
+		if invitation.MemberId != nil {
+			continue
+		}
+
+		invitation.LinkMember(member)
+		if err := usecase.repos.Invitation.Save(ctx, &invitation); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,98 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lopesgabriel/tellawl/packages/logger"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/infra/database"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/infra/publisher"
+	usecases "github.com/lopesgabriel/tellawl/services/wallet/internal/use-cases"
+	lognoop "go.opentelemetry.io/otel/log/noop"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestSyncMemberUseCase(t *testing.T) {
+	appLogger, err := logger.Init(t.Context(), logger.InitLoggerArgs{
+		LoggerProvider: lognoop.NewLoggerProvider(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to initialize logger: %v", err)
+	}
+	defer appLogger.Shutdown(t.Context())
+
+	eventPublisher := publisher.NewInMemoryEventPublisher(appLogger)
+	repos := database.NewInMemory(eventPublisher)
+	useCases := usecases.NewUseCases(usecases.NewUseCasesArgs{
+		Repos:  repos,
+		Tracer: tracenoop.NewTracerProvider().Tracer("test"),
+		Logger: appLogger,
+	})
+
+	createdAt := time.Date(2026, time.March, 1, 10, 0, 0, 0, time.UTC)
+	updatedAt := createdAt.Add(time.Hour)
+
+	t.Run("should store new members", func(t *testing.T) {
+		err := useCases.SyncMember(t.Context(), usecases.SyncMemberUseCaseInput{
+			MemberId:  "member1",
+			FirstName: "Gabriel",
+			LastName:  "Lopes",
+			Email:     "gabriel@example.com",
+			CreatedAt: createdAt,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		member, err := repos.Member.FindByID(t.Context(), "member1")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if member.Email != "gabriel@example.com" {
+			t.Errorf("Expected email to be gabriel@example.com, got %v", member.Email)
+		}
+	})
+
+	t.Run("should keep the most recent copy of a member", func(t *testing.T) {
+		err := useCases.SyncMember(t.Context(), usecases.SyncMemberUseCaseInput{
+			MemberId:  "member1",
+			FirstName: "Gabriel",
+			LastName:  "Lopes",
+			Email:     "gabriel.lopes@example.com",
+			CreatedAt: updatedAt,
+			UpdatedAt: &updatedAt,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// The creation event arriving late must not undo the update
+		err = useCases.SyncMember(t.Context(), usecases.SyncMemberUseCaseInput{
+			MemberId:  "member1",
+			FirstName: "Gabriel",
+			LastName:  "Lopes",
+			Email:     "gabriel@example.com",
+			CreatedAt: createdAt,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		member, err := repos.Member.FindByID(t.Context(), "member1")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if member.Email != "gabriel.lopes@example.com" {
+			t.Errorf("Expected email to be gabriel.lopes@example.com, got %v", member.Email)
+		}
+	})
+
+	t.Run("should reject members without id", func(t *testing.T) {
+		err := useCases.SyncMember(t.Context(), usecases.SyncMemberUseCaseInput{Email: "someone@example.com"})
+		if !errors.Is(err, errx.ErrInvalidInput) {
+			t.Errorf("Expected invalid input error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,40 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+)
+
+type UnshareWalletUseCaseInput struct {
+	WalletCreatorId string
+	WalletCreator   *models.Member
+	WalletId        string
+	MemberId        string
+}
+
+func (usecase *UseCase) UnshareWallet(ctx context.Context, input UnshareWalletUseCaseInput) (*models.Wallet, error) {
+	creator, err := usecase.resolveMember(ctx, input.WalletCreatorId, input.WalletCreator)
+	if err != nil {
+		return nil, err
+	}
+
+	wallet, err := usecase.repos.Wallet.FindById(ctx, input.WalletId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := wallet.CheckPermission(creator.Id, models.PermissionManage); err != nil {
+		return nil, err
+	}
+
+	if err := wallet.RemoveMember(input.MemberId, creator.Id); err != nil {
+		return nil, err
+	}
+
+	if err := usecase.repos.Wallet.Save(ctx, wallet); err != nil {
+		return nil, err
+	}
+
+	return wallet, nil
+}
@@ -0,0 +1,132 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lopesgabriel/tellawl/packages/logger"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/infra/database"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/infra/publisher"
+	usecases "github.com/lopesgabriel/tellawl/services/wallet/internal/use-cases"
+	lognoop "go.opentelemetry.io/otel/log/noop"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestUnshareAndLeaveWalletUseCases(t *testing.T) {
+	appLogger, err := logger.Init(t.Context(), logger.InitLoggerArgs{
+		LoggerProvider: lognoop.NewLoggerProvider(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to initialize logger: %v", err)
+	}
+	defer appLogger.Shutdown(t.Context())
+
+	eventPublisher := publisher.NewInMemoryEventPublisher(appLogger)
+
+	user1 := createMember("member1", "Gabriel", "Lopes", "gabriel@example.com")
+	user2 := createMember("member2", "Matheus", "Lopes", "matheus@example.com")
+	user3 := createMember("member3", "Maria", "Lopes", "maria@example.com")
+
+	setup := func(t *testing.T) (*usecases.UseCase, *models.Wallet) {
+		repos := database.NewInMemory(eventPublisher)
+		memberRepo := database.NewInMemoryMemberRepository(eventPublisher)
+		repos.Member = memberRepo
+		memberRepo.Items = append(memberRepo.Items, *user1, *user2, *user3)
+		useCases := usecases.NewUseCases(usecases.NewUseCasesArgs{
+			Repos:  repos,
+			Tracer: tracenoop.NewTracerProvider().Tracer("test"),
+			Logger: appLogger,
+		})
+
+		wallet := models.CreateNewWallet("Test wallet", user1, models.DefaultCurrency)
+		wallet.AddUser(user2, models.WalletRoleEditor)
+		wallet.AddUser(user3, models.WalletRoleEditor)
+		repos.Wallet.Save(t.Context(), wallet)
+
+		return useCases, wallet
+	}
+
+	t.Run("should not share the wallet twice with the same member", func(t *testing.T) {
+		useCases, wallet := setup(t)
+
+		_, err := useCases.ShareWallet(t.Context(), usecases.ShareWalletUseCaseInput{
+			WalletCreatorId: user1.Id,
+			WalletId:        wallet.Id,
+			SharedUserEmail: user2.Email,
+		})
+		if !errors.Is(err, errx.ErrMemberAlreadyInWallet) {
+			t.Errorf("Expected member already in wallet error, got %v", err)
+		}
+	})
+
+	t.Run("creator should unshare the wallet", func(t *testing.T) {
+		useCases, wallet := setup(t)
+
+		updatedWallet, err := useCases.UnshareWallet(t.Context(), usecases.UnshareWalletUseCaseInput{
+			WalletCreatorId: user1.Id,
+			WalletId:        wallet.Id,
+			MemberId:        user2.Id,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if len(updatedWallet.Members) != 2 {
+			t.Errorf("Expected wallet to have 2 users, got %v", len(updatedWallet.Members))
+		}
+
+		if updatedWallet.IsMember(user2.Id) {
+			t.Errorf("Expected %v to no longer be a member", user2.Id)
+		}
+	})
+
+	t.Run("editors should not unshare the wallet", func(t *testing.T) {
+		useCases, wallet := setup(t)
+
+		_, err := useCases.UnshareWallet(t.Context(), usecases.UnshareWalletUseCaseInput{
+			WalletCreatorId: user2.Id,
+			WalletId:        wallet.Id,
+			MemberId:        user3.Id,
+		})
+		if !errors.Is(err, errx.ErrInsufficientPermissions) {
+			t.Errorf("Expected insufficient permissions error, got %v", err)
+		}
+	})
+
+	t.Run("member should leave the wallet", func(t *testing.T) {
+		useCases, wallet := setup(t)
+
+		err := useCases.LeaveWallet(t.Context(), usecases.LeaveWalletUseCaseInput{
+			MemberId: user3.Id,
+			WalletId: wallet.Id,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		wallets, err := useCases.ListUserWallets(t.Context(), usecases.ListUserWalletsUseCaseInput{
+			UserId: user3.Id,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if len(wallets) != 0 {
+			t.Errorf("Expected 0 wallets, got %v", len(wallets))
+		}
+	})
+
+	t.Run("creator should not leave the wallet", func(t *testing.T) {
+		useCases, wallet := setup(t)
+
+		err := useCases.LeaveWallet(t.Context(), usecases.LeaveWalletUseCaseInput{
+			MemberId: user1.Id,
+			WalletId: wallet.Id,
+		})
+		if !errors.Is(err, errx.ErrCannotRemoveCreator) {
+			t.Errorf("Expected cannot remove creator error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,87 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+)
+
+type UpdateTransactionUseCaseInput struct {
+	MemberId      string
+	Member        *models.Member
+	WalletId      string
+	TransactionId string
+
+	// Nil fields keep the current value of the transaction
+	Amount          *int
+	Offset          *int
+	TransactionType *string
+	Description     *string
+	CategoryId      *string
+	ClearCategory   bool
+	// Replaces the split of the transaction, a kept split is reallocated to
+	// the new amount
+	Split      *TransactionSplitInput
+	ClearSplit bool
+}
+
+func (usecase *UseCase) UpdateTransaction(ctx context.Context, input UpdateTransactionUseCaseInput) (*models.Transaction, error) {
+	member, err := usecase.resolveMember(ctx, input.MemberId, input.Member)
+	if err != nil {
+		return nil, err
+	}
+
+	wallet, err := usecase.repos.Wallet.FindById(ctx, input.WalletId)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := models.TransactionChanges{
+		Description:   input.Description,
+		CategoryId:    input.CategoryId,
+		ClearCategory: input.ClearCategory,
+		ClearSplit:    input.ClearSplit,
+	}
+
+	if input.Amount != nil || input.Offset != nil || input.Split != nil {
+		original, err := wallet.FindTransaction(input.TransactionId)
+		if err != nil {
+			return nil, err
+		}
+
+		amount := original.Amount
+		if input.Amount != nil {
+			amount.Value = *input.Amount
+		}
+		if input.Offset != nil && *input.Offset != 0 {
+			amount.Offset = *input.Offset
+		}
+		if input.Amount != nil || input.Offset != nil {
+			changes.Amount = &amount
+		}
+
+		if input.Split != nil {
+			split, err := input.Split.toSplit(amount.Offset, amount.Currency)
+			if err != nil {
+				return nil, err
+			}
+			changes.Split = split
+		}
+	}
+
+	if input.TransactionType != nil {
+		transactionType := models.TransactionType(*input.TransactionType)
+		changes.Type = &transactionType
+	}
+
+	transaction, err := wallet.UpdateTransaction(input.TransactionId, *member, changes)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := usecase.repos.Wallet.Save(ctx, wallet); err != nil {
+		return nil, err
+	}
+
+	return transaction, nil
+}
@@ -0,0 +1,152 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lopesgabriel/tellawl/packages/logger"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/repository"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/infra/database"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/infra/publisher"
+	usecases "github.com/lopesgabriel/tellawl/services/wallet/internal/use-cases"
+	lognoop "go.opentelemetry.io/otel/log/noop"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestUpdateAndVoidTransactionUseCases(t *testing.T) {
+	appLogger, err := logger.Init(t.Context(), logger.InitLoggerArgs{
+		LoggerProvider: lognoop.NewLoggerProvider(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to initialize logger: %v", err)
+	}
+	defer appLogger.Shutdown(t.Context())
+
+	eventPublisher := publisher.NewInMemoryEventPublisher(appLogger)
+
+	setup := func(t *testing.T) (*usecases.UseCase, *repository.Repositories, *models.Member, *models.Wallet, *models.Transaction) {
+		repos := database.NewInMemory(eventPublisher)
+		memberRepo := database.NewInMemoryMemberRepository(eventPublisher)
+		repos.Member = memberRepo
+		useCases := usecases.NewUseCases(usecases.NewUseCasesArgs{
+			Repos:  repos,
+			Tracer: tracenoop.NewTracerProvider().Tracer("test"),
+			Logger: appLogger,
+		})
+
+		user := createMember("member1", "Gabriel", "Lopes", "gabriel@example.com")
+		memberRepo.Items = append(memberRepo.Items, *user)
+
+		wallet := models.CreateNewWallet("Test wallet", user, models.DefaultCurrency)
+		repos.Wallet.Save(t.Context(), wallet)
+
+		transaction, err := useCases.RegisterTransaction(t.Context(), usecases.RegisterTransactionUseCaseInput{
+			TransactionRegisteredByUserId: user.Id,
+			WalletId:                      wallet.Id,
+			Amount:                        10000,
+			TransactionType:               "withdraw",
+			Description:                   "Groceries",
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		wallet, err = repos.Wallet.FindById(t.Context(), wallet.Id)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		return useCases, repos, user, wallet, transaction
+	}
+
+	t.Run("should replace the transaction and correct the balance", func(t *testing.T) {
+		useCases, repos, user, wallet, transaction := setup(t)
+
+		amount := 1000
+		updated, err := useCases.UpdateTransaction(t.Context(), usecases.UpdateTransactionUseCaseInput{
+			MemberId:      user.Id,
+			WalletId:      wallet.Id,
+			TransactionId: transaction.Id,
+			Amount:        &amount,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if updated.ReplacesId == nil || *updated.ReplacesId != transaction.Id {
+			t.Errorf("Expected the new transaction to replace %v, got %v", transaction.Id, updated.ReplacesId)
+		}
+		if updated.Description != "Groceries" {
+			t.Errorf("Expected description to be kept, got %v", updated.Description)
+		}
+
+		wallet, err = repos.Wallet.FindById(t.Context(), wallet.Id)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if wallet.Balance.Value != -1000 {
+			t.Errorf("Expected wallet balance to be -1000, got %v", wallet.Balance.Value)
+		}
+
+		if len(wallet.Transactions) != 2 {
+			t.Fatalf("Expected wallet to keep 2 transactions, got %v", len(wallet.Transactions))
+		}
+
+		original, _ := wallet.FindTransaction(transaction.Id)
+		if original.Status != models.TransactionStatusReplaced {
+			t.Errorf("Expected original transaction to be replaced, got %v", original.Status)
+		}
+
+		_, err = useCases.UpdateTransaction(t.Context(), usecases.UpdateTransactionUseCaseInput{
+			MemberId:      user.Id,
+			WalletId:      wallet.Id,
+			TransactionId: transaction.Id,
+			Amount:        &amount,
+		})
+		if !errors.Is(err, errx.ErrTransactionNotActive) {
+			t.Errorf("Expected transaction not active error, got %v", err)
+		}
+	})
+
+	t.Run("should void the transaction and correct the balance", func(t *testing.T) {
+		useCases, repos, user, wallet, transaction := setup(t)
+
+		voided, err := useCases.VoidTransaction(t.Context(), usecases.VoidTransactionUseCaseInput{
+			MemberId:      user.Id,
+			WalletId:      wallet.Id,
+			TransactionId: transaction.Id,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if voided.Status != models.TransactionStatusVoided {
+			t.Errorf("Expected transaction to be voided, got %v", voided.Status)
+		}
+
+		wallet, err = repos.Wallet.FindById(t.Context(), wallet.Id)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if wallet.Balance.Value != 0 {
+			t.Errorf("Expected wallet balance to be 0, got %v", wallet.Balance.Value)
+		}
+
+		if len(wallet.Transactions) != 1 {
+			t.Errorf("Expected wallet to keep 1 transaction, got %v", len(wallet.Transactions))
+		}
+
+		_, err = useCases.VoidTransaction(t.Context(), usecases.VoidTransactionUseCaseInput{
+			MemberId:      user.Id,
+			WalletId:      wallet.Id,
+			TransactionId: transaction.Id,
+		})
+		if !errors.Is(err, errx.ErrTransactionNotActive) {
+			t.Errorf("Expected transaction not active error, got %v", err)
+		}
+	})
+}
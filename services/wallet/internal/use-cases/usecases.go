@@ -1,7 +1,12 @@
 package usecases
 
 import (
+	"context"
+	"errors"
+
 	"github.com/lopesgabriel/tellawl/packages/logger"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/errx"
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
 	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/repository"
 	"go.opentelemetry.io/otel/trace"
 )
@@ -25,3 +30,64 @@ func NewUseCases(args NewUseCasesArgs) *UseCase {
 		logger: args.Logger,
 	}
 }
+
+// maxWalletSaveAttempts is how many times a use case loads and saves a wallet
+// before giving up on errx.ErrWalletConflict.
+const maxWalletSaveAttempts = 3
+
+// retryOnConflict runs attempt again while another request saves the wallet
+// first. Each attempt must load the wallet again.
+func retryOnConflict(ctx context.Context, attempt func() error) error {
+	var err error
+	for range maxWalletSaveAttempts {
+		err = attempt()
+		if !errors.Is(err, errx.ErrWalletConflict) || ctx.Err() != nil {
+			return err
+		}
+	}
+
+	return err
+}
+
+// amountOffset is the offset of an amount sent by a client. Amounts without
+// one are expressed in the minor unit of their currency, the wallet currency
+// when none is informed, never in the offset of the balance, which grows
+// finer as finer amounts are summed into it.
+func amountOffset(offset int, currency string, wallet *models.Wallet) int {
+	if offset != 0 {
+		return offset
+	}
+
+	if currency == "" {
+		currency = wallet.Balance.Currency
+	}
+	return models.CurrencyOffset(currency)
+}
+
+// resolveMember returns the member already loaded by the caller or, when it
+// is not available, looks it up by id.
+func (usecase *UseCase) resolveMember(ctx context.Context, memberId string, member *models.Member) (*models.Member, error) {
+	if member != nil {
+		return member, nil
+	}
+
+	return usecase.repos.Member.FindByID(ctx, memberId)
+}
+
+// checkWalletPermission verifies the member permission on a wallet without
+// loading the whole aggregate.
+func (usecase *UseCase) checkWalletPermission(ctx context.Context, walletId, memberId string, permission models.WalletPermission) error {
+	role, err := usecase.repos.Wallet.FindMemberRole(ctx, walletId, memberId)
+	if errors.Is(err, errx.ErrMemberNotInWallet) {
+		return errx.ErrInsufficientPermissions
+	}
+	if err != nil {
+		return err
+	}
+
+	if !role.Allows(permission) {
+		return errx.ErrInsufficientPermissions
+	}
+
+	return nil
+}
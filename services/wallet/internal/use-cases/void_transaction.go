@@ -0,0 +1,37 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/lopesgabriel/tellawl/services/wallet/internal/domain/models"
+)
+
+type VoidTransactionUseCaseInput struct {
+	MemberId      string
+	Member        *models.Member
+	WalletId      string
+	TransactionId string
+}
+
+func (usecase *UseCase) VoidTransaction(ctx context.Context, input VoidTransactionUseCaseInput) (*models.Transaction, error) {
+	member, err := usecase.resolveMember(ctx, input.MemberId, input.Member)
+	if err != nil {
+		return nil, err
+	}
+
+	wallet, err := usecase.repos.Wallet.FindById(ctx, input.WalletId)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction, err := wallet.VoidTransaction(input.TransactionId, *member)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := usecase.repos.Wallet.Save(ctx, wallet); err != nil {
+		return nil, err
+	}
+
+	return transaction, nil
+}